@@ -1,56 +1,45 @@
 package main
 
 import (
-	"grovia/configs"
-	"grovia/internal/firebase"
-	"grovia/internal/repositories"
-	"grovia/internal/routes"
-	"grovia/internal/services"
-
-	// "grovia/migrations"
-	"grovia/migrations/seeds"
-	"log"
-
-	"github.com/gofiber/fiber/v2"
-	"gorm.io/gorm"
+	"fmt"
+	"os"
 )
 
-func main() {
-	firebase.InitFirebase()
-
-	cfg := configs.LoadConfig()
+const usage = `Usage: grovia <command> [arguments]
 
-	configs.DBInitiator()
-	db := configs.DBConnections
+Commands:
+  serve                          start the API server
+  migrate up                     apply all pending migrations
+  migrate down [-steps=N]        roll back the latest N migrations (default 1)
+  migrate status                 list migrations and when they were applied
+  migrate redo                   roll back the latest migration and apply it again
+  seed --profile=admin|demo      insert the admin user, or demo data as well
+`
 
-	// migrations.Initiator(db)
-	// seeds.SeedDefaultLocation(db)
-	seeds.SeedAdmin(db)
-	RunAllSeeds(db)
-
-	s3 := services.NewS3Service(cfg.Aws)
-	predictRepo := repositories.NewPredictRepository(db)
-	predictService := services.NewPredictService(predictRepo, cfg.MLAPIURL)
-
-	InitiateRoutes(db, s3, predictService, cfg.MLAPIURL)
-}
-
-func InitiateRoutes(db *gorm.DB, s3 *services.S3Service, predict services.PredictService, mlAPIURL string) {
-	app := fiber.New()
-
-	routes.AuthRouter(db, app)
-	routes.LocationRouter(app, db, s3)
-	routes.ParentRouter(db, app)
-	routes.PredictRouter(db, app, mlAPIURL)
-	routes.ToddlerRouter(db, app, s3, predict)
-	routes.UserRouter(app, db, s3)
-
-	log.Fatal(app.Listen(":8080"))
-}
-
-func RunAllSeeds(db *gorm.DB) {
-	locations := seeds.SeedLocations(db)
-	parents := seeds.SeedParents(db, locations)
-	seeds.SeedToddlers(db, locations, parents)
-	seeds.SeedUsers(db, locations)
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "serve":
+		err = serve()
+	case "migrate":
+		err = runMigrate(os.Args[2:])
+	case "seed":
+		err = runSeed(os.Args[2:])
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
 }
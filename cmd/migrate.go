@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"grovia/configs"
+	"grovia/migrations"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+// runMigrate handles `migrate up|down|status|redo`.
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New("migrate needs a subcommand: up, down, status or redo")
+	}
+
+	action, args := args[0], args[1:]
+
+	flags := flag.NewFlagSet("migrate "+action, flag.ContinueOnError)
+	steps := 1
+	if action == "down" {
+		flags.IntVar(&steps, "steps", 1, "number of migrations to roll back")
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+
+	switch action {
+	case "up", "down", "status", "redo":
+	default:
+		return fmt.Errorf("unknown migrate subcommand %q (use up, down, status or redo)", action)
+	}
+	if steps < 1 {
+		return errors.New("-steps must be at least 1")
+	}
+
+	configs.LoadConfig()
+	configs.DBInitiator()
+	db := configs.DBConnections
+
+	switch action {
+	case "up":
+		n, err := migrations.Up(db)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Applied %d migrations\n", n)
+	case "down":
+		n, err := migrations.Down(db, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Rolled back %d migrations\n", n)
+	case "redo":
+		id, err := migrations.Redo(db)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Reapplied %s\n", id)
+	case "status":
+		statuses, err := migrations.Statuses(db)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "MIGRATION\tAPPLIED AT")
+		for _, s := range statuses {
+			applied := "pending"
+			if s.AppliedAt != nil {
+				applied = s.AppliedAt.Local().Format(time.DateTime)
+			}
+			fmt.Fprintf(w, "%s\t%s\n", s.ID, applied)
+		}
+		return w.Flush()
+	}
+
+	return nil
+}
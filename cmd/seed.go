@@ -0,0 +1,33 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"grovia/configs"
+	"grovia/migrations/seeds"
+)
+
+// runSeed handles `seed --profile=admin|demo`. There is no default
+// profile, so demo data is only ever inserted when asked for by name.
+func runSeed(args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	profile := flags.String("profile", "", "seed profile: admin or demo")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+	if *profile == "" {
+		return errors.New("seed needs --profile=admin or --profile=demo")
+	}
+	if err := seeds.CheckProfile(*profile); err != nil {
+		return err
+	}
+
+	configs.LoadConfig()
+	configs.DBInitiator()
+
+	return seeds.Run(configs.DBConnections, *profile)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"grovia/configs"
+	"grovia/internal/jobs"
+	"grovia/internal/middlewares"
+	"grovia/internal/repositories"
+	"grovia/internal/routes"
+	"grovia/internal/services"
+	"grovia/internal/storage"
+	"grovia/migrations"
+	"grovia/pkg"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// serve starts the API. It neither migrates nor seeds; it only warns when
+// the schema is behind the binary.
+func serve() error {
+	cfg := configs.LoadConfig()
+
+	configs.DBInitiator()
+	db := configs.DBConnections
+
+	if pending, err := migrations.Pending(db); err != nil {
+		log.Printf("could not check migrations: %v", err)
+	} else if pending > 0 {
+		log.Printf("%d migrations are pending, run `grovia migrate up`", pending)
+	}
+
+	store, err := storage.New(cfg.Storage, cfg.Aws)
+	if err != nil {
+		return fmt.Errorf("initializing storage: %w", err)
+	}
+
+	predictRepo := repositories.NewPredictRepository(db)
+	toddlerRepo := repositories.NewToddlerRepository(db)
+	parentRepo := repositories.NewParentRepository(db)
+	previewRepo := repositories.NewGroupPredictPreviewRepository(db)
+	jobRepo := repositories.NewJobRepository(db)
+	jobService := services.NewJobService(jobRepo)
+	auditService := services.NewAuditService(repositories.NewAuditRepository(db))
+	var shadow services.ShadowEvaluator
+	if cfg.ShadowMLAPIURL != "" {
+		shadow = services.NewShadowEvaluator(services.NewMLClient(cfg.ShadowMLAPIURL), repositories.NewShadowPredictionRepository(db))
+		shadow.Start(context.Background())
+	}
+
+	predictService, err := services.NewPredictService(predictRepo, toddlerRepo, parentRepo, previewRepo, jobService, auditService, services.NewMLClient(cfg.MLAPIURL), cfg.Predictor, shadow)
+	if err != nil {
+		return fmt.Errorf("initializing predict service: %w", err)
+	}
+
+	verifier, err := services.NewPhoneVerifier(cfg.Verification, repositories.NewPhoneOTPRepository(db))
+	if err != nil {
+		return fmt.Errorf("initializing phone verifier: %w", err)
+	}
+
+	runner := jobs.NewRunner(jobRepo, cfg.JobWorkers)
+	runner.Register(pkg.JobGroupPredict, predictService.RunGroupPredictJob)
+	runner.Start(context.Background())
+
+	return InitiateRoutes(db, store, predictService, jobService, auditService, verifier)
+}
+
+func InitiateRoutes(db *gorm.DB, store storage.ObjectStorage, predict services.PredictService, job services.JobService, audit services.AuditService, verifier services.PhoneVerifier) error {
+	app := fiber.New()
+
+	app.Use(middlewares.RequestMeta())
+
+	routes.AuthRouter(db, app, verifier, audit)
+	routes.LocationRouter(app, db, store, audit)
+	routes.ParentRouter(db, app, store, audit)
+	routes.PredictRouter(app, predict)
+	routes.ToddlerRouter(db, app, store, predict, audit)
+	routes.JobRouter(app, job)
+	routes.ReportRouter(db, app)
+	routes.SessionRouter(app, db, audit)
+	routes.ImmunizationRouter(app, db, audit)
+	routes.PermissionRouter(app)
+	routes.AuditRouter(app, audit)
+	routes.MeRouter(db, app, store, predict, audit)
+	routes.FileRouter(app, store)
+	routes.UserRouter(app, db, store, audit)
+
+	return app.Listen(":8080")
+}
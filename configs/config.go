@@ -11,7 +11,31 @@ import (
 
 type AppConfig struct {
 	MLAPIURL string
-	Aws      AwsConfig
+	// ShadowMLAPIURL is a candidate ML API evaluated next to the primary
+	// one without affecting recorded statuses. Empty disables it.
+	ShadowMLAPIURL string
+	Predictor      string
+	JobWorkers     int
+	Aws            AwsConfig
+	Storage        StorageConfig
+	Verification   VerificationConfig
+}
+
+// StorageConfig selects the file storage. BaseURL prefixes the signed
+// URLs of the local and memory drivers; without it they are relative.
+type StorageConfig struct {
+	Driver        string
+	LocalDir      string
+	BaseURL       string
+	SigningSecret string
+}
+
+type VerificationConfig struct {
+	PhoneVerifier       string
+	FirebaseCredentials string
+	SMSGateway          string
+	SMSGatewayURL       string
+	SMSGatewayAPIKey    string
 }
 
 type AwsConfig struct {
@@ -35,12 +59,28 @@ func LoadConfig() *AppConfig {
 	}
 
 	return &AppConfig{
-		MLAPIURL: viper.GetString("ml_api_url"),
+		MLAPIURL:       viper.GetString("ml_api_url"),
+		ShadowMLAPIURL: viper.GetString("shadow_ml_api_url"),
+		Predictor:      viper.GetString("predictor"),
+		JobWorkers:     viper.GetInt("job_workers"),
 		Aws: AwsConfig{
 			Region:    os.Getenv("AWS_REGION"),
 			Bucket:    os.Getenv("AWS_S3_BUCKET"),
 			AccessKey: os.Getenv("AWS_ACCESS_KEY_ID"),
 			SecretKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
 		},
+		Storage: StorageConfig{
+			Driver:        viper.GetString("storage_driver"),
+			LocalDir:      viper.GetString("storage_local_dir"),
+			BaseURL:       viper.GetString("storage_base_url"),
+			SigningSecret: os.Getenv("STORAGE_SIGNING_SECRET"),
+		},
+		Verification: VerificationConfig{
+			PhoneVerifier:       viper.GetString("phone_verifier"),
+			FirebaseCredentials: viper.GetString("firebase_credentials"),
+			SMSGateway:          viper.GetString("sms_gateway"),
+			SMSGatewayURL:       os.Getenv("SMS_GATEWAY_URL"),
+			SMSGatewayAPIKey:    os.Getenv("SMS_GATEWAY_API_KEY"),
+		},
 	}
 }
@@ -0,0 +1,311 @@
+// Package charts renders KMS (Kartu Menuju Sehat) style growth charts: a
+// child's measurements drawn over the WHO z-score bands for their sex.
+package charts
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"sort"
+
+	"grovia/internal/growth"
+)
+
+const (
+	width        = 800
+	height       = 500
+	marginLeft   = 60
+	marginRight  = 20
+	marginTop    = 40
+	marginBottom = 50
+	maxAge       = growth.MaxAgeMonths
+	sampleStep   = 0.5
+)
+
+// Point is one measurement placed on the chart.
+type Point struct {
+	AgeMonths float64
+	Value     float64
+}
+
+// Chart is a single indicator chart for one child.
+type Chart struct {
+	Indicator growth.Indicator
+	Sex       growth.Sex
+	Title     string
+	Points    []Point
+}
+
+type band struct {
+	lower, upper float64
+	fill         color.RGBA
+}
+
+// bands follow the KMS colouring: green around the median, yellow for the
+// -3..-2 and +2..+3 SD areas and red beyond -3 SD.
+var bands = []band{
+	{-4, -3, color.RGBA{0xf2, 0x8b, 0x82, 0xff}},
+	{-3, -2, color.RGBA{0xfd, 0xe6, 0x8a, 0xff}},
+	{-2, -1, color.RGBA{0xbb, 0xf7, 0xd0, 0xff}},
+	{-1, 1, color.RGBA{0x86, 0xef, 0xac, 0xff}},
+	{1, 2, color.RGBA{0xbb, 0xf7, 0xd0, 0xff}},
+	{2, 3, color.RGBA{0xfd, 0xe6, 0x8a, 0xff}},
+	{3, 4, color.RGBA{0xfd, 0xba, 0x74, 0xff}},
+}
+
+var (
+	boyColor   = color.RGBA{0x1d, 0x4e, 0xd8, 0xff}
+	girlColor  = color.RGBA{0xdb, 0x27, 0x77, 0xff}
+	gridColor  = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	pointColor = color.RGBA{0x11, 0x18, 0x27, 0xff}
+)
+
+var zLines = []float64{-3, -2, 0, 2, 3}
+
+type layout struct {
+	minValue, maxValue float64
+}
+
+func (c Chart) accent() color.RGBA {
+	if c.Sex == growth.Female {
+		return girlColor
+	}
+	return boyColor
+}
+
+func (c Chart) unit() string {
+	if c.Indicator == growth.IndicatorWeightForAge {
+		return "kg"
+	}
+	return "cm"
+}
+
+func (c Chart) layout() (layout, error) {
+	lo, err := growth.Reference(c.Indicator, c.Sex, 0)
+	if err != nil {
+		return layout{}, err
+	}
+	hi, err := growth.Reference(c.Indicator, c.Sex, maxAge)
+	if err != nil {
+		return layout{}, err
+	}
+
+	l := layout{minValue: lo.ValueAt(-4), maxValue: hi.ValueAt(4)}
+	for _, p := range c.Points {
+		l.minValue = math.Min(l.minValue, p.Value)
+		l.maxValue = math.Max(l.maxValue, p.Value)
+	}
+
+	l.minValue = math.Floor(l.minValue/5) * 5
+	l.maxValue = math.Ceil(l.maxValue/5) * 5
+
+	return l, nil
+}
+
+func (l layout) x(age float64) float64 {
+	return marginLeft + age/maxAge*(width-marginLeft-marginRight)
+}
+
+func (l layout) y(value float64) float64 {
+	plot := float64(height - marginTop - marginBottom)
+	return marginTop + plot - (value-l.minValue)/(l.maxValue-l.minValue)*plot
+}
+
+// curve samples the reference value of z-score z from birth to 60 months.
+func (c Chart) curve(z float64) ([]Point, error) {
+	var points []Point
+	for age := 0.0; age <= maxAge; age += sampleStep {
+		lms, err := growth.Reference(c.Indicator, c.Sex, age)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, Point{AgeMonths: age, Value: lms.ValueAt(z)})
+	}
+	return points, nil
+}
+
+func (c Chart) sortedPoints() []Point {
+	points := make([]Point, len(c.Points))
+	copy(points, c.Points)
+	sort.Slice(points, func(i, j int) bool { return points[i].AgeMonths < points[j].AgeMonths })
+	return points
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// SVG renders the chart as a standalone SVG document.
+func (c Chart) SVG() ([]byte, error) {
+	l, err := c.layout()
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="11">`, width, height, width, height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#ffffff"/>`, width, height)
+	fmt.Fprintf(&b, `<text x="%d" y="24" font-size="15" font-weight="bold" fill="%s">%s</text>`, marginLeft, hex(c.accent()), html.EscapeString(c.Title))
+
+	for _, bd := range bands {
+		lower, err := c.curve(bd.lower)
+		if err != nil {
+			return nil, err
+		}
+		upper, err := c.curve(bd.upper)
+		if err != nil {
+			return nil, err
+		}
+
+		b.WriteString(`<path d="`)
+		for i, p := range upper {
+			cmd := "L"
+			if i == 0 {
+				cmd = "M"
+			}
+			fmt.Fprintf(&b, "%s%.1f %.1f ", cmd, l.x(p.AgeMonths), l.y(p.Value))
+		}
+		for i := len(lower) - 1; i >= 0; i-- {
+			fmt.Fprintf(&b, "L%.1f %.1f ", l.x(lower[i].AgeMonths), l.y(lower[i].Value))
+		}
+		fmt.Fprintf(&b, `Z" fill="%s"/>`, hex(bd.fill))
+	}
+
+	for _, z := range zLines {
+		line, err := c.curve(z)
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString(`<polyline fill="none" stroke="#4b5563" stroke-width="0.8" points="`)
+		for _, p := range line {
+			fmt.Fprintf(&b, "%.1f,%.1f ", l.x(p.AgeMonths), l.y(p.Value))
+		}
+		b.WriteString(`"/>`)
+		last := line[len(line)-1]
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="end" fill="#374151">%+g</text>`, l.x(last.AgeMonths)-2, l.y(last.Value)-3, z)
+	}
+
+	for age := 0; age <= maxAge; age += 6 {
+		x := l.x(float64(age))
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="#ffffff" stroke-width="0.6"/>`, x, marginTop, x, height-marginBottom)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="middle">%d</text>`, x, height-marginBottom+16, age)
+	}
+	for v := l.minValue; v <= l.maxValue; v += 5 {
+		y := l.y(v)
+		fmt.Fprintf(&b, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#ffffff" stroke-width="0.6"/>`, marginLeft, y, width-marginRight, y)
+		fmt.Fprintf(&b, `<text x="%d" y="%.1f" text-anchor="end">%g</text>`, marginLeft-6, y+4, v)
+	}
+
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle">Umur (bulan)</text>`, (width+marginLeft)/2, height-12)
+	fmt.Fprintf(&b, `<text x="16" y="%d" text-anchor="middle" transform="rotate(-90 16 %d)">%s</text>`, height/2, height/2, c.unit())
+	fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="none" stroke="%s" stroke-width="2"/>`,
+		marginLeft, marginTop, width-marginLeft-marginRight, height-marginTop-marginBottom, hex(c.accent()))
+
+	points := c.sortedPoints()
+	if len(points) > 1 {
+		b.WriteString(`<polyline fill="none" stroke="#111827" stroke-width="2" points="`)
+		for _, p := range points {
+			fmt.Fprintf(&b, "%.1f,%.1f ", l.x(p.AgeMonths), l.y(p.Value))
+		}
+		b.WriteString(`"/>`)
+	}
+	for _, p := range points {
+		fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="4" fill="%s"><title>%g bulan: %g %s</title></circle>`,
+			l.x(p.AgeMonths), l.y(p.Value), hex(pointColor), p.AgeMonths, p.Value, c.unit())
+	}
+
+	b.WriteString(`</svg>`)
+	return b.Bytes(), nil
+}
+
+// PNG renders the same chart as a raster image. Labels are only drawn in
+// the SVG variant.
+func (c Chart) PNG() ([]byte, error) {
+	l, err := c.layout()
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	fillRect(img, 0, 0, width, height, color.RGBA{0xff, 0xff, 0xff, 0xff})
+
+	for px := marginLeft; px <= width-marginRight; px++ {
+		age := (float64(px) - marginLeft) / float64(width-marginLeft-marginRight) * maxAge
+		lms, err := growth.Reference(c.Indicator, c.Sex, age)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, bd := range bands {
+			top := int(math.Max(l.y(lms.ValueAt(bd.upper)), marginTop))
+			bottom := int(math.Min(l.y(lms.ValueAt(bd.lower)), height-marginBottom))
+			fillRect(img, px, top, px+1, bottom, bd.fill)
+		}
+
+		for _, z := range zLines {
+			img.Set(px, int(l.y(lms.ValueAt(z))), color.RGBA{0x4b, 0x55, 0x63, 0xff})
+		}
+	}
+
+	for age := 0; age <= maxAge; age += 6 {
+		x := int(l.x(float64(age)))
+		fillRect(img, x, marginTop, x+1, height-marginBottom, gridColor)
+	}
+	for v := l.minValue; v <= l.maxValue; v += 5 {
+		y := int(l.y(v))
+		fillRect(img, marginLeft, y, width-marginRight, y+1, gridColor)
+	}
+
+	accent := c.accent()
+	fillRect(img, marginLeft, marginTop, width-marginRight, marginTop+2, accent)
+	fillRect(img, marginLeft, height-marginBottom-2, width-marginRight, height-marginBottom, accent)
+	fillRect(img, marginLeft, marginTop, marginLeft+2, height-marginBottom, accent)
+	fillRect(img, width-marginRight-2, marginTop, width-marginRight, height-marginBottom, accent)
+
+	points := c.sortedPoints()
+	for i := 1; i < len(points); i++ {
+		drawLine(img, l.x(points[i-1].AgeMonths), l.y(points[i-1].Value), l.x(points[i].AgeMonths), l.y(points[i].Value), pointColor)
+	}
+	for _, p := range points {
+		drawDot(img, l.x(p.AgeMonths), l.y(p.Value), 4, pointColor)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func fillRect(img *image.RGBA, x0, y0, x1, y1 int, c color.RGBA) {
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+}
+
+func drawLine(img *image.RGBA, x0, y0, x1, y1 float64, c color.RGBA) {
+	steps := int(math.Max(math.Abs(x1-x0), math.Abs(y1-y0)))
+	for i := 0; i <= steps; i++ {
+		t := 0.0
+		if steps > 0 {
+			t = float64(i) / float64(steps)
+		}
+		drawDot(img, x0+(x1-x0)*t, y0+(y1-y0)*t, 1, c)
+	}
+}
+
+func drawDot(img *image.RGBA, cx, cy, r float64, c color.RGBA) {
+	for y := int(cy - r); y <= int(cy+r); y++ {
+		for x := int(cx - r); x <= int(cx+r); x++ {
+			if (float64(x)-cx)*(float64(x)-cx)+(float64(y)-cy)*(float64(y)-cy) <= r*r {
+				img.SetRGBA(x, y, c)
+			}
+		}
+	}
+}
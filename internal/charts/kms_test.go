@@ -0,0 +1,164 @@
+package charts
+
+import (
+	"bytes"
+	"image/png"
+	"math"
+	"strings"
+	"testing"
+
+	"grovia/internal/growth"
+)
+
+func TestCurveSamplesEveryHalfMonth(t *testing.T) {
+	c := Chart{Indicator: growth.IndicatorHeightForAge, Sex: growth.Male}
+
+	median, err := c.curve(0)
+	if err != nil {
+		t.Fatalf("curve: %v", err)
+	}
+
+	if want := int(maxAge/sampleStep) + 1; len(median) != want {
+		t.Fatalf("len = %d, want %d", len(median), want)
+	}
+	if median[0].AgeMonths != 0 || median[len(median)-1].AgeMonths != maxAge {
+		t.Fatalf("ages span %v..%v, want 0..%d", median[0].AgeMonths, median[len(median)-1].AgeMonths, maxAge)
+	}
+
+	birth, _ := growth.Reference(growth.IndicatorHeightForAge, growth.Male, 0)
+	if median[0].Value != birth.M {
+		t.Errorf("median at birth = %v, want %v", median[0].Value, birth.M)
+	}
+
+	for i := 1; i < len(median); i++ {
+		if median[i].AgeMonths-median[i-1].AgeMonths != sampleStep {
+			t.Fatalf("step at %v months is not %v", median[i].AgeMonths, sampleStep)
+		}
+	}
+}
+
+func TestCurvesAreOrderedByZScore(t *testing.T) {
+	for _, indicator := range []growth.Indicator{growth.IndicatorHeightForAge, growth.IndicatorWeightForAge} {
+		for _, sex := range []growth.Sex{growth.Male, growth.Female} {
+			c := Chart{Indicator: indicator, Sex: sex}
+
+			var prev []Point
+			for _, z := range []float64{-4, -3, -2, -1, 0, 1, 2, 3, 4} {
+				curr, err := c.curve(z)
+				if err != nil {
+					t.Fatalf("%s %s curve(%v): %v", indicator, sex, z, err)
+				}
+				for i := range prev {
+					if curr[i].Value <= prev[i].Value {
+						t.Fatalf("%s %s: z=%v is not above the previous curve at %v months", indicator, sex, z, curr[i].AgeMonths)
+					}
+				}
+				prev = curr
+			}
+		}
+	}
+}
+
+func TestCurveRejectsUnknownIndicator(t *testing.T) {
+	c := Chart{Indicator: growth.Indicator("bmi"), Sex: growth.Male}
+	if _, err := c.curve(0); err == nil {
+		t.Fatal("expected an error for an indicator without an age table")
+	}
+}
+
+func TestLayoutCoversBandsAndPoints(t *testing.T) {
+	c := Chart{
+		Indicator: growth.IndicatorWeightForAge,
+		Sex:       growth.Female,
+		Points:    []Point{{AgeMonths: 12, Value: 3}, {AgeMonths: 24, Value: 40}},
+	}
+
+	l, err := c.layout()
+	if err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+
+	if math.Mod(l.minValue, 5) != 0 || math.Mod(l.maxValue, 5) != 0 {
+		t.Errorf("axis %v..%v is not rounded to 5", l.minValue, l.maxValue)
+	}
+	if l.minValue > 0 || l.maxValue < 40 {
+		t.Errorf("axis %v..%v does not contain the points", l.minValue, l.maxValue)
+	}
+	if l.y(l.minValue) != height-marginBottom || l.y(l.maxValue) != marginTop {
+		t.Errorf("y maps the axis to %v..%v", l.y(l.minValue), l.y(l.maxValue))
+	}
+	if l.x(0) != marginLeft || l.x(maxAge) != width-marginRight {
+		t.Errorf("x maps the ages to %v..%v", l.x(0), l.x(maxAge))
+	}
+}
+
+func TestSortedPointsDoesNotReorderChart(t *testing.T) {
+	c := Chart{Points: []Point{{AgeMonths: 6, Value: 7}, {AgeMonths: 2, Value: 5}, {AgeMonths: 4, Value: 6}}}
+
+	sorted := c.sortedPoints()
+
+	for i, want := range []float64{2, 4, 6} {
+		if sorted[i].AgeMonths != want {
+			t.Errorf("sorted[%d] = %v months, want %v", i, sorted[i].AgeMonths, want)
+		}
+	}
+	if c.Points[0].AgeMonths != 6 {
+		t.Error("sortedPoints reordered the chart's points")
+	}
+}
+
+func TestSVG(t *testing.T) {
+	c := Chart{
+		Indicator: growth.IndicatorHeightForAge,
+		Sex:       growth.Female,
+		Title:     "Ani <TB/U>",
+		Points:    []Point{{AgeMonths: 6, Value: 65}, {AgeMonths: 3, Value: 60}},
+	}
+
+	out, err := c.SVG()
+	if err != nil {
+		t.Fatalf("SVG: %v", err)
+	}
+	svg := string(out)
+
+	if !strings.HasPrefix(svg, "<svg") || !strings.HasSuffix(svg, "</svg>") {
+		t.Fatal("output is not a standalone SVG document")
+	}
+	if !strings.Contains(svg, "Ani &lt;TB/U&gt;") {
+		t.Error("title is not escaped")
+	}
+	if got := strings.Count(svg, "<path "); got != len(bands) {
+		t.Errorf("%d bands drawn, want %d", got, len(bands))
+	}
+	if got := strings.Count(svg, "<polyline "); got != len(zLines)+1 {
+		t.Errorf("%d polylines drawn, want %d z-lines and the child's line", got, len(zLines))
+	}
+	if got := strings.Count(svg, "<circle "); got != 2 {
+		t.Errorf("%d points drawn, want 2", got)
+	}
+	if !strings.Contains(svg, hex(girlColor)) {
+		t.Error("girl chart does not use the girl accent colour")
+	}
+}
+
+func TestPNG(t *testing.T) {
+	c := Chart{Indicator: growth.IndicatorWeightForAge, Sex: growth.Male, Points: []Point{{AgeMonths: 12, Value: 9.6}}}
+
+	out, err := c.PNG()
+	if err != nil {
+		t.Fatalf("PNG: %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != width || b.Dy() != height {
+		t.Errorf("size = %dx%d, want %dx%d", b.Dx(), b.Dy(), width, height)
+	}
+
+	r, g, b, _ := img.At(marginLeft+1, marginTop).RGBA()
+	if uint8(r>>8) != boyColor.R || uint8(g>>8) != boyColor.G || uint8(b>>8) != boyColor.B {
+		t.Error("boy chart frame is not drawn in the boy accent colour")
+	}
+}
@@ -0,0 +1,12 @@
+package requests
+
+type AuditLogQuery struct {
+	Entity   string `query:"entity"`
+	EntityID int    `query:"entityId"`
+	ActorID  int    `query:"actorId"`
+	Action   string `query:"action" validate:"omitempty,oneof=create update delete"`
+	From     string `query:"from"`
+	To       string `query:"to"`
+	Page     int    `query:"page"`
+	Limit    int    `query:"limit"`
+}
@@ -3,9 +3,17 @@ package requests
 type LoginRequest struct {
 	PhoneNumber string `json:"phoneNumber" validate:"required,phone"`
 	Password    string `json:"password" validate:"required,min=6"`
+	Device      string `json:"device" validate:"omitempty,max=255"`
 }
 
+type SendResetCodeRequest struct {
+	PhoneNumber string `json:"phoneNumber" validate:"required,phone"`
+}
+
+// ResetPasswordRequest carries the proof from the configured phone
+// verifier: Code for the OTP verifier, FirebaseToken for Firebase.
 type ResetPasswordRequest struct {
+	Code            string `json:"code"`
 	FirebaseToken   string `json:"firebaseToken"`
 	PhoneNumber     string `json:"phoneNumber" validate:"required,phone"`
 	Password        string `json:"password" validate:"required,min=6"`
@@ -15,3 +23,15 @@ type ResetPasswordRequest struct {
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refreshToken" validate:"required"`
 }
+
+type ParentSendCodeRequest struct {
+	PhoneNumber string `json:"phoneNumber" validate:"required,phone"`
+}
+
+// ParentLoginRequest carries the proof from the configured phone verifier,
+// like ResetPasswordRequest.
+type ParentLoginRequest struct {
+	PhoneNumber   string `json:"phoneNumber" validate:"required,phone"`
+	Code          string `json:"code"`
+	FirebaseToken string `json:"firebaseToken"`
+}
@@ -0,0 +1,18 @@
+package requests
+
+type CreateImmunizationRequest struct {
+	ToddlerID   int     `json:"toddlerID" validate:"required,min=1"`
+	VaccineCode string  `json:"vaccineCode" validate:"required"`
+	GivenAt     *string `json:"givenAt,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	SessionID   *int    `json:"sessionID,omitempty" validate:"omitempty,min=1"`
+	BatchNumber *string `json:"batchNumber,omitempty" validate:"omitempty,max=50"`
+	Notes       *string `json:"notes,omitempty" validate:"omitempty,max=500"`
+}
+
+// DueImmunizationQuery filters the doses toddlers of a location still
+// need.
+type DueImmunizationQuery struct {
+	LocationID string `query:"locationId"`
+	Status     string `query:"status" validate:"omitempty,oneof=due overdue"`
+	Vaccine    string `query:"vaccine"`
+}
@@ -3,7 +3,9 @@ package requests
 import "mime/multipart"
 
 type LocationRequest struct {
-	Name    string                `form:"name" validate:"required"`
-	Address string                `form:"address" validate:"required"`
-	Picture *multipart.FileHeader `form:"picture"`
+	Name         string                `form:"name" validate:"required"`
+	Address      string                `form:"address" validate:"required"`
+	Picture      *multipart.FileHeader `form:"picture"`
+	ScheduleDay  *int                  `form:"scheduleDay" validate:"omitempty,min=1,max=31"`
+	ScheduleTime *string               `form:"scheduleTime" validate:"omitempty,datetime=15:04"`
 }
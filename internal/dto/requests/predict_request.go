@@ -7,10 +7,28 @@ type CreateIndividualPredictRequest struct {
 	NutritionalStatus string  `json:"nutritionalStatus" validate:"required"`
 }
 
+// UpdatePredictRequest corrects a predict. Changing a measurement predicts
+// the status again; setting Zscore or NutritionalStatus overrides it by
+// hand and requires a Reason.
 type UpdatePredictRequest struct {
 	Height            *float64 `json:"height,omitempty" validate:"omitempty,height"`
+	Weight            *float64 `json:"weight,omitempty" validate:"omitempty,weight"`
+	HeadCircumference *float64 `json:"headCircumference,omitempty" validate:"omitempty,headcircumference"`
 	Age               *int     `json:"age,omitempty" validate:"omitempty,age"`
 	Sex               *string  `json:"sex,omitempty" validate:"omitempty,oneof=male female"`
 	Zscore            *float64 `json:"zscore,omitempty" validate:"omitempty"`
 	NutritionalStatus *string  `json:"nutritionalStatus,omitempty" validate:"omitempty"`
+	Reason            *string  `json:"reason,omitempty" validate:"omitempty,max=500"`
+}
+
+type ConfirmGroupPredictRequest struct {
+	PreviewToken string `json:"previewToken" validate:"required,uuid"`
+}
+
+// PredictQuery filters predict lists by where the status came from, so
+// classifications of one model version can be re-evaluated together.
+type PredictQuery struct {
+	Source       string `query:"source" validate:"omitempty,oneof=ml who manual"`
+	ModelName    string `query:"modelName"`
+	ModelVersion string `query:"modelVersion"`
 }
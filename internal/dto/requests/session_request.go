@@ -0,0 +1,11 @@
+package requests
+
+type OpenSessionRequest struct {
+	LocationID *int    `json:"locationID,omitempty" validate:"omitempty,min=1"`
+	Date       *string `json:"date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Notes      *string `json:"notes,omitempty" validate:"omitempty,max=500"`
+}
+
+type RecordAttendanceRequest struct {
+	ToddlerID int `json:"toddlerID" validate:"required,min=1"`
+}
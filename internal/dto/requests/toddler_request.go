@@ -10,6 +10,8 @@ type CreateToddlerRequest struct {
 	Birthdate         time.Time `json:"birthdate" validate:"required"`
 	Sex               string    `json:"sex" validate:"required,oneof=male female"`
 	Height            float64   `json:"height" validate:"required"`
+	Weight            float64   `json:"weight" validate:"omitempty,weight"`
+	HeadCircumference float64   `json:"headCircumference" validate:"omitempty,headcircumference"`
 	NutritionalStatus string    `json:"nutritionalStatus" validate:"required"`
 	LocationID        int       `json:"locationID" validate:"required"`
 	PhoneNumber       string    `json:"phoneNumber" validate:"required,phone"`
@@ -20,6 +22,8 @@ type UpdateToddlerRequest struct {
 	Birthdate         *time.Time            `form:"birthdate,omitempty" validate:"omitempty"`
 	Sex               string                `form:"sex,omitempty" validate:"omitempty,oneof=male female"`
 	Height            *float64              `form:"height,omitempty" validate:"omitempty,height"`
+	Weight            *float64              `form:"weight,omitempty" validate:"omitempty,weight"`
+	HeadCircumference *float64              `form:"headCircumference,omitempty" validate:"omitempty,headcircumference"`
 	ProfilePicture    *multipart.FileHeader `form:"profilePicture,omitempty"`
 	NutritionalStatus *string               `form:"nutritionalStatus,omitempty" validate:"omitempty"`
 	LocationID        *int                  `form:"locationID,omitempty" validate:"omitempty"`
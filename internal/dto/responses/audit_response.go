@@ -0,0 +1,20 @@
+package responses
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditLogResponse struct {
+	ID              int             `json:"id"`
+	ActorID         *int            `json:"actorId"`
+	ActorRole       string          `json:"actorRole"`
+	ActorLocationID *int            `json:"actorLocationId"`
+	Action          string          `json:"action"`
+	Entity          string          `json:"entity"`
+	EntityID        int             `json:"entityId"`
+	Changes         json.RawMessage `json:"changes,omitempty"`
+	IPAddress       string          `json:"ipAddress"`
+	UserAgent       string          `json:"userAgent"`
+	CreatedAt       time.Time       `json:"createdAt"`
+}
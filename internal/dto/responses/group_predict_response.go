@@ -0,0 +1,67 @@
+package responses
+
+import "time"
+
+const (
+	GroupRowCreated = "created"
+	GroupRowMatched = "matched"
+	GroupRowFailed  = "failed"
+)
+
+type GroupPredictRowResponse struct {
+	Row       int              `json:"row"`
+	Name      string           `json:"name"`
+	Status    string           `json:"status"`
+	ToddlerID *int             `json:"toddlerID,omitempty"`
+	Predict   *PredictResponse `json:"predict,omitempty"`
+	Errors    []string         `json:"errors,omitempty"`
+}
+
+type GroupPredictSummary struct {
+	Total       int `json:"total"`
+	Succeeded   int `json:"succeeded"`
+	Failed      int `json:"failed"`
+	NewToddlers int `json:"newToddlers"`
+}
+
+type GroupPredictResponse struct {
+	Summary  GroupPredictSummary       `json:"summary"`
+	Rows     []GroupPredictRowResponse `json:"rows"`
+	FileName string                    `json:"fileName"`
+	File     []byte                    `json:"file,omitempty"`
+}
+
+const (
+	GroupRowActionCreate = "create"
+	GroupRowActionMatch  = "match"
+)
+
+type GroupPredictPreviewRowResponse struct {
+	Row               int      `json:"row"`
+	Name              string   `json:"name"`
+	Age               *int     `json:"age,omitempty"`
+	Sex               string   `json:"sex"`
+	Height            float64  `json:"height"`
+	Weight            *float64 `json:"weight,omitempty"`
+	HeadCircumference *float64 `json:"headCircumference,omitempty"`
+	PhoneNumber       string   `json:"phoneNumber"`
+	ParentName        string   `json:"parentName,omitempty"`
+	ToddlerID         *int     `json:"toddlerID,omitempty"`
+	Action            string   `json:"action,omitempty"`
+	Errors            []string `json:"errors,omitempty"`
+}
+
+type GroupPredictPreviewSummary struct {
+	Total       int `json:"total"`
+	Valid       int `json:"valid"`
+	Invalid     int `json:"invalid"`
+	NewToddlers int `json:"newToddlers"`
+	Matched     int `json:"matched"`
+}
+
+type GroupPredictPreviewResponse struct {
+	PreviewToken string                           `json:"previewToken"`
+	ExpiresAt    time.Time                        `json:"expiresAt"`
+	Summary      GroupPredictPreviewSummary       `json:"summary"`
+	Rows         []GroupPredictPreviewRowResponse `json:"rows"`
+}
@@ -0,0 +1,14 @@
+package responses
+
+import "time"
+
+type GrowthFalteringResponse struct {
+	ToddlerID       int        `json:"toddlerID"`
+	Name            string     `json:"name"`
+	LocationID      int        `json:"locationID"`
+	Flags           []string   `json:"flags"`
+	ConsecutiveT    int        `json:"consecutiveT"`
+	LastMeasuredAt  *time.Time `json:"lastMeasuredAt"`
+	HfaZscoreChange *float64   `json:"hfaZscoreChange"`
+	WfaZscoreChange *float64   `json:"wfaZscoreChange"`
+}
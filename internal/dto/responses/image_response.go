@@ -0,0 +1,9 @@
+package responses
+
+// ImageVariantsResponse holds signed URLs of the resized copies of a
+// picture. Pictures uploaded before resizing existed have no copies, so
+// every size points at the picture itself.
+type ImageVariantsResponse struct {
+	Medium    string `json:"medium"`
+	Thumbnail string `json:"thumbnail"`
+}
@@ -0,0 +1,69 @@
+package responses
+
+import "time"
+
+type VaccineResponse struct {
+	ID               int    `json:"id"`
+	Code             string `json:"code"`
+	Name             string `json:"name"`
+	Antigen          string `json:"antigen"`
+	Dose             int    `json:"dose"`
+	MinAgeMonths     int    `json:"minAgeMonths"`
+	OverdueAgeMonths int    `json:"overdueAgeMonths"`
+	Basic            bool   `json:"basic"`
+}
+
+type ImmunizationResponse struct {
+	ID           int       `json:"id"`
+	ToddlerID    int       `json:"toddlerID"`
+	VaccineID    int       `json:"vaccineID"`
+	VaccineCode  string    `json:"vaccineCode"`
+	VaccineName  string    `json:"vaccineName"`
+	LocationID   int       `json:"locationID"`
+	SessionID    *int      `json:"sessionID"`
+	GivenAt      string    `json:"givenAt"`
+	BatchNumber  string    `json:"batchNumber"`
+	Notes        string    `json:"notes"`
+	RecordedByID int       `json:"recordedByID"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// ImmunizationScheduleResponse is one dose of a toddler's schedule, with
+// the record when it was given.
+type ImmunizationScheduleResponse struct {
+	Vaccine      VaccineResponse       `json:"vaccine"`
+	DueDate      string                `json:"dueDate"`
+	OverdueDate  string                `json:"overdueDate"`
+	Status       string                `json:"status"`
+	Immunization *ImmunizationResponse `json:"immunization"`
+}
+
+// ToddlerImmunizationResponse is a toddler's immunization card.
+type ToddlerImmunizationResponse struct {
+	ToddlerID   int                            `json:"toddlerID"`
+	Name        string                         `json:"name"`
+	Birthdate   time.Time                      `json:"birthdate"`
+	AgeMonths   int                            `json:"ageMonths"`
+	IDLComplete bool                           `json:"idlComplete"`
+	Schedule    []ImmunizationScheduleResponse `json:"schedule"`
+}
+
+// DueImmunizationResponse is a dose a toddler has reached the age for but
+// has not been given, with the parent to follow up with.
+type DueImmunizationResponse struct {
+	ToddlerID         int       `json:"toddlerID"`
+	Name              string    `json:"name"`
+	Birthdate         time.Time `json:"birthdate"`
+	Sex               string    `json:"sex"`
+	AgeMonths         int       `json:"ageMonths"`
+	LocationID        int       `json:"locationID"`
+	LocationName      string    `json:"locationName"`
+	ParentName        string    `json:"parentName"`
+	ParentPhoneNumber string    `json:"parentPhoneNumber"`
+	VaccineCode       string    `json:"vaccineCode"`
+	VaccineName       string    `json:"vaccineName"`
+	DueDate           string    `json:"dueDate"`
+	OverdueDate       string    `json:"overdueDate"`
+	Status            string    `json:"status"`
+}
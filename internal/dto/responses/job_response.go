@@ -0,0 +1,24 @@
+package responses
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type JobResponse struct {
+	ID             int             `json:"id"`
+	Type           string          `json:"type"`
+	Status         string          `json:"status"`
+	LocationID     int             `json:"locationID"`
+	CreatedByID    int             `json:"createdByID"`
+	Progress       int             `json:"progress"`
+	Total          int             `json:"total"`
+	Result         json.RawMessage `json:"result,omitempty"`
+	ResultFileName string          `json:"resultFileName,omitempty"`
+	Error          *string         `json:"error,omitempty"`
+	Attempts       int             `json:"attempts"`
+	StartedAt      *time.Time      `json:"startedAt,omitempty"`
+	FinishedAt     *time.Time      `json:"finishedAt,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	UpdatedAt      time.Time       `json:"updatedAt"`
+}
@@ -3,10 +3,22 @@ package responses
 import "time"
 
 type LocationResponse struct {
-	ID        int       `json:"id"`
-	Name      string    `json:"name"`
-	Address   string    `json:"address"`
-	Picture   string    `json:"picture"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID              int                    `json:"id"`
+	Name            string                 `json:"name"`
+	Address         string                 `json:"address"`
+	Picture         string                 `json:"picture"`
+	PictureVariants *ImageVariantsResponse `json:"pictureVariants"`
+	ScheduleDay     *int                   `json:"scheduleDay"`
+	ScheduleTime    *string                `json:"scheduleTime"`
+	CreatedAt       time.Time              `json:"createdAt"`
+	UpdatedAt       time.Time              `json:"updatedAt"`
+}
+
+// ScheduleResponse lists the next posyandu days of a location.
+type ScheduleResponse struct {
+	LocationID   int         `json:"locationId"`
+	LocationName string      `json:"locationName"`
+	Address      string      `json:"address"`
+	ScheduleTime *string     `json:"scheduleTime"`
+	Dates        []time.Time `json:"dates"`
 }
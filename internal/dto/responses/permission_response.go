@@ -0,0 +1,11 @@
+package responses
+
+type RolePermissionResponse struct {
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
+type PermissionMatrixResponse struct {
+	Permissions []string                 `json:"permissions"`
+	Roles       []RolePermissionResponse `json:"roles"`
+}
@@ -1,17 +1,59 @@
 package responses
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type PredictResponse struct {
-	ID                int       `json:"id"`
-	ToddlerID         int       `json:"toddlerID"`
-	CreatedByID       int       `json:"createdByID"`
-	Name              string    `json:"name"`
-	Height            float64   `json:"height"`
-	Age               int       `json:"age"`
-	Sex               string    `json:"sex"`
-	Zscore            float64   `json:"zscore"`
-	NutritionalStatus string    `json:"nutritionalStatus"`
-	CreatedAt         time.Time `json:"createdAt"`
-	UpdatedAt         time.Time `json:"updatedAt"`
+	ID                   int             `json:"id"`
+	ToddlerID            int             `json:"toddlerID"`
+	CreatedByID          int             `json:"createdByID"`
+	Name                 string          `json:"name"`
+	Height               float64         `json:"height"`
+	Weight               *float64        `json:"weight,omitempty"`
+	HeadCircumference    *float64        `json:"headCircumference,omitempty"`
+	Age                  int             `json:"age"`
+	Sex                  string          `json:"sex"`
+	Zscore               float64         `json:"zscore"`
+	NutritionalStatus    string          `json:"nutritionalStatus"`
+	WhoZscore            *float64        `json:"whoZscore,omitempty"`
+	WhoNutritionalStatus *string         `json:"whoNutritionalStatus,omitempty"`
+	Source               string          `json:"source"`
+	ModelName            *string         `json:"modelName,omitempty"`
+	ModelVersion         *string         `json:"modelVersion,omitempty"`
+	MLResponse           json.RawMessage `json:"mlResponse,omitempty"`
+	MLLatencyMs          *int            `json:"mlLatencyMs,omitempty"`
+	WfaZscore            *float64        `json:"wfaZscore,omitempty"`
+	WfaStatus            *string         `json:"wfaStatus,omitempty"`
+	WfhZscore            *float64        `json:"wfhZscore,omitempty"`
+	WfhStatus            *string         `json:"wfhStatus,omitempty"`
+	BfaZscore            *float64        `json:"bfaZscore,omitempty"`
+	BfaStatus            *string         `json:"bfaStatus,omitempty"`
+	HcfaZscore           *float64        `json:"hcfaZscore,omitempty"`
+	HcfaStatus           *string         `json:"hcfaStatus,omitempty"`
+	SessionID            *int            `json:"sessionID,omitempty"`
+	CreatedAt            time.Time       `json:"createdAt"`
+	UpdatedAt            time.Time       `json:"updatedAt"`
+}
+
+// PredictRevisionResponse is a predict as it was before one correction.
+type PredictRevisionResponse struct {
+	ID                 int       `json:"id"`
+	PredictID          int       `json:"predictID"`
+	EditedByID         int       `json:"editedByID"`
+	MeasurementChanged bool      `json:"measurementChanged"`
+	StatusOverridden   bool      `json:"statusOverridden"`
+	Reason             *string   `json:"reason,omitempty"`
+	Height             float64   `json:"height"`
+	Weight             *float64  `json:"weight,omitempty"`
+	HeadCircumference  *float64  `json:"headCircumference,omitempty"`
+	Age                int       `json:"age"`
+	Sex                string    `json:"sex"`
+	Zscore             float64   `json:"zscore"`
+	NutritionalStatus  string    `json:"nutritionalStatus"`
+	Source             string    `json:"source"`
+	ModelName          *string   `json:"modelName,omitempty"`
+	ModelVersion       *string   `json:"modelVersion,omitempty"`
+	CreatedAt          time.Time `json:"createdAt"`
 }
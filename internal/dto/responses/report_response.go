@@ -0,0 +1,116 @@
+package responses
+
+import "time"
+
+type SKDNResponse struct {
+	LocationID   int     `json:"locationID,omitempty"`
+	LocationName string  `json:"locationName"`
+	S            int     `json:"s"`
+	K            int     `json:"k"`
+	D            int     `json:"d"`
+	N            int     `json:"n"`
+	T            int     `json:"t"`
+	O            int     `json:"o"`
+	KPerS        float64 `json:"kPerS"`
+	DPerS        float64 `json:"dPerS"`
+	NPerD        float64 `json:"nPerD"`
+}
+
+type SKDNReportResponse struct {
+	Month     string         `json:"month"`
+	Locations []SKDNResponse `json:"locations"`
+	Total     SKDNResponse   `json:"total"`
+}
+
+type PrevalenceStatusResponse struct {
+	NutritionalStatus string  `json:"nutritionalStatus"`
+	Count             int     `json:"count"`
+	Percentage        float64 `json:"percentage"`
+}
+
+type PrevalenceGroupResponse struct {
+	LocationID         *int                       `json:"locationID,omitempty"`
+	LocationName       *string                    `json:"locationName,omitempty"`
+	Month              *string                    `json:"month,omitempty"`
+	Sex                *string                    `json:"sex,omitempty"`
+	AgeBand            *string                    `json:"ageBand,omitempty"`
+	Total              int                        `json:"total"`
+	StuntingPrevalence float64                    `json:"stuntingPrevalence"`
+	Statuses           []PrevalenceStatusResponse `json:"statuses"`
+}
+
+type PrevalenceResponse struct {
+	From    string                    `json:"from"`
+	To      string                    `json:"to"`
+	GroupBy []string                  `json:"groupBy"`
+	Groups  []PrevalenceGroupResponse `json:"groups"`
+}
+
+// ShadowConfusionResponse compares the shadow model with a reference.
+// Matrix[i][j] counts the predicts the reference classified as Labels[i]
+// and the shadow model as Labels[j].
+type ShadowConfusionResponse struct {
+	Compared      int      `json:"compared"`
+	Agreed        int      `json:"agreed"`
+	AgreementRate float64  `json:"agreementRate"`
+	Labels        []string `json:"labels"`
+	Matrix        [][]int  `json:"matrix"`
+}
+
+type ShadowDisagreementResponse struct {
+	PredictID                int       `json:"predictID"`
+	ToddlerID                int       `json:"toddlerID"`
+	LocationID               int       `json:"locationID"`
+	Name                     string    `json:"name"`
+	Age                      int       `json:"age"`
+	Sex                      string    `json:"sex"`
+	Height                   float64   `json:"height"`
+	ModelVersion             *string   `json:"modelVersion,omitempty"`
+	Zscore                   float64   `json:"zscore"`
+	NutritionalStatus        string    `json:"nutritionalStatus"`
+	PrimarySource            string    `json:"primarySource"`
+	PrimaryZscore            float64   `json:"primaryZscore"`
+	PrimaryNutritionalStatus string    `json:"primaryNutritionalStatus"`
+	WhoZscore                *float64  `json:"whoZscore,omitempty"`
+	WhoNutritionalStatus     *string   `json:"whoNutritionalStatus,omitempty"`
+	CreatedAt                time.Time `json:"createdAt"`
+}
+
+type ShadowReportResponse struct {
+	From           string                       `json:"from"`
+	To             string                       `json:"to"`
+	ModelVersion   string                       `json:"modelVersion,omitempty"`
+	Total          int                          `json:"total"`
+	Failed         int                          `json:"failed"`
+	AgainstPrimary ShadowConfusionResponse      `json:"againstPrimary"`
+	AgainstWHO     ShadowConfusionResponse      `json:"againstWho"`
+	Disagreements  []ShadowDisagreementResponse `json:"disagreements"`
+}
+
+// IDLResponse counts the toddlers who turned one during the period and
+// those of them with imunisasi dasar lengkap.
+type IDLResponse struct {
+	LocationID   int     `json:"locationID,omitempty"`
+	LocationName string  `json:"locationName"`
+	Cohort       int     `json:"cohort"`
+	Complete     int     `json:"complete"`
+	Percentage   float64 `json:"percentage"`
+}
+
+// VaccineCoverageResponse is the share of the same toddlers who got one
+// dose before their first birthday.
+type VaccineCoverageResponse struct {
+	Code       string  `json:"code"`
+	Name       string  `json:"name"`
+	Basic      bool    `json:"basic"`
+	Given      int     `json:"given"`
+	Percentage float64 `json:"percentage"`
+}
+
+type ImmunizationReportResponse struct {
+	From      string                    `json:"from"`
+	To        string                    `json:"to"`
+	Locations []IDLResponse             `json:"locations"`
+	Total     IDLResponse               `json:"total"`
+	Vaccines  []VaccineCoverageResponse `json:"vaccines"`
+}
@@ -0,0 +1,42 @@
+package responses
+
+import "time"
+
+type SessionResponse struct {
+	ID           int                    `json:"id"`
+	LocationID   int                    `json:"locationID"`
+	LocationName string                 `json:"locationName"`
+	Date         string                 `json:"date"`
+	Status       string                 `json:"status"`
+	OpenedByID   int                    `json:"openedByID"`
+	ClosedByID   *int                   `json:"closedByID"`
+	OpenedAt     time.Time              `json:"openedAt"`
+	ClosedAt     *time.Time             `json:"closedAt"`
+	Notes        string                 `json:"notes"`
+	Counts       *SessionCountsResponse `json:"counts,omitempty"`
+	CreatedAt    time.Time              `json:"createdAt"`
+	UpdatedAt    time.Time              `json:"updatedAt"`
+}
+
+// SessionCountsResponse counts the toddlers registered at the location on
+// the session's date, those who came and those who were measured.
+type SessionCountsResponse struct {
+	Registered int `json:"registered"`
+	Attended   int `json:"attended"`
+	Measured   int `json:"measured"`
+	Absent     int `json:"absent"`
+}
+
+// SessionAbsentResponse is a registered toddler who did not come, with the
+// parent to follow up with.
+type SessionAbsentResponse struct {
+	ToddlerID         int       `json:"toddlerID"`
+	Name              string    `json:"name"`
+	Birthdate         time.Time `json:"birthdate"`
+	Sex               string    `json:"sex"`
+	AgeMonths         int       `json:"ageMonths"`
+	NutritionalStatus string    `json:"nutritionalStatus"`
+	ParentID          int       `json:"parentID"`
+	ParentName        string    `json:"parentName"`
+	ParentPhoneNumber string    `json:"parentPhoneNumber"`
+}
@@ -3,17 +3,21 @@ package responses
 import "time"
 
 type ToddlerResponse struct {
-	ID                int       `json:"id"`
-	ParentID          int       `json:"parentID"`
-	LocationID        int       `json:"locationID"`
-	CreatedByID       int       `json:"createdByID"`
-	UpdatedByID       int       `json:"updatedByID"`
-	Name              string    `json:"name"`
-	Birthdate         time.Time `json:"birthdate"`
-	Sex               string    `json:"sex"`
-	Height            float64   `json:"height"`
-	ProfilePicture    string    `json:"profilePicture"`
-	NutritionalStatus string    `json:"nutritionalStatus"`
-	CreatedAt         time.Time `json:"createdAt"`
-	UpdatedAt         time.Time `json:"updatedAt"`
+	ID                     int                      `json:"id"`
+	ParentID               int                      `json:"parentID"`
+	LocationID             int                      `json:"locationID"`
+	CreatedByID            int                      `json:"createdByID"`
+	UpdatedByID            int                      `json:"updatedByID"`
+	Name                   string                   `json:"name"`
+	Birthdate              time.Time                `json:"birthdate"`
+	Sex                    string                   `json:"sex"`
+	Height                 float64                  `json:"height"`
+	Weight                 *float64                 `json:"weight"`
+	HeadCircumference      *float64                 `json:"headCircumference"`
+	ProfilePicture         string                   `json:"profilePicture"`
+	ProfilePictureVariants *ImageVariantsResponse   `json:"profilePictureVariants"`
+	NutritionalStatus      string                   `json:"nutritionalStatus"`
+	Growth                 *GrowthFalteringResponse `json:"growth,omitempty"`
+	CreatedAt              time.Time                `json:"createdAt"`
+	UpdatedAt              time.Time                `json:"updatedAt"`
 }
@@ -2,7 +2,6 @@ package firebase
 
 import (
 	"context"
-	"log"
 
 	firebase "firebase.google.com/go/v4"
 	"google.golang.org/api/option"
@@ -10,14 +9,17 @@ import (
 
 var FirebaseApp *firebase.App
 
-func InitFirebase() {
+// InitFirebase is only needed when the Firebase phone verifier is
+// configured; the rest of the server does not depend on it.
+func InitFirebase(credentialsFile string) error {
 	ctx := context.Background()
-	opt := option.WithCredentialsFile("grovia-mobile-app-firebase-adminsdk-fbsvc-5725680d24.json")
+	opt := option.WithCredentialsFile(credentialsFile)
 
 	app, err := firebase.NewApp(ctx, nil, opt)
 	if err != nil {
-		log.Fatalf("error initializing firebase app: %v", err)
+		return err
 	}
 
 	FirebaseApp = app
+	return nil
 }
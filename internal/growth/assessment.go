@@ -0,0 +1,46 @@
+package growth
+
+// Measurement is a single anthropometric measurement of a child. Weight
+// and head circumference are optional; zero means "not measured".
+type Measurement struct {
+	Sex                 Sex
+	AgeMonths           int
+	HeightCm            float64
+	WeightKg            float64
+	HeadCircumferenceCm float64
+}
+
+// Assessment holds every indicator that could be computed for a
+// measurement. Indicators whose inputs are missing or outside the WHO
+// reference range are left nil.
+type Assessment struct {
+	HeightForAge            *Result `json:"heightForAge,omitempty"`
+	WeightForAge            *Result `json:"weightForAge,omitempty"`
+	WeightForHeight         *Result `json:"weightForHeight,omitempty"`
+	BMIForAge               *Result `json:"bmiForAge,omitempty"`
+	HeadCircumferenceForAge *Result `json:"headCircumferenceForAge,omitempty"`
+}
+
+// Assess computes all applicable indicators for m.
+func Assess(m Measurement) Assessment {
+	var a Assessment
+
+	if m.HeightCm > 0 {
+		a.HeightForAge, _ = HeightForAge(m.Sex, m.AgeMonths, m.HeightCm)
+	}
+
+	if m.WeightKg > 0 {
+		a.WeightForAge, _ = WeightForAge(m.Sex, m.AgeMonths, m.WeightKg)
+
+		if m.HeightCm > 0 {
+			a.WeightForHeight, _ = WeightForHeight(m.Sex, m.AgeMonths, m.HeightCm, m.WeightKg)
+			a.BMIForAge, _ = BMIForAge(m.Sex, m.AgeMonths, m.HeightCm, m.WeightKg)
+		}
+	}
+
+	if m.HeadCircumferenceCm > 0 {
+		a.HeadCircumferenceForAge, _ = HeadCircumferenceForAge(m.Sex, m.AgeMonths, m.HeadCircumferenceCm)
+	}
+
+	return a
+}
@@ -0,0 +1,133 @@
+package growth
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	FlagT              = "T"
+	Flag2T             = "2T"
+	FlagFallingHeight  = "FALLING_HFA"
+	FlagFallingWeight  = "FALLING_WFA"
+	maxVisitGap        = 62 * 24 * time.Hour
+	fallingWindow      = 3
+	fallingMinimumDrop = 0.5
+)
+
+// Point is one measurement in a child's history.
+type Point struct {
+	MeasuredAt time.Time
+	Height     float64
+	Weight     *float64
+	HfaZscore  *float64
+	WfaZscore  *float64
+}
+
+type Faltering struct {
+	Flags           []string   `json:"flags"`
+	ConsecutiveT    int        `json:"consecutiveT"`
+	LastMeasuredAt  *time.Time `json:"lastMeasuredAt,omitempty"`
+	HfaZscoreChange *float64   `json:"hfaZscoreChange,omitempty"`
+	WfaZscoreChange *float64   `json:"wfaZscoreChange,omitempty"`
+}
+
+// IsFaltering reports whether any flag was raised.
+func (f Faltering) IsFaltering() bool {
+	return len(f.Flags) > 0
+}
+
+// DetectFaltering compares consecutive measurements, in any order, and
+// flags the KMS "T" (tidak naik) pattern and falling z-score trajectories.
+//
+// A visit counts as T when weight did not increase since the previous
+// visit, or height when weight was not measured. Two visits more than two
+// months apart are not compared, like the "O" (not weighed) case on the
+// KMS card. 2T means the last two visits were both T. A trajectory is
+// falling when the z-score dropped at each of the last three visits by at
+// least half a standard deviation in total.
+func DetectFaltering(points []Point) Faltering {
+	sorted := make([]Point, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].MeasuredAt.Before(sorted[j].MeasuredAt)
+	})
+
+	result := Faltering{Flags: []string{}}
+	if len(sorted) == 0 {
+		return result
+	}
+
+	last := sorted[len(sorted)-1].MeasuredAt
+	result.LastMeasuredAt = &last
+
+	for i := len(sorted) - 1; i > 0; i-- {
+		if !notGained(sorted[i-1], sorted[i]) {
+			break
+		}
+		result.ConsecutiveT++
+	}
+
+	switch {
+	case result.ConsecutiveT >= 2:
+		result.Flags = append(result.Flags, Flag2T)
+	case result.ConsecutiveT == 1:
+		result.Flags = append(result.Flags, FlagT)
+	}
+
+	if change, falling := fallingTrend(sorted, func(p Point) *float64 { return p.HfaZscore }); change != nil {
+		result.HfaZscoreChange = change
+		if falling {
+			result.Flags = append(result.Flags, FlagFallingHeight)
+		}
+	}
+
+	if change, falling := fallingTrend(sorted, func(p Point) *float64 { return p.WfaZscore }); change != nil {
+		result.WfaZscoreChange = change
+		if falling {
+			result.Flags = append(result.Flags, FlagFallingWeight)
+		}
+	}
+
+	return result
+}
+
+func notGained(prev, curr Point) bool {
+	if curr.MeasuredAt.Sub(prev.MeasuredAt) > maxVisitGap {
+		return false
+	}
+
+	if prev.Weight != nil && curr.Weight != nil {
+		return *curr.Weight <= *prev.Weight
+	}
+
+	return curr.Height <= prev.Height
+}
+
+// fallingTrend returns the z-score change over the last visits and whether
+// it is a sustained fall.
+func fallingTrend(points []Point, zscore func(Point) *float64) (*float64, bool) {
+	var values []float64
+	for i := len(points) - 1; i >= 0 && len(values) < fallingWindow; i-- {
+		if z := zscore(points[i]); z != nil {
+			values = append([]float64{*z}, values...)
+		}
+	}
+
+	if len(values) < 2 {
+		return nil, false
+	}
+
+	change := Round2(values[len(values)-1] - values[0])
+	if len(values) < fallingWindow {
+		return &change, false
+	}
+
+	for i := 1; i < len(values); i++ {
+		if values[i] >= values[i-1] {
+			return &change, false
+		}
+	}
+
+	return &change, -change >= fallingMinimumDrop
+}
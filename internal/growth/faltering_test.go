@@ -0,0 +1,158 @@
+package growth
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func visit(month int, height, weight float64, hfa, wfa *float64) Point {
+	p := Point{
+		MeasuredAt: time.Date(2026, time.Month(month), 10, 0, 0, 0, 0, time.UTC),
+		Height:     height,
+		HfaZscore:  hfa,
+		WfaZscore:  wfa,
+	}
+	if weight > 0 {
+		p.Weight = &weight
+	}
+	return p
+}
+
+func z(v float64) *float64 {
+	return &v
+}
+
+func TestDetectFaltering(t *testing.T) {
+	tests := []struct {
+		name         string
+		points       []Point
+		flags        []string
+		consecutiveT int
+		hfaChange    *float64
+		wfaChange    *float64
+	}{
+		{
+			name:  "no history",
+			flags: []string{},
+		},
+		{
+			name:   "single visit",
+			points: []Point{visit(1, 70, 8, nil, nil)},
+			flags:  []string{},
+		},
+		{
+			name:   "weight gained every month",
+			points: []Point{visit(1, 70, 8, nil, nil), visit(2, 71, 8.3, nil, nil), visit(3, 72, 8.6, nil, nil)},
+			flags:  []string{},
+		},
+		{
+			name:         "weight stayed the same at the last visit",
+			points:       []Point{visit(1, 70, 8, nil, nil), visit(2, 71, 8.3, nil, nil), visit(3, 71.5, 8.3, nil, nil)},
+			flags:        []string{FlagT},
+			consecutiveT: 1,
+		},
+		{
+			name:         "weight dropped at the last two visits",
+			points:       []Point{visit(1, 70, 8.4, nil, nil), visit(2, 71, 8.3, nil, nil), visit(3, 71.5, 8.2, nil, nil)},
+			flags:        []string{Flag2T},
+			consecutiveT: 2,
+		},
+		{
+			name:         "height is compared when weight was not measured",
+			points:       []Point{visit(1, 70, 0, nil, nil), visit(2, 70, 0, nil, nil)},
+			flags:        []string{FlagT},
+			consecutiveT: 1,
+		},
+		{
+			name:   "visits more than two months apart are not compared",
+			points: []Point{visit(1, 70, 8.4, nil, nil), visit(5, 71, 8.2, nil, nil)},
+			flags:  []string{},
+		},
+		{
+			name:         "visits are sorted before comparing",
+			points:       []Point{visit(3, 71.5, 8.2, nil, nil), visit(1, 70, 8, nil, nil), visit(2, 71, 8.4, nil, nil)},
+			flags:        []string{FlagT},
+			consecutiveT: 1,
+		},
+		{
+			name: "height-for-age falling by half a standard deviation",
+			points: []Point{
+				visit(1, 70, 8, z(-0.8), nil),
+				visit(2, 71, 8.3, z(-1.1), nil),
+				visit(3, 72, 8.6, z(-1.3), nil),
+			},
+			flags:     []string{FlagFallingHeight},
+			hfaChange: z(-0.5),
+		},
+		{
+			name: "small fall is reported but not flagged",
+			points: []Point{
+				visit(1, 70, 8, nil, z(0.2)),
+				visit(2, 71, 8.3, nil, z(0.1)),
+				visit(3, 72, 8.6, nil, z(-0.1)),
+			},
+			flags:     []string{},
+			wfaChange: z(-0.3),
+		},
+		{
+			name: "fall interrupted by a rise is not flagged",
+			points: []Point{
+				visit(1, 70, 8, nil, z(0.5)),
+				visit(2, 71, 8.3, nil, z(-0.5)),
+				visit(3, 72, 8.6, nil, z(-0.4)),
+			},
+			flags:     []string{},
+			wfaChange: z(-0.9),
+		},
+		{
+			name: "two z-scores give a change but no trend",
+			points: []Point{
+				visit(1, 70, 8, z(0), nil),
+				visit(2, 71, 8.3, z(-1), nil),
+			},
+			flags:     []string{},
+			hfaChange: z(-1),
+		},
+		{
+			name: "2T with falling weight-for-age",
+			points: []Point{
+				visit(1, 70, 8.5, nil, z(-1)),
+				visit(2, 70.5, 8.4, nil, z(-1.4)),
+				visit(3, 71, 8.3, nil, z(-1.8)),
+			},
+			flags:        []string{Flag2T, FlagFallingWeight},
+			consecutiveT: 2,
+			wfaChange:    z(-0.8),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectFaltering(tt.points)
+
+			if !reflect.DeepEqual(got.Flags, tt.flags) {
+				t.Errorf("flags = %v, want %v", got.Flags, tt.flags)
+			}
+			if got.ConsecutiveT != tt.consecutiveT {
+				t.Errorf("consecutiveT = %d, want %d", got.ConsecutiveT, tt.consecutiveT)
+			}
+			if !reflect.DeepEqual(got.HfaZscoreChange, tt.hfaChange) {
+				t.Errorf("hfaZscoreChange = %v, want %v", deref(got.HfaZscoreChange), deref(tt.hfaChange))
+			}
+			if !reflect.DeepEqual(got.WfaZscoreChange, tt.wfaChange) {
+				t.Errorf("wfaZscoreChange = %v, want %v", deref(got.WfaZscoreChange), deref(tt.wfaChange))
+			}
+			if got.IsFaltering() != (len(tt.flags) > 0) {
+				t.Errorf("IsFaltering() = %v with flags %v", got.IsFaltering(), got.Flags)
+			}
+		})
+	}
+}
+
+func deref(v *float64) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
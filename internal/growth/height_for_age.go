@@ -0,0 +1,59 @@
+package growth
+
+// Height-for-age categories. The labels match the ones returned by the
+// ML API so both predictors can be stored in the same column.
+const (
+	StatusSeverelyStunted = "severely stunted"
+	StatusStunted         = "stunted"
+	StatusNormal          = "normal"
+	StatusTall            = "tinggi"
+)
+
+const MaxAgeMonths = 60
+
+type Result struct {
+	Zscore float64 `json:"zscore"`
+	Status string  `json:"status"`
+}
+
+// HeightForAge computes the WHO length/height-for-age z-score from the
+// single 0-60 month table, whose rows are for recumbent length below 24
+// months and standing height from then on. The measurement is assumed to
+// match the age; predicts do not record how the child was measured, so
+// the 0.7 cm length/height adjustment is not applied.
+func HeightForAge(sex Sex, ageMonths int, heightCm float64) (*Result, error) {
+	if heightCm <= 0 || ageMonths < 0 {
+		return nil, ErrInvalidData
+	}
+	if ageMonths > MaxAgeMonths {
+		return nil, ErrOutOfRange
+	}
+
+	t, err := lengthHeightForAge.forSex(sex)
+	if err != nil {
+		return nil, err
+	}
+
+	lms, err := t.at(float64(ageMonths))
+	if err != nil {
+		return nil, err
+	}
+
+	z := Round2(lms.ZScore(heightCm, false))
+
+	return &Result{Zscore: z, Status: ClassifyHeightForAge(z)}, nil
+}
+
+// ClassifyHeightForAge maps a z-score to the Permenkes 2/2020 categories.
+func ClassifyHeightForAge(z float64) string {
+	switch {
+	case z < -3:
+		return StatusSeverelyStunted
+	case z < -2:
+		return StatusStunted
+	case z > 3:
+		return StatusTall
+	default:
+		return StatusNormal
+	}
+}
@@ -0,0 +1,85 @@
+package growth
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHeightForAge(t *testing.T) {
+	tests := []struct {
+		name   string
+		sex    Sex
+		age    int
+		height float64
+		zscore float64
+		status string
+	}{
+		{"boy at the median at birth", Male, 0, 49.8842, 0, StatusNormal},
+		{"boy at the WHO -2 SD cut-off at birth", Male, 0, 46.1, -2, StatusNormal},
+		{"boy just below -2 SD at birth", Male, 0, 46.0, -2.05, StatusStunted},
+		{"boy below -3 SD at 24 months", Male, 24, 77.5, -3.15, StatusSeverelyStunted},
+		{"girl at the median at birth", Female, 0, 49.1477, 0, StatusNormal},
+		{"girl stunted at 12 months", Female, 12, 68.0, -2.34, StatusStunted},
+		{"tall boy at 60 months", Male, 60, 125.0, 3.24, StatusTall},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HeightForAge(tt.sex, tt.age, tt.height)
+			if err != nil {
+				t.Fatalf("HeightForAge: %v", err)
+			}
+			if got.Zscore != tt.zscore {
+				t.Errorf("zscore = %v, want %v", got.Zscore, tt.zscore)
+			}
+			if got.Status != tt.status {
+				t.Errorf("status = %q, want %q", got.Status, tt.status)
+			}
+		})
+	}
+}
+
+func TestHeightForAgeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		sex    Sex
+		age    int
+		height float64
+		want   error
+	}{
+		{"zero height", Male, 12, 0, ErrInvalidData},
+		{"negative age", Male, -1, 70, ErrInvalidData},
+		{"older than five years", Male, 61, 110, ErrOutOfRange},
+		{"unknown sex", Sex("x"), 12, 70, ErrUnknownSex},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := HeightForAge(tt.sex, tt.age, tt.height)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyHeightForAge(t *testing.T) {
+	tests := []struct {
+		z    float64
+		want string
+	}{
+		{-3.01, StatusSeverelyStunted},
+		{-3, StatusStunted},
+		{-2.01, StatusStunted},
+		{-2, StatusNormal},
+		{0, StatusNormal},
+		{3, StatusNormal},
+		{3.01, StatusTall},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyHeightForAge(tt.z); got != tt.want {
+			t.Errorf("ClassifyHeightForAge(%v) = %q, want %q", tt.z, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package growth
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrUnknownSex  = errors.New("jenis kelamin tidak dikenali")
+	ErrOutOfRange  = errors.New("nilai di luar rentang standar WHO")
+	ErrInvalidData = errors.New("data pengukuran tidak valid")
+)
+
+// LMS holds the Box-Cox power (L), median (M) and coefficient of
+// variation (S) of a WHO reference distribution at one age or length.
+type LMS struct {
+	L float64
+	M float64
+	S float64
+}
+
+// ValueAt returns the measurement that corresponds to the given z-score.
+func (p LMS) ValueAt(z float64) float64 {
+	if p.L == 0 {
+		return p.M * math.Exp(p.S*z)
+	}
+	return p.M * math.Pow(1+p.L*p.S*z, 1/p.L)
+}
+
+// ZScore computes the z-score of x. When restricted is true the WHO
+// adjustment for |z| > 3 is applied, as required for the weight based
+// indicators whose distributions are skewed in the tails.
+func (p LMS) ZScore(x float64, restricted bool) float64 {
+	var z float64
+	if p.L == 0 {
+		z = math.Log(x/p.M) / p.S
+	} else {
+		z = (math.Pow(x/p.M, p.L) - 1) / (p.L * p.S)
+	}
+
+	if !restricted {
+		return z
+	}
+
+	switch {
+	case z > 3:
+		sd3 := p.ValueAt(3)
+		sd23 := sd3 - p.ValueAt(2)
+		z = 3 + (x-sd3)/sd23
+	case z < -3:
+		sd3 := p.ValueAt(-3)
+		sd23 := p.ValueAt(-2) - sd3
+		z = -3 + (x-sd3)/sd23
+	}
+
+	return z
+}
+
+func interpolate(a, b LMS, t float64) LMS {
+	return LMS{
+		L: a.L + (b.L-a.L)*t,
+		M: a.M + (b.M-a.M)*t,
+		S: a.S + (b.S-a.S)*t,
+	}
+}
+
+// Round2 rounds a z-score to two decimals, the precision WHO Anthro reports.
+func Round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
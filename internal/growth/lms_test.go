@@ -0,0 +1,103 @@
+package growth
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLMSZScoreRoundTrip(t *testing.T) {
+	params := []LMS{
+		{L: 1, M: 49.8842, S: 0.03795},
+		{L: 0.3487, M: 3.3464, S: 0.14602},
+		{L: 0, M: 10, S: 0.1},
+		{L: -0.3521, M: 9.5032, S: 0.07786},
+	}
+
+	for _, p := range params {
+		for _, z := range []float64{-3, -2, -1, 0, 1, 2, 3} {
+			got := p.ZScore(p.ValueAt(z), false)
+			if math.Abs(got-z) > 1e-9 {
+				t.Errorf("%+v: ZScore(ValueAt(%v)) = %v", p, z, got)
+			}
+		}
+	}
+}
+
+func TestLMSZScoreRestricted(t *testing.T) {
+	p := LMS{L: 0.3487, M: 3.3464, S: 0.14602}
+
+	tests := []struct {
+		name string
+		x    float64
+		want float64
+	}{
+		{"inside the tails", p.ValueAt(2.5), 2.5},
+		{"one SD23 above +3", p.ValueAt(3) + (p.ValueAt(3) - p.ValueAt(2)), 4},
+		{"one SD23 below -3", p.ValueAt(-3) - (p.ValueAt(-2) - p.ValueAt(-3)), -4},
+		{"half SD23 above +3", p.ValueAt(3) + (p.ValueAt(3)-p.ValueAt(2))/2, 3.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p.ZScore(tt.x, true)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ZScore(%v, true) = %v, want %v", tt.x, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReferenceMatchesWHOCutOffs(t *testing.T) {
+	// Published WHO 2006 z-score tables, rounded to one decimal.
+	tests := []struct {
+		indicator Indicator
+		sex       Sex
+		age       float64
+		z         float64
+		want      float64
+	}{
+		{IndicatorHeightForAge, Male, 0, -2, 46.1},
+		{IndicatorHeightForAge, Male, 0, 0, 49.9},
+		{IndicatorHeightForAge, Male, 0, 2, 53.7},
+		{IndicatorHeightForAge, Male, 24, -2, 81.0},
+		{IndicatorHeightForAge, Male, 24, 0, 87.1},
+		{IndicatorHeightForAge, Female, 0, -2, 45.4},
+		{IndicatorHeightForAge, Female, 0, 0, 49.1},
+		{IndicatorHeightForAge, Female, 0, 2, 52.9},
+		{IndicatorWeightForAge, Male, 0, -3, 2.1},
+		{IndicatorWeightForAge, Male, 0, -2, 2.5},
+		{IndicatorWeightForAge, Male, 0, 0, 3.3},
+		{IndicatorWeightForAge, Male, 0, 2, 4.4},
+		{IndicatorWeightForAge, Male, 0, 3, 5.0},
+		{IndicatorWeightForAge, Female, 0, -2, 2.4},
+		{IndicatorWeightForAge, Female, 0, 0, 3.2},
+		{IndicatorWeightForAge, Female, 0, 2, 4.2},
+	}
+
+	for _, tt := range tests {
+		lms, err := Reference(tt.indicator, tt.sex, tt.age)
+		if err != nil {
+			t.Fatalf("Reference(%s, %s, %v): %v", tt.indicator, tt.sex, tt.age, err)
+		}
+		got := math.Round(lms.ValueAt(tt.z)*10) / 10
+		if got != tt.want {
+			t.Errorf("%s %s %v months at z=%v: got %v, want %v", tt.indicator, tt.sex, tt.age, tt.z, got, tt.want)
+		}
+	}
+}
+
+func TestRound2(t *testing.T) {
+	tests := map[float64]float64{
+		-1.996: -2,
+		-2.004: -2,
+		-2.006: -2.01,
+		1.234:  1.23,
+		0:      0,
+	}
+
+	for in, want := range tests {
+		if got := Round2(in); got != want {
+			t.Errorf("Round2(%v) = %v, want %v", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package growth
+
+type Indicator string
+
+const (
+	IndicatorHeightForAge Indicator = "hfa"
+	IndicatorWeightForAge Indicator = "wfa"
+)
+
+// Reference returns the LMS parameters of an age based indicator at the
+// given age, which may be fractional.
+func Reference(indicator Indicator, sex Sex, ageMonths float64) (LMS, error) {
+	var tables sexTables
+	switch indicator {
+	case IndicatorHeightForAge:
+		tables = lengthHeightForAge
+	case IndicatorWeightForAge:
+		tables = weightForAge
+	default:
+		return LMS{}, ErrInvalidData
+	}
+
+	t, err := tables.forSex(sex)
+	if err != nil {
+		return LMS{}, err
+	}
+
+	return t.at(ageMonths)
+}
@@ -0,0 +1,23 @@
+package growth
+
+import "strings"
+
+type Sex string
+
+const (
+	Male   Sex = "male"
+	Female Sex = "female"
+)
+
+// ParseSex accepts the spellings used across the API, the seed data and
+// the posyandu spreadsheets ("male", "M", "L", "laki-laki", ...).
+func ParseSex(value string) (Sex, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "male", "m", "l", "laki-laki", "laki laki", "lakilaki", "boy":
+		return Male, nil
+	case "female", "f", "p", "perempuan", "girl":
+		return Female, nil
+	default:
+		return "", ErrUnknownSex
+	}
+}
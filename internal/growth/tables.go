@@ -0,0 +1,116 @@
+package growth
+
+import (
+	"embed"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// The reference tables are the WHO 2006 Child Growth Standards published
+// as LMS parameters. Each file has a header row followed by
+// "<key>,l,m,s" rows, where the key is the age in months or the
+// length/height in centimetres, sorted ascending.
+//
+//go:embed data/*.csv
+var tableFiles embed.FS
+
+type table struct {
+	keys []float64
+	rows []LMS
+}
+
+func mustLoadTable(name string) *table {
+	f, err := tableFiles.Open("data/" + name + ".csv")
+	if err != nil {
+		panic(fmt.Sprintf("growth: missing table %s: %v", name, err))
+	}
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("growth: malformed table %s: %v", name, err))
+	}
+
+	t := &table{}
+	for i, rec := range records {
+		if i == 0 {
+			continue
+		}
+		var vals [4]float64
+		for j := range vals {
+			vals[j], err = strconv.ParseFloat(rec[j], 64)
+			if err != nil {
+				panic(fmt.Sprintf("growth: table %s row %d: %v", name, i, err))
+			}
+		}
+		t.keys = append(t.keys, vals[0])
+		t.rows = append(t.rows, LMS{L: vals[1], M: vals[2], S: vals[3]})
+	}
+
+	return t
+}
+
+// at returns the LMS parameters for key, linearly interpolating between
+// the two nearest rows when key is not tabulated exactly.
+func (t *table) at(key float64) (LMS, error) {
+	n := len(t.keys)
+	if n == 0 || key < t.keys[0] || key > t.keys[n-1] {
+		return LMS{}, ErrOutOfRange
+	}
+
+	i := sort.SearchFloat64s(t.keys, key)
+	if t.keys[i] == key {
+		return t.rows[i], nil
+	}
+
+	lo, hi := t.keys[i-1], t.keys[i]
+	return interpolate(t.rows[i-1], t.rows[i], (key-lo)/(hi-lo)), nil
+}
+
+type sexTables struct {
+	male   *table
+	female *table
+}
+
+func (s sexTables) forSex(sex Sex) (*table, error) {
+	switch sex {
+	case Male:
+		return s.male, nil
+	case Female:
+		return s.female, nil
+	default:
+		return nil, ErrUnknownSex
+	}
+}
+
+var lengthHeightForAge = sexTables{
+	male:   mustLoadTable("lhfa_boys"),
+	female: mustLoadTable("lhfa_girls"),
+}
+
+var weightForAge = sexTables{
+	male:   mustLoadTable("wfa_boys"),
+	female: mustLoadTable("wfa_girls"),
+}
+
+var weightForLength = sexTables{
+	male:   mustLoadTable("wfl_boys"),
+	female: mustLoadTable("wfl_girls"),
+}
+
+var weightForHeight = sexTables{
+	male:   mustLoadTable("wfh_boys"),
+	female: mustLoadTable("wfh_girls"),
+}
+
+var bmiForAge = sexTables{
+	male:   mustLoadTable("bfa_boys"),
+	female: mustLoadTable("bfa_girls"),
+}
+
+var headCircumferenceForAge = sexTables{
+	male:   mustLoadTable("hcfa_boys"),
+	female: mustLoadTable("hcfa_girls"),
+}
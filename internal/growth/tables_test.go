@@ -0,0 +1,99 @@
+package growth
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTableResolution(t *testing.T) {
+	tests := []struct {
+		name  string
+		table *table
+		first float64
+		last  float64
+		step  float64
+	}{
+		{"wfl_boys", weightForLength.male, 45, 110, 0.5},
+		{"wfl_girls", weightForLength.female, 45, 110, 0.5},
+		{"wfh_boys", weightForHeight.male, 65, 120, 0.5},
+		{"wfh_girls", weightForHeight.female, 65, 120, 0.5},
+		{"hcfa_boys", headCircumferenceForAge.male, 0, 60, 1},
+		{"hcfa_girls", headCircumferenceForAge.female, 0, 60, 1},
+		{"lhfa_boys", lengthHeightForAge.male, 0, 60, 1},
+		{"wfa_girls", weightForAge.female, 0, 60, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := tt.table.keys
+			if keys[0] != tt.first || keys[len(keys)-1] != tt.last {
+				t.Fatalf("range = %v..%v, want %v..%v", keys[0], keys[len(keys)-1], tt.first, tt.last)
+			}
+			for i := 1; i < len(keys); i++ {
+				if math.Abs(keys[i]-keys[i-1]-tt.step) > 1e-9 {
+					t.Fatalf("step between %v and %v, want %v", keys[i-1], keys[i], tt.step)
+				}
+			}
+		})
+	}
+}
+
+// WHO derives weight-for-height from the weight-for-length curves by
+// converting standing height to recumbent length (+0.7 cm), so the two
+// tables must agree wherever they overlap.
+func TestWeightForHeightMatchesShiftedLength(t *testing.T) {
+	for _, sex := range []Sex{Male, Female} {
+		wfl, _ := weightForLength.forSex(sex)
+		wfh, _ := weightForHeight.forSex(sex)
+
+		for h := 65.0; h+0.7 <= 110; h += 0.5 {
+			byHeight, err := wfh.at(h)
+			if err != nil {
+				t.Fatal(err)
+			}
+			byLength, err := wfl.at(h + 0.7)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(byHeight.M-byLength.M) > 0.001 {
+				t.Errorf("%s %.1f cm: wfh M %v, wfl M at +0.7 cm %v", sex, h, byHeight.M, byLength.M)
+			}
+		}
+	}
+}
+
+func TestLengthTablesMatchWHOCutOffs(t *testing.T) {
+	// Published WHO 2006 z-score tables, rounded to one decimal.
+	tests := []struct {
+		name  string
+		table *table
+		key   float64
+		z     float64
+		want  float64
+	}{
+		{"wfl boys 45 cm -3 SD", weightForLength.male, 45, -3, 1.9},
+		{"wfl boys 45 cm -2 SD", weightForLength.male, 45, -2, 2.0},
+		{"wfl boys 45 cm median", weightForLength.male, 45, 0, 2.4},
+		{"wfl boys 45 cm +2 SD", weightForLength.male, 45, 2, 3.0},
+		{"wfl boys 60 cm median", weightForLength.male, 60, 0, 6.0},
+		{"wfl boys 85 cm median", weightForLength.male, 85, 0, 11.5},
+		{"wfl girls 80 cm median", weightForLength.female, 80, 0, 10.1},
+		{"wfh boys 120 cm median", weightForHeight.male, 120, 0, 22.4},
+		{"wfh girls 120 cm median", weightForHeight.female, 120, 0, 22.8},
+		{"hcfa boys birth -2 SD", headCircumferenceForAge.male, 0, -2, 31.9},
+		{"hcfa boys birth median", headCircumferenceForAge.male, 0, 0, 34.5},
+		{"hcfa boys 60 months median", headCircumferenceForAge.male, 60, 0, 50.7},
+		{"hcfa girls birth -2 SD", headCircumferenceForAge.female, 0, -2, 31.5},
+		{"hcfa girls 60 months median", headCircumferenceForAge.female, 60, 0, 49.9},
+	}
+
+	for _, tt := range tests {
+		lms, err := tt.table.at(tt.key)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := math.Round(lms.ValueAt(tt.z)*10) / 10; got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package growth
+
+// Weight-for-age categories.
+const (
+	StatusSeverelyUnderweight = "severely underweight"
+	StatusUnderweight         = "underweight"
+	StatusRiskOfOverweight    = "risk of overweight"
+)
+
+// Weight-for-height and BMI-for-age categories.
+const (
+	StatusSeverelyWasted         = "severely wasted"
+	StatusWasted                 = "wasted"
+	StatusPossibleRiskOverweight = "possible risk of overweight"
+	StatusOverweight             = "overweight"
+	StatusObese                  = "obese"
+)
+
+// Head circumference-for-age categories.
+const (
+	StatusMicrocephaly = "microcephaly"
+	StatusMacrocephaly = "macrocephaly"
+)
+
+// WeightForAge computes the WHO weight-for-age z-score.
+func WeightForAge(sex Sex, ageMonths int, weightKg float64) (*Result, error) {
+	lms, err := lmsForAge(weightForAge, sex, ageMonths, weightKg)
+	if err != nil {
+		return nil, err
+	}
+
+	z := Round2(lms.ZScore(weightKg, true))
+
+	return &Result{Zscore: z, Status: ClassifyWeightForAge(z)}, nil
+}
+
+// WeightForHeight computes the WHO weight-for-length (under 24 months)
+// or weight-for-height z-score.
+func WeightForHeight(sex Sex, ageMonths int, heightCm, weightKg float64) (*Result, error) {
+	if heightCm <= 0 || weightKg <= 0 || ageMonths < 0 {
+		return nil, ErrInvalidData
+	}
+
+	tables := weightForHeight
+	if ageMonths < 24 {
+		tables = weightForLength
+	}
+
+	t, err := tables.forSex(sex)
+	if err != nil {
+		return nil, err
+	}
+
+	lms, err := t.at(heightCm)
+	if err != nil {
+		return nil, err
+	}
+
+	z := Round2(lms.ZScore(weightKg, true))
+
+	return &Result{Zscore: z, Status: ClassifyWeightForHeight(z)}, nil
+}
+
+// BMIForAge computes the WHO BMI-for-age z-score.
+func BMIForAge(sex Sex, ageMonths int, heightCm, weightKg float64) (*Result, error) {
+	if heightCm <= 0 {
+		return nil, ErrInvalidData
+	}
+
+	bmi := weightKg / ((heightCm / 100) * (heightCm / 100))
+
+	lms, err := lmsForAge(bmiForAge, sex, ageMonths, bmi)
+	if err != nil {
+		return nil, err
+	}
+
+	z := Round2(lms.ZScore(bmi, true))
+
+	return &Result{Zscore: z, Status: ClassifyWeightForHeight(z)}, nil
+}
+
+// HeadCircumferenceForAge computes the WHO head circumference-for-age z-score.
+func HeadCircumferenceForAge(sex Sex, ageMonths int, headCm float64) (*Result, error) {
+	lms, err := lmsForAge(headCircumferenceForAge, sex, ageMonths, headCm)
+	if err != nil {
+		return nil, err
+	}
+
+	z := Round2(lms.ZScore(headCm, false))
+
+	return &Result{Zscore: z, Status: ClassifyHeadCircumferenceForAge(z)}, nil
+}
+
+// ClassifyWeightForAge maps a z-score to the Permenkes 2/2020 categories.
+func ClassifyWeightForAge(z float64) string {
+	switch {
+	case z < -3:
+		return StatusSeverelyUnderweight
+	case z < -2:
+		return StatusUnderweight
+	case z > 1:
+		return StatusRiskOfOverweight
+	default:
+		return StatusNormal
+	}
+}
+
+// ClassifyWeightForHeight maps a weight-for-height or BMI-for-age z-score
+// to the Permenkes 2/2020 categories.
+func ClassifyWeightForHeight(z float64) string {
+	switch {
+	case z < -3:
+		return StatusSeverelyWasted
+	case z < -2:
+		return StatusWasted
+	case z > 3:
+		return StatusObese
+	case z > 2:
+		return StatusOverweight
+	case z > 1:
+		return StatusPossibleRiskOverweight
+	default:
+		return StatusNormal
+	}
+}
+
+func ClassifyHeadCircumferenceForAge(z float64) string {
+	switch {
+	case z < -2:
+		return StatusMicrocephaly
+	case z > 2:
+		return StatusMacrocephaly
+	default:
+		return StatusNormal
+	}
+}
+
+func lmsForAge(tables sexTables, sex Sex, ageMonths int, value float64) (LMS, error) {
+	if value <= 0 || ageMonths < 0 {
+		return LMS{}, ErrInvalidData
+	}
+	if ageMonths > MaxAgeMonths {
+		return LMS{}, ErrOutOfRange
+	}
+
+	t, err := tables.forSex(sex)
+	if err != nil {
+		return LMS{}, err
+	}
+
+	return t.at(float64(ageMonths))
+}
@@ -0,0 +1,128 @@
+package growth
+
+import "testing"
+
+func TestWeightForAge(t *testing.T) {
+	tests := []struct {
+		name   string
+		sex    Sex
+		age    int
+		weight float64
+		zscore float64
+		status string
+	}{
+		{"boy at the median at birth", Male, 0, 3.3464, 0, StatusNormal},
+		{"underweight boy at birth", Male, 0, 2.4, -2.15, StatusUnderweight},
+		{"severely underweight boy at birth", Male, 0, 1.9, -3.48, StatusSeverelyUnderweight},
+		{"girl at risk of overweight at birth", Female, 0, 4.0, 1.57, StatusRiskOfOverweight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WeightForAge(tt.sex, tt.age, tt.weight)
+			if err != nil {
+				t.Fatalf("WeightForAge: %v", err)
+			}
+			if got.Zscore != tt.zscore {
+				t.Errorf("zscore = %v, want %v", got.Zscore, tt.zscore)
+			}
+			if got.Status != tt.status {
+				t.Errorf("status = %q, want %q", got.Status, tt.status)
+			}
+		})
+	}
+}
+
+func TestClassifyWeightForAge(t *testing.T) {
+	tests := []struct {
+		z    float64
+		want string
+	}{
+		{-3.01, StatusSeverelyUnderweight},
+		{-3, StatusUnderweight},
+		{-2.01, StatusUnderweight},
+		{-2, StatusNormal},
+		{1, StatusNormal},
+		{1.01, StatusRiskOfOverweight},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyWeightForAge(tt.z); got != tt.want {
+			t.Errorf("ClassifyWeightForAge(%v) = %q, want %q", tt.z, got, tt.want)
+		}
+	}
+}
+
+func TestClassifyWeightForHeight(t *testing.T) {
+	tests := []struct {
+		z    float64
+		want string
+	}{
+		{-3.01, StatusSeverelyWasted},
+		{-3, StatusWasted},
+		{-2.01, StatusWasted},
+		{-2, StatusNormal},
+		{1, StatusNormal},
+		{1.01, StatusPossibleRiskOverweight},
+		{2, StatusPossibleRiskOverweight},
+		{2.01, StatusOverweight},
+		{3, StatusOverweight},
+		{3.01, StatusObese},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyWeightForHeight(tt.z); got != tt.want {
+			t.Errorf("ClassifyWeightForHeight(%v) = %q, want %q", tt.z, got, tt.want)
+		}
+	}
+}
+
+func TestClassifyHeadCircumferenceForAge(t *testing.T) {
+	tests := []struct {
+		z    float64
+		want string
+	}{
+		{-2.01, StatusMicrocephaly},
+		{-2, StatusNormal},
+		{2, StatusNormal},
+		{2.01, StatusMacrocephaly},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyHeadCircumferenceForAge(tt.z); got != tt.want {
+			t.Errorf("ClassifyHeadCircumferenceForAge(%v) = %q, want %q", tt.z, got, tt.want)
+		}
+	}
+}
+
+func TestWeightForHeight(t *testing.T) {
+	tests := []struct {
+		name   string
+		sex    Sex
+		age    int
+		height float64
+		weight float64
+		zscore float64
+		status string
+	}{
+		{"median on a half centimetre row uses the length table", Male, 12, 75.5, 9.6041, 0, StatusNormal},
+		{"median on a half centimetre row uses the height table", Female, 36, 95.5, 14.0186, 0, StatusNormal},
+		{"wasted boy measured lying down", Male, 9, 70, 6.9, -2.55, StatusWasted},
+		{"overweight girl measured standing", Female, 48, 100, 18.6, 2.13, StatusOverweight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WeightForHeight(tt.sex, tt.age, tt.height, tt.weight)
+			if err != nil {
+				t.Fatalf("WeightForHeight: %v", err)
+			}
+			if got.Zscore != tt.zscore {
+				t.Errorf("zscore = %v, want %v", got.Zscore, tt.zscore)
+			}
+			if got.Status != tt.status {
+				t.Errorf("status = %q, want %q", got.Status, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"grovia/internal/dto/requests"
+	"grovia/internal/dto/responses"
+	"grovia/internal/services"
+	"grovia/pkg"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuditHandler struct {
+	service services.AuditService
+}
+
+func NewAuditHandler(service services.AuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+func (h *AuditHandler) GetAllAuditLog(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	var query requests.AuditLogQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid Request",
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	logs, meta, err := h.service.GetAllAuditLog(query)
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Get audit log success",
+		Data:    logs,
+		Meta:    meta,
+	})
+}
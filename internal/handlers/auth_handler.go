@@ -17,6 +17,33 @@ func NewAuthHandler(service services.AuthService) *AuthHandler {
 	return &AuthHandler{service: service}
 }
 
+func (a *AuthHandler) SendResetCode(ctx *fiber.Ctx) error {
+	var req requests.SendResetCodeRequest
+
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid request",
+			Data:    nil,
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	if err := a.service.SendResetCode(req); err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Verification code sent",
+		Data:    nil,
+		Error:   nil,
+	})
+}
+
 func (a *AuthHandler) ResetPassword(ctx *fiber.Ctx) error {
 	var req requests.ResetPasswordRequest
 
@@ -32,7 +59,7 @@ func (a *AuthHandler) ResetPassword(ctx *fiber.Ctx) error {
 		})
 	}
 
-	err := a.service.ResetPassword(req)
+	err := a.service.ResetPassword(ctx.UserContext(), req)
 	if err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
 			Success: false,
@@ -68,7 +95,7 @@ func (a *AuthHandler) Login(ctx *fiber.Ctx) error {
 		})
 	}
 
-	token, err := a.service.Login(req)
+	token, err := a.service.Login(req, ctx.Get(fiber.HeaderUserAgent))
 
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
@@ -103,7 +130,7 @@ func (a *AuthHandler) RefreshToken(ctx *fiber.Ctx) error {
 		})
 	}
 
-	token, err := a.service.RefreshToken(req.RefreshToken)
+	token, err := a.service.RefreshToken(req.RefreshToken, ctx.Get(fiber.HeaderUserAgent))
 
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
@@ -123,3 +150,109 @@ func (a *AuthHandler) RefreshToken(ctx *fiber.Ctx) error {
 		Error:   nil,
 	})
 }
+
+func (a *AuthHandler) Logout(ctx *fiber.Ctx) error {
+	var req requests.RefreshTokenRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid Request",
+			Data:    nil,
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
+				Message: "Invalid Request",
+			},
+		})
+	}
+
+	if err := a.service.Logout(req.RefreshToken); err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Logout Success",
+		Data:    nil,
+		Error:   nil,
+	})
+}
+
+func (a *AuthHandler) LogoutEverywhere(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	if err := a.service.LogoutEverywhere(userID); err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Logout From All Devices Success",
+		Data:    nil,
+		Error:   nil,
+	})
+}
+
+func (a *AuthHandler) SendParentLoginCode(ctx *fiber.Ctx) error {
+	var req requests.ParentSendCodeRequest
+
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid request",
+			Data:    nil,
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	if err := a.service.SendParentLoginCode(req); err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Verification code sent",
+		Data:    nil,
+		Error:   nil,
+	})
+}
+
+func (a *AuthHandler) ParentLogin(ctx *fiber.Ctx) error {
+	var req requests.ParentLoginRequest
+
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid Request",
+			Data:    nil,
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	token, err := a.service.ParentLogin(req)
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Login Success",
+		Data:    responses.LoginResponse{Token: *token},
+		Error:   nil,
+	})
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"errors"
+	"grovia/internal/dto/responses"
+	"grovia/internal/storage"
+	"net/url"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FileHandler serves objects of the storage backends that have no URL of
+// their own. Access is granted by the signature in the URL, not by a
+// bearer token, so the URLs work in <img> tags.
+type FileHandler struct {
+	storage  storage.ObjectStorage
+	verifier storage.URLVerifier
+}
+
+func NewFileHandler(store storage.ObjectStorage, verifier storage.URLVerifier) *FileHandler {
+	return &FileHandler{storage: store, verifier: verifier}
+}
+
+func (f *FileHandler) GetFile(ctx *fiber.Ctx) error {
+	key, err := url.PathUnescape(ctx.Params("*"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid Request",
+			Data:    nil,
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	if err := f.verifier.VerifySignedURL(key, ctx.Query("expires"), ctx.Query("signature")); err != nil {
+		return ctx.Status(fiber.StatusForbidden).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Forbidden",
+			Data:    nil,
+			Error: responses.ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	object, err := f.storage.Get(ctx.UserContext(), key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+			return ctx.Status(fiber.StatusNotFound).JSON(responses.BaseResponse{
+				Success: false,
+				Message: "File not found",
+				Data:    nil,
+				Error: responses.ErrorResponse{
+					Code:    "NOT_FOUND",
+					Message: "File not found",
+				},
+			})
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Internal Server Error",
+			Data:    nil,
+			Error: responses.ErrorResponse{
+				Code:    "INTERNAL_SERVER_ERROR",
+				Message: "Gagal membaca file",
+			},
+		})
+	}
+
+	if object.ContentType != "" {
+		ctx.Set(fiber.HeaderContentType, object.ContentType)
+	}
+	ctx.Set(fiber.HeaderCacheControl, "private, max-age=300")
+
+	// Fiber closes the body once it has been written.
+	return ctx.SendStream(object.Body, int(object.Size))
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"grovia/internal/dto/requests"
+	"grovia/internal/dto/responses"
+	"grovia/internal/services"
+	"grovia/pkg"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ImmunizationHandler struct {
+	service services.ImmunizationService
+}
+
+func NewImmunizationHandler(service services.ImmunizationService) *ImmunizationHandler {
+	return &ImmunizationHandler{service: service}
+}
+
+func (h *ImmunizationHandler) GetVaccines(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	vaccines, err := h.service.GetVaccines()
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Get vaccines success",
+		Data:    vaccines,
+	})
+}
+
+func (h *ImmunizationHandler) CreateImmunization(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	var req requests.CreateImmunizationRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid Request",
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	immunization, err := h.service.CreateImmunization(ctx.UserContext(), scope, userID, req)
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Create immunization success",
+		Data:    immunization,
+	})
+}
+
+func (h *ImmunizationHandler) GetToddlerImmunizations(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid toddler ID",
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	card, err := h.service.GetToddlerImmunizations(id, scope)
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Get toddler immunizations success",
+		Data:    card,
+	})
+}
+
+func (h *ImmunizationHandler) DeleteImmunizationByID(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid immunization ID",
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	if err := h.service.DeleteImmunizationByID(ctx.UserContext(), id, scope, userID); err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Delete immunization success",
+	})
+}
+
+func (h *ImmunizationHandler) GetDueImmunizations(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	var query requests.DueImmunizationQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid Request",
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	due, err := h.service.GetDueImmunizations(scope, query)
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Get due immunizations success",
+		Data:    due,
+	})
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"grovia/internal/dto/responses"
+	"grovia/internal/services"
+	"grovia/pkg"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type JobHandler struct {
+	service services.JobService
+}
+
+func NewJobHandler(service services.JobService) *JobHandler {
+	return &JobHandler{service: service}
+}
+
+func (h *JobHandler) GetAllJob(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+	pageStr := ctx.Query("page")
+	limitStr := ctx.Query("limit")
+
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	jobs, meta, err := h.service.GetAllJob(scope, pageStr, limitStr)
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Get all job success",
+		Data:    jobs,
+		Meta:    meta,
+	})
+}
+
+func (h *JobHandler) GetJobByID(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	idParam := ctx.Params("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid job ID",
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	job, err := h.service.GetJobByID(id, scope)
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Get job success",
+		Data:    job,
+	})
+}
+
+func (h *JobHandler) GetJobResultFile(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	idParam := ctx.Params("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid job ID",
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	data, fileName, err := h.service.GetJobResultFile(id, scope)
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	ctx.Attachment(fileName)
+	return ctx.Send(data)
+}
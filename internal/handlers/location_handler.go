@@ -20,7 +20,6 @@ func NewLocationHandler(service services.LocationService) *LocationHandler {
 
 func (l *LocationHandler) CreateLocation(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
-	role := ctx.Locals("role")
 
 	if !ok || userID == 0 {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
@@ -34,18 +33,6 @@ func (l *LocationHandler) CreateLocation(ctx *fiber.Ctx) error {
 		})
 	}
 
-	if role != "admin" {
-		return ctx.Status(fiber.StatusForbidden).JSON(responses.BaseResponse{
-			Success: false,
-			Message: "Forbidden",
-			Data:    nil,
-			Error: responses.ErrorResponse{
-				Code:    "FORBIDDEN",
-				Message: "Forbidden",
-			},
-		})
-	}
-
 	var req requests.LocationRequest
 
 	if err := ctx.BodyParser(&req); err != nil {
@@ -65,7 +52,7 @@ func (l *LocationHandler) CreateLocation(ctx *fiber.Ctx) error {
 		req.Picture = file
 	}
 
-	locationResponse, err := l.service.CreateLocation(ctx.Context(), req, userID)
+	locationResponse, err := l.service.CreateLocation(ctx.UserContext(), req, userID)
 
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
@@ -81,7 +68,6 @@ func (l *LocationHandler) CreateLocation(ctx *fiber.Ctx) error {
 
 func (l *LocationHandler) GetAllLocation(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
-	role := ctx.Locals("role")
 
 	name := ctx.Query("name")
 	pageStr := ctx.Query("page")
@@ -99,18 +85,6 @@ func (l *LocationHandler) GetAllLocation(ctx *fiber.Ctx) error {
 		})
 	}
 
-	if role != "admin" {
-		return ctx.Status(fiber.StatusForbidden).JSON(responses.BaseResponse{
-			Success: false,
-			Message: "Forbidden",
-			Data:    nil,
-			Error: responses.ErrorResponse{
-				Code:    "FORBIDDEN",
-				Message: "Forbidden",
-			},
-		})
-	}
-
 	locationsResponse, meta, err := l.service.GetAllLocation(name, pageStr, limitStr)
 
 	if err != nil {
@@ -172,7 +146,6 @@ func (l *LocationHandler) GetLocationByID(ctx *fiber.Ctx) error {
 
 func (l *LocationHandler) UpdateLocationByID(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
-	role := ctx.Locals("role")
 
 	if !ok || userID == 0 {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
@@ -186,18 +159,6 @@ func (l *LocationHandler) UpdateLocationByID(ctx *fiber.Ctx) error {
 		})
 	}
 
-	if role != "admin" {
-		return ctx.Status(fiber.StatusForbidden).JSON(responses.BaseResponse{
-			Success: false,
-			Message: "Forbidden",
-			Data:    nil,
-			Error: responses.ErrorResponse{
-				Code:    "FORBIDDEN",
-				Message: "Forbidden",
-			},
-		})
-	}
-
 	var req requests.LocationRequest
 
 	if err := ctx.BodyParser(&req); err != nil {
@@ -232,7 +193,7 @@ func (l *LocationHandler) UpdateLocationByID(ctx *fiber.Ctx) error {
 		})
 	}
 
-	locationResponse, err := l.service.UpdateLocationByID(ctx.Context(), id, userID, req)
+	locationResponse, err := l.service.UpdateLocationByID(ctx.UserContext(), id, userID, req)
 
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
@@ -248,7 +209,6 @@ func (l *LocationHandler) UpdateLocationByID(ctx *fiber.Ctx) error {
 
 func (l *LocationHandler) DeleteLocationByID(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
-	role := ctx.Locals("role")
 
 	if !ok || userID == 0 {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
@@ -262,18 +222,6 @@ func (l *LocationHandler) DeleteLocationByID(ctx *fiber.Ctx) error {
 		})
 	}
 
-	if role != "admin" {
-		return ctx.Status(fiber.StatusForbidden).JSON(responses.BaseResponse{
-			Success: false,
-			Message: "Forbidden",
-			Data:    nil,
-			Error: responses.ErrorResponse{
-				Code:    "FORBIDDEN",
-				Message: "Forbidden",
-			},
-		})
-	}
-
 	idParam := ctx.Params("id")
 	id, err := strconv.Atoi(idParam)
 
@@ -289,7 +237,7 @@ func (l *LocationHandler) DeleteLocationByID(ctx *fiber.Ctx) error {
 		})
 	}
 
-	err = l.service.DeleteLocationByID(id, userID)
+	err = l.service.DeleteLocationByID(ctx.UserContext(), id, userID)
 
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"grovia/internal/dto/responses"
+	"grovia/internal/services"
+	"grovia/pkg"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// upcomingScheduleCount is how many posyandu days a parent is shown.
+const upcomingScheduleCount = 3
+
+// MeHandler serves the read-only API of a signed-in parent. Every query
+// goes through the parent's access scope, so only their own children are
+// found.
+type MeHandler struct {
+	toddlerService  services.ToddlerService
+	predictService  services.PredictService
+	locationService services.LocationService
+}
+
+func NewMeHandler(toddlerService services.ToddlerService, predictService services.PredictService, locationService services.LocationService) *MeHandler {
+	return &MeHandler{toddlerService: toddlerService, predictService: predictService, locationService: locationService}
+}
+
+func (m *MeHandler) GetChildren(ctx *fiber.Ctx) error {
+	parentID, ok := ctx.Locals("parent_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+
+	if !ok || parentID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Data:    nil,
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	toddlers, meta, err := m.toddlerService.GetAllToddler(scope, "", ctx.Query("page"), ctx.Query("limit", "50"))
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Get Children Success",
+		Data:    toddlers,
+		Meta:    meta,
+		Error:   nil,
+	})
+}
+
+func (m *MeHandler) GetChildByID(ctx *fiber.Ctx) error {
+	parentID, ok := ctx.Locals("parent_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+
+	if !ok || parentID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Data:    nil,
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	idParam := ctx.Params("id")
+	id, err := strconv.Atoi(idParam)
+
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid Request",
+			Data:    nil,
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	toddler, err := m.toddlerService.GetToddlerByID(id, scope)
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Get Child Success",
+		Data:    toddler,
+		Error:   nil,
+	})
+}
+
+func (m *MeHandler) GetChildPredicts(ctx *fiber.Ctx) error {
+	parentID, ok := ctx.Locals("parent_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+
+	if !ok || parentID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Data:    nil,
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	idParam := ctx.Params("id")
+	id, err := strconv.Atoi(idParam)
+
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid Request",
+			Data:    nil,
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	if _, err := m.toddlerService.GetToddlerByID(id, scope); err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	predicts, err := m.predictService.GetAllPredictByToddlerID(scope, id)
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Get Child Predict History Success",
+		Data:    predicts,
+		Error:   nil,
+	})
+}
+
+func (m *MeHandler) GetChildGrowthChart(ctx *fiber.Ctx) error {
+	parentID, ok := ctx.Locals("parent_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+
+	if !ok || parentID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Data:    nil,
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	idParam := ctx.Params("id")
+	id, err := strconv.Atoi(idParam)
+
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid Request",
+			Data:    nil,
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	data, contentType, err := m.toddlerService.GetGrowthChart(id, scope, ctx.Query("indicator"), ctx.Query("format"))
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	ctx.Set("Content-Type", contentType)
+	return ctx.Send(data)
+}
+
+func (m *MeHandler) GetSchedule(ctx *fiber.Ctx) error {
+	parentID, ok := ctx.Locals("parent_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+
+	if !ok || parentID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Data:    nil,
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	schedule, err := m.locationService.GetUpcomingSchedule(scope.LocationID, upcomingScheduleCount)
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Get Posyandu Schedule Success",
+		Data:    schedule,
+		Error:   nil,
+	})
+}
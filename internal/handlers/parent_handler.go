@@ -49,7 +49,7 @@ func (p *ParentHandler) CreateParent(ctx *fiber.Ctx) error {
 
 	req.LocationID = locationID
 
-	parentResp, err := p.service.CreateParent(req, userID)
+	parentResp, err := p.service.CreateParent(ctx.UserContext(), req, userID)
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
 	}
@@ -64,7 +64,7 @@ func (p *ParentHandler) CreateParent(ctx *fiber.Ctx) error {
 
 func (p *ParentHandler) GetAllParent(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
-	locationID := ctx.Locals("location_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
 	pageStr := ctx.Query("page")
 	limitStr := ctx.Query("limit")
 
@@ -82,7 +82,7 @@ func (p *ParentHandler) GetAllParent(ctx *fiber.Ctx) error {
 		})
 	}
 
-	parents, meta, err := p.service.GetAllParent(locationID, name, pageStr, limitStr)
+	parents, meta, err := p.service.GetAllParent(scope, name, pageStr, limitStr)
 
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
@@ -99,7 +99,7 @@ func (p *ParentHandler) GetAllParent(ctx *fiber.Ctx) error {
 
 func (p *ParentHandler) GetParentByID(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
-	locationID := ctx.Locals("location_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
 
 	if !ok || userID == 0 {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
@@ -128,7 +128,7 @@ func (p *ParentHandler) GetParentByID(ctx *fiber.Ctx) error {
 		})
 	}
 
-	parent, err := p.service.GetParentByID(id, locationID)
+	parent, err := p.service.GetParentByID(id, scope)
 
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
@@ -144,7 +144,7 @@ func (p *ParentHandler) GetParentByID(ctx *fiber.Ctx) error {
 
 func (p *ParentHandler) UpdateParentByID(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
-	locationID := ctx.Locals("location_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
 
 	if !ok || userID == 0 {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
@@ -186,7 +186,7 @@ func (p *ParentHandler) UpdateParentByID(ctx *fiber.Ctx) error {
 		})
 	}
 
-	parentResponses, err := p.service.UpdateParentByID(id, locationID, userID, req)
+	parentResponses, err := p.service.UpdateParentByID(ctx.UserContext(), id, scope, userID, req)
 
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
@@ -202,7 +202,7 @@ func (p *ParentHandler) UpdateParentByID(ctx *fiber.Ctx) error {
 
 func (p *ParentHandler) DeleteParentByID(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
-	locationID := ctx.Locals("location_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
 
 	if !ok || userID == 0 {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
@@ -231,7 +231,7 @@ func (p *ParentHandler) DeleteParentByID(ctx *fiber.Ctx) error {
 		})
 	}
 
-	err = p.service.DeleteParentByID(id, locationID, userID)
+	err = p.service.DeleteParentByID(ctx.UserContext(), id, scope, userID)
 
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
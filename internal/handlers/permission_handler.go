@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"grovia/internal/dto/responses"
+	"grovia/internal/services"
+	"grovia/pkg"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PermissionHandler struct {
+	service services.PermissionService
+}
+
+func NewPermissionHandler(service services.PermissionService) *PermissionHandler {
+	return &PermissionHandler{service: service}
+}
+
+func (p *PermissionHandler) GetPermissionMatrix(ctx *fiber.Ctx) error {
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Get Permission Matrix Success",
+		Data:    p.service.GetPermissionMatrix(),
+		Error:   nil,
+	})
+}
+
+func (p *PermissionHandler) GetCurrentPermissions(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+	role, _ := ctx.Locals("role").(string)
+
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Data:    nil,
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	permissions, err := p.service.GetRolePermissions(role)
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Get Current Permissions Success",
+		Data:    permissions,
+		Error:   nil,
+	})
+}
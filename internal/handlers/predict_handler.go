@@ -18,8 +18,9 @@ func NewPredictHandler(service services.PredictService) *PredictHandler {
 	return &PredictHandler{service: service}
 }
 
-func (h *PredictHandler) CreateGroupPredict(ctx *fiber.Ctx) error {
+func (h *PredictHandler) PreviewGroupPredict(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
 
 	if !ok || userID == 0 {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
@@ -44,31 +45,70 @@ func (h *PredictHandler) CreateGroupPredict(ctx *fiber.Ctx) error {
 		})
 	}
 
-	filePath := "./uploads/" + file.Filename
-	if err := ctx.SaveFile(file, filePath); err != nil {
-		return pkg.HandleServiceError(ctx, err)
+	src, err := file.Open()
+	if err != nil {
+		return pkg.HandleServiceError(ctx, pkg.NewBadRequestError("File tidak dapat dibuka"))
 	}
+	defer src.Close()
 
-	data, err := h.service.CreateGroupPredict(filePath)
+	preview, err := h.service.PreviewGroupPredict(src, file.Filename, scope)
 	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(responses.BaseResponse{
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Preview group predict success",
+		Data:    preview,
+	})
+}
+
+func (h *PredictHandler) ConfirmGroupPredict(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
 			Success: false,
-			Message: "Failed to process group predict",
+			Message: "Unauthorized",
 			Error: responses.ErrorResponse{
-				Code:    "INTERNAL_SERVER_ERROR",
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	var req requests.ConfirmGroupPredictRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid Request",
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
 				Message: err.Error(),
 			},
 		})
 	}
 
-	ctx.Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
-	ctx.Set("Content-Disposition", "attachment; filename=hasil_prediksi.xlsx")
-	return ctx.Send(data)
+	if err := pkg.ValidateStruct(req); err != nil {
+		return pkg.HandleServiceError(ctx, pkg.NewBadRequestError(err.Error()))
+	}
+
+	job, err := h.service.ConfirmGroupPredict(req.PreviewToken, scope)
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusAccepted).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Group predict queued",
+		Data:    job,
+	})
 }
 
 func (h *PredictHandler) GetAllPredict(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
-	locationID := ctx.Locals("location_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
 	pageStr := ctx.Query("page")
 	limitStr := ctx.Query("limit")
 
@@ -83,7 +123,19 @@ func (h *PredictHandler) GetAllPredict(ctx *fiber.Ctx) error {
 		})
 	}
 
-	predicts, meta, err := h.service.GetAllPredict(locationID, pageStr, limitStr)
+	var query requests.PredictQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid Request",
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	predicts, meta, err := h.service.GetAllPredict(scope, query, pageStr, limitStr)
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
 	}
@@ -98,7 +150,7 @@ func (h *PredictHandler) GetAllPredict(ctx *fiber.Ctx) error {
 
 func (h *PredictHandler) GetAllPredictByToddlerID(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
-	locationID := ctx.Locals("location_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
 
 	if !ok || userID == 0 {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
@@ -124,7 +176,7 @@ func (h *PredictHandler) GetAllPredictByToddlerID(ctx *fiber.Ctx) error {
 		})
 	}
 
-	predicts, err := h.service.GetAllPredictByToddlerID(locationID, toddlerID)
+	predicts, err := h.service.GetAllPredictByToddlerID(scope, toddlerID)
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
 	}
@@ -138,6 +190,7 @@ func (h *PredictHandler) GetAllPredictByToddlerID(ctx *fiber.Ctx) error {
 
 func (h *PredictHandler) GetPredictByID(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
 
 	if !ok || userID == 0 {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
@@ -163,7 +216,7 @@ func (h *PredictHandler) GetPredictByID(ctx *fiber.Ctx) error {
 		})
 	}
 
-	predict, err := h.service.GetPredictByID(id)
+	predict, err := h.service.GetPredictByID(id, scope)
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
 	}
@@ -177,6 +230,7 @@ func (h *PredictHandler) GetPredictByID(ctx *fiber.Ctx) error {
 
 func (h *PredictHandler) UpdatePredictByID(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
 
 	if !ok || userID == 0 {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
@@ -214,7 +268,7 @@ func (h *PredictHandler) UpdatePredictByID(ctx *fiber.Ctx) error {
 		})
 	}
 
-	updated, err := h.service.UpdatePredictByID(id, &req)
+	updated, err := h.service.UpdatePredictByID(ctx.UserContext(), id, scope, userID, &req)
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
 	}
@@ -226,9 +280,49 @@ func (h *PredictHandler) UpdatePredictByID(ctx *fiber.Ctx) error {
 	})
 }
 
+func (h *PredictHandler) GetPredictRevisions(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	idParam := ctx.Params("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid predict ID",
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	revisions, err := h.service.GetPredictRevisions(id, scope)
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Get predict revisions success",
+		Data:    revisions,
+	})
+}
+
 func (h *PredictHandler) DeletePredictByID(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
-	locationID := ctx.Locals("location_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
 
 	if !ok || userID == 0 {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
@@ -254,7 +348,7 @@ func (h *PredictHandler) DeletePredictByID(ctx *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.service.DeletePredictByID(id, locationID, userID); err != nil {
+	if err := h.service.DeletePredictByID(ctx.UserContext(), id, scope, userID); err != nil {
 		return pkg.HandleServiceError(ctx, err)
 	}
 
@@ -281,7 +375,19 @@ func (h *PredictHandler) GetAllPredictAllLocation(ctx *fiber.Ctx) error {
 		})
 	}
 
-	predictResponses, meta, err := h.service.GetAllPredictAllLocation(pageStr, limitStr)
+	var query requests.PredictQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid Request",
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	predictResponses, meta, err := h.service.GetAllPredictAllLocation(query, pageStr, limitStr)
 
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"grovia/internal/dto/responses"
+	"grovia/internal/services"
+	"grovia/pkg"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReportHandler struct {
+	service services.ReportService
+}
+
+func NewReportHandler(service services.ReportService) *ReportHandler {
+	return &ReportHandler{service: service}
+}
+
+func (h *ReportHandler) GetSKDN(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	report, err := h.service.GetSKDN(scope, ctx.Query("locationId"), ctx.Query("month"))
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	if ctx.Query("format") == "xlsx" {
+		data, fileName, err := h.service.ExportSKDN(report)
+		if err != nil {
+			return pkg.HandleServiceError(ctx, err)
+		}
+
+		ctx.Attachment(fileName)
+		return ctx.Send(data)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Get SKDN report success",
+		Data:    report,
+	})
+}
+
+func (h *ReportHandler) GetPrevalence(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	prevalence, err := h.service.GetPrevalence(scope, ctx.Query("locationId"), ctx.Query("groupBy"), ctx.Query("from"), ctx.Query("to"))
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Get prevalence success",
+		Data:    prevalence,
+	})
+}
+
+func (h *ReportHandler) GetShadowReport(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	report, err := h.service.GetShadowReport(scope, ctx.Query("locationId"), ctx.Query("modelVersion"), ctx.Query("from"), ctx.Query("to"))
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Get shadow model report success",
+		Data:    report,
+	})
+}
+
+func (h *ReportHandler) GetImmunizationReport(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	report, err := h.service.GetImmunizationReport(scope, ctx.Query("locationId"), ctx.Query("from"), ctx.Query("to"))
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Get immunization report success",
+		Data:    report,
+	})
+}
@@ -50,7 +50,7 @@ func (t *ToddlerHandler) CreateToddler(ctx *fiber.Ctx) error {
 
 	req.LocationID = locationID
 
-	toddlerResp, predictResponse, err := t.service.CreateToddler(req, userID)
+	toddlerResp, predictResponse, err := t.service.CreateToddler(ctx.UserContext(), req, userID)
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
 	}
@@ -99,7 +99,7 @@ func (t *ToddlerHandler) CreateToddlerWithParent(ctx *fiber.Ctx) error {
 	req.Toddler.LocationID = locationID
 	req.Parent.LocationID = locationID
 
-	toddlerResponse, parentResp, predictResponse, err := t.service.CreateToddlerWithParent(req.Toddler, req.Parent, userID)
+	toddlerResponse, parentResp, predictResponse, err := t.service.CreateToddlerWithParent(ctx.UserContext(), req.Toddler, req.Parent, userID)
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
 	}
@@ -118,7 +118,7 @@ func (t *ToddlerHandler) CreateToddlerWithParent(ctx *fiber.Ctx) error {
 
 func (t *ToddlerHandler) GetAllToddler(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
-	locationID := ctx.Locals("location_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
 
 	name := ctx.Query("name")
 	pageStr := ctx.Query("page")
@@ -135,7 +135,7 @@ func (t *ToddlerHandler) GetAllToddler(ctx *fiber.Ctx) error {
 		})
 	}
 
-	toddlers, meta, err := t.service.GetAllToddler(locationID, name, pageStr, limitStr)
+	toddlers, meta, err := t.service.GetAllToddler(scope, name, pageStr, limitStr)
 
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
@@ -152,7 +152,7 @@ func (t *ToddlerHandler) GetAllToddler(ctx *fiber.Ctx) error {
 
 func (t *ToddlerHandler) GetToddlerByID(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
-	locationID := ctx.Locals("location_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
 
 	if !ok || userID == 0 {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
@@ -181,7 +181,7 @@ func (t *ToddlerHandler) GetToddlerByID(ctx *fiber.Ctx) error {
 		})
 	}
 
-	toddler, err := t.service.GetToddlerByID(id, locationID)
+	toddler, err := t.service.GetToddlerByID(id, scope)
 
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
@@ -197,7 +197,7 @@ func (t *ToddlerHandler) GetToddlerByID(ctx *fiber.Ctx) error {
 
 func (t *ToddlerHandler) UpdateToddlerByID(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
-	locationID := ctx.Locals("location_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
 
 	if !ok || userID == 0 {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
@@ -261,7 +261,7 @@ func (t *ToddlerHandler) UpdateToddlerByID(ctx *fiber.Ctx) error {
 		})
 	}
 
-	toddlerResponse, predictResponse, err := t.service.UpdateToddlerByID(ctx.Context(), id, locationID, userID, req)
+	toddlerResponse, predictResponse, err := t.service.UpdateToddlerByID(ctx.UserContext(), id, scope, userID, req)
 
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
@@ -280,7 +280,7 @@ func (t *ToddlerHandler) UpdateToddlerByID(ctx *fiber.Ctx) error {
 
 func (t *ToddlerHandler) UpdateToddlerByIDWithoutPredict(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
-	locationID := ctx.Locals("location_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
 
 	if !ok || userID == 0 {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
@@ -344,7 +344,7 @@ func (t *ToddlerHandler) UpdateToddlerByIDWithoutPredict(ctx *fiber.Ctx) error {
 		})
 	}
 
-	toddlerResponse, err := t.service.UpdateToddlerByIDWithoutPredict(ctx.Context(), id, locationID, userID, req)
+	toddlerResponse, err := t.service.UpdateToddlerByIDWithoutPredict(ctx.UserContext(), id, scope, userID, req)
 
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
@@ -360,7 +360,7 @@ func (t *ToddlerHandler) UpdateToddlerByIDWithoutPredict(ctx *fiber.Ctx) error {
 
 func (t *ToddlerHandler) DeleteToddlerByID(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
-	locationID := ctx.Locals("location_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
 
 	if !ok || userID == 0 {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
@@ -389,7 +389,7 @@ func (t *ToddlerHandler) DeleteToddlerByID(ctx *fiber.Ctx) error {
 		})
 	}
 
-	err = t.service.DeleteToddlerByID(id, locationID, userID)
+	err = t.service.DeleteToddlerByID(ctx.UserContext(), id, scope, userID)
 
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
@@ -486,3 +486,72 @@ func (t *ToddlerHandler) GetAllToddlerAllLocation(ctx *fiber.Ctx) error {
 		Error:   nil,
 	})
 }
+
+func (t *ToddlerHandler) GetFalteringToddlers(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Data:    nil,
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	faltering, err := t.service.GetFalteringToddlers(scope)
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(responses.BaseResponse{
+		Success: true,
+		Message: "Get Growth Faltering Toddlers Success",
+		Data:    faltering,
+		Error:   nil,
+	})
+}
+
+func (t *ToddlerHandler) GetGrowthChart(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(int)
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
+
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Data:    nil,
+			Error: responses.ErrorResponse{
+				Code:    "UNAUTHORIZED",
+				Message: "Unauthorized",
+			},
+		})
+	}
+
+	idParam := ctx.Params("id")
+	id, err := strconv.Atoi(idParam)
+
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.BaseResponse{
+			Success: false,
+			Message: "Invalid Request",
+			Data:    nil,
+			Error: responses.ErrorResponse{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	data, contentType, err := t.service.GetGrowthChart(id, scope, ctx.Query("indicator"), ctx.Query("format"))
+	if err != nil {
+		return pkg.HandleServiceError(ctx, err)
+	}
+
+	ctx.Set("Content-Type", contentType)
+	return ctx.Send(data)
+}
@@ -54,7 +54,7 @@ func (u *UserHandler) CreateUser(ctx *fiber.Ctx) error {
 		req.ProfilePicture = file
 	}
 
-	user, err := u.service.CreateUser(ctx.Context(), req, role, locationID)
+	user, err := u.service.CreateUser(ctx.UserContext(), req, role, locationID)
 
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
@@ -143,8 +143,7 @@ func (u *UserHandler) GetUserByID(ctx *fiber.Ctx) error {
 
 func (u *UserHandler) GetUsersByRole(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(int)
-	locationID := ctx.Locals("location_id").(int)
-	role := ctx.Locals("role")
+	scope, _ := ctx.Locals("scope").(pkg.AccessScope)
 	name := ctx.Query("name")
 	pageStr := ctx.Query("page")
 	limitStr := ctx.Query("limit")
@@ -161,7 +160,7 @@ func (u *UserHandler) GetUsersByRole(ctx *fiber.Ctx) error {
 		})
 	}
 
-	user, meta, err := u.service.GetUsersByRole(role.(string), name, pageStr, limitStr, locationID)
+	user, meta, err := u.service.GetUsersByRole(scope, name, pageStr, limitStr)
 
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
@@ -209,7 +208,7 @@ func (u *UserHandler) UpdateCurrentUser(ctx *fiber.Ctx) error {
 		req.ProfilePicture = file
 	}
 
-	user, err := u.service.UpdateCurrentUser(ctx.Context(), userID, req)
+	user, err := u.service.UpdateCurrentUser(ctx.UserContext(), userID, req)
 
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
@@ -271,7 +270,7 @@ func (u *UserHandler) UpdateUserByID(ctx *fiber.Ctx) error {
 		})
 	}
 
-	user, err := u.service.UpdateUserByID(ctx.Context(), id, req, role.(string))
+	user, err := u.service.UpdateUserByID(ctx.UserContext(), id, req, role.(string))
 
 	if err != nil {
 		return pkg.HandleServiceError(ctx, err)
@@ -300,7 +299,7 @@ func (u *UserHandler) DeleteCurrentUser(ctx *fiber.Ctx) error {
 		})
 	}
 
-	if err := u.service.DeleteCurrentUser(userID); err != nil {
+	if err := u.service.DeleteCurrentUser(ctx.UserContext(), userID); err != nil {
 		return pkg.HandleServiceError(ctx, err)
 	}
 
@@ -341,7 +340,7 @@ func (u *UserHandler) DeleteUserByID(ctx *fiber.Ctx) error {
 		})
 	}
 
-	if err := u.service.DeleteUserByID(id, role.(string)); err != nil {
+	if err := u.service.DeleteUserByID(ctx.UserContext(), id, role.(string)); err != nil {
 		return pkg.HandleServiceError(ctx, err)
 	}
 
@@ -0,0 +1,142 @@
+// Package imaging prepares uploaded photos for storage: it checks the real
+// content type, turns the picture upright, drops all metadata (EXIF, GPS)
+// by re-encoding, and produces resized copies.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+const (
+	// ContentType is the type of every encoded image.
+	ContentType = "image/jpeg"
+	// Extension matches ContentType.
+	Extension = ".jpg"
+
+	jpegQuality = 82
+	// maxPixels guards against small files that decode into huge images.
+	maxPixels = 40_000_000
+)
+
+var (
+	ErrUnsupportedType = errors.New("unsupported image type")
+	ErrInvalidImage    = errors.New("invalid image")
+	ErrImageTooLarge   = errors.New("image dimensions too large")
+)
+
+// allowedTypes are the formats phones and browsers upload and the
+// standard library can decode.
+var allowedTypes = []string{"image/jpeg", "image/png"}
+
+// Variant is a resized copy of a picture, fitted within Size x Size.
+type Variant struct {
+	Name string
+	Size int
+}
+
+// Full is the stored picture itself; the others are thumbnails of it.
+var (
+	Full      = Variant{Name: "full", Size: 1600}
+	Medium    = Variant{Name: "medium", Size: 640}
+	Thumbnail = Variant{Name: "thumbnail", Size: 160}
+)
+
+// Variants lists every size stored for an upload, largest first.
+var Variants = []Variant{Full, Medium, Thumbnail}
+
+// Decode detects the content type from the bytes, not the file name, and
+// returns the picture upright on an opaque white background.
+func Decode(data []byte) (*image.RGBA, error) {
+	if !mimetype.EqualsAny(mimetype.Detect(data).String(), allowedTypes...) {
+		return nil, ErrUnsupportedType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	bounds := src.Bounds()
+	img := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(img, img.Bounds(), src, bounds.Min, draw.Over)
+
+	return orient(img, exifOrientation(data)), nil
+}
+
+// Resize fits img within size x size, keeping the aspect ratio. Smaller
+// pictures are returned as they are.
+func Resize(img *image.RGBA, size int) *image.RGBA {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	if w <= size && h <= size {
+		return img
+	}
+
+	dw, dh := size, h*size/w
+	if h > w {
+		dw, dh = w*size/h, size
+	}
+	dw, dh = max(dw, 1), max(dh, 1)
+
+	return boxResize(img, dw, dh)
+}
+
+// EncodeJPEG encodes img without any metadata.
+func EncodeJPEG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// boxResize averages the source pixels covered by each destination pixel,
+// which keeps thumbnails free of the aliasing nearest-neighbour gives.
+func boxResize(src *image.RGBA, dw, dh int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		y0, y1 := y*sh/dh, max((y+1)*sh/dh, y*sh/dh+1)
+		for x := 0; x < dw; x++ {
+			x0, x1 := x*sw/dw, max((x+1)*sw/dw, x*sw/dw+1)
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += int(p[0])
+					g += int(p[1])
+					b += int(p[2])
+					a += int(p[3])
+					n++
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,252 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+var (
+	red  = color.RGBA{0xff, 0, 0, 0xff}
+	blue = color.RGBA{0, 0, 0xff, 0xff}
+)
+
+// halves is a w x h picture, red on the left and blue on the right.
+func halves(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if x < w/2 {
+				img.SetRGBA(x, y, red)
+			} else {
+				img.SetRGBA(x, y, blue)
+			}
+		}
+	}
+	return img
+}
+
+// exifSegment builds an APP1 segment with one orientation entry, followed
+// by a GPS-looking payload that must not survive processing.
+func exifSegment(order binary.ByteOrder, orientation uint16) []byte {
+	tiff := make([]byte, 8+2+12+4)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 1)
+	order.PutUint16(tiff[10:], orientationTag)
+	order.PutUint16(tiff[12:], 3)
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], orientation)
+	tiff = append(tiff, "GPSLatitude -6.2088 GPSLongitude 106.8456"...)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xff, 0xe1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+// withExif inserts segment right after the SOI marker of a JPEG.
+func withExif(t *testing.T, img image.Image, segment []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+func near(got color.RGBA, want color.RGBA) bool {
+	diff := func(a, b uint8) int {
+		if a > b {
+			return int(a - b)
+		}
+		return int(b - a)
+	}
+	return diff(got.R, want.R) < 40 && diff(got.G, want.G) < 40 && diff(got.B, want.B) < 40
+}
+
+func TestExifOrientation(t *testing.T) {
+	img := halves(16, 8)
+
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{"big endian", withExif(t, img, exifSegment(binary.BigEndian, 6)), 6},
+		{"little endian", withExif(t, img, exifSegment(binary.LittleEndian, 8)), 8},
+		{"out of range", withExif(t, img, exifSegment(binary.BigEndian, 9)), 1},
+		{"no exif", withExif(t, img, nil), 1},
+		{"not a jpeg", []byte("GIF89a"), 1},
+		{"truncated segment", []byte{0xff, 0xd8, 0xff, 0xe1, 0x40, 0x00, 'E'}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exifOrientation(tt.data); got != tt.want {
+				t.Errorf("exifOrientation = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrient(t *testing.T) {
+	// A 3x2 picture with numbered pixels:
+	//   1 2 3
+	//   4 5 6
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	for i := 0; i < 6; i++ {
+		src.SetRGBA(i%3, i/3, color.RGBA{uint8(i + 1), 0, 0, 0xff})
+	}
+
+	tests := []struct {
+		orientation int
+		want        [][]uint8
+	}{
+		{1, [][]uint8{{1, 2, 3}, {4, 5, 6}}},
+		{2, [][]uint8{{3, 2, 1}, {6, 5, 4}}},
+		{3, [][]uint8{{6, 5, 4}, {3, 2, 1}}},
+		{4, [][]uint8{{4, 5, 6}, {1, 2, 3}}},
+		{5, [][]uint8{{1, 4}, {2, 5}, {3, 6}}},
+		{6, [][]uint8{{4, 1}, {5, 2}, {6, 3}}},
+		{7, [][]uint8{{6, 3}, {5, 2}, {4, 1}}},
+		{8, [][]uint8{{3, 6}, {2, 5}, {1, 4}}},
+	}
+
+	for _, tt := range tests {
+		got := orient(src, tt.orientation)
+		if got.Bounds().Dy() != len(tt.want) || got.Bounds().Dx() != len(tt.want[0]) {
+			t.Errorf("orientation %d: size %v", tt.orientation, got.Bounds().Size())
+			continue
+		}
+		for y, row := range tt.want {
+			for x, want := range row {
+				if r := got.RGBAAt(x, y).R; r != want {
+					t.Errorf("orientation %d: pixel (%d,%d) = %d, want %d", tt.orientation, x, y, r, want)
+				}
+			}
+		}
+	}
+}
+
+func TestDecodeAppliesOrientationAndStripsMetadata(t *testing.T) {
+	data := withExif(t, halves(64, 32), exifSegment(binary.BigEndian, 6))
+
+	img, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	// Turned clockwise, the red left half ends up on top.
+	if size := img.Bounds().Size(); size != image.Pt(32, 64) {
+		t.Fatalf("size = %v, want 32x64", size)
+	}
+	if got := img.RGBAAt(16, 8); !near(got, red) {
+		t.Errorf("top = %v, want red", got)
+	}
+	if got := img.RGBAAt(16, 56); !near(got, blue) {
+		t.Errorf("bottom = %v, want blue", got)
+	}
+
+	out, err := EncodeJPEG(img)
+	if err != nil {
+		t.Fatalf("EncodeJPEG: %v", err)
+	}
+	for _, leak := range []string{"Exif", "GPSLatitude"} {
+		if bytes.Contains(out, []byte(leak)) {
+			t.Errorf("encoded image still contains %q", leak)
+		}
+	}
+	if exifOrientation(out) != 1 {
+		t.Errorf("encoded image still has an orientation")
+	}
+}
+
+func TestDecodeFlattensTransparency(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := Decode(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if got := img.RGBAAt(1, 1); got != (color.RGBA{0xff, 0xff, 0xff, 0xff}) {
+		t.Errorf("transparent pixel = %v, want white", got)
+	}
+}
+
+func TestDecodeRejects(t *testing.T) {
+	var gifData bytes.Buffer
+	if err := gif.Encode(&gifData, halves(4, 4), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"text", []byte("not a picture at all"), ErrUnsupportedType},
+		{"gif", gifData.Bytes(), ErrUnsupportedType},
+		{"script named .jpg", []byte("<?php echo 'hi'; ?>"), ErrUnsupportedType},
+		{"broken jpeg", []byte{0xff, 0xd8, 0xff, 0xe0, 0, 0x10, 'J', 'F', 'I', 'F', 0}, ErrInvalidImage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(tt.data); !errors.Is(err, tt.want) {
+				t.Errorf("Decode = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestResize(t *testing.T) {
+	tests := []struct {
+		w, h, size   int
+		wantW, wantH int
+	}{
+		{3000, 1000, Full.Size, 1600, 533},
+		{1000, 3000, Full.Size, 533, 1600},
+		{800, 800, Thumbnail.Size, 160, 160},
+		{100, 50, Thumbnail.Size, 100, 50},
+		{5000, 2, Thumbnail.Size, 160, 1},
+	}
+
+	for _, tt := range tests {
+		got := Resize(image.NewRGBA(image.Rect(0, 0, tt.w, tt.h)), tt.size).Bounds().Size()
+		if got != image.Pt(tt.wantW, tt.wantH) {
+			t.Errorf("Resize(%dx%d, %d) = %v, want %dx%d", tt.w, tt.h, tt.size, got, tt.wantW, tt.wantH)
+		}
+	}
+}
+
+func TestBoxResizeAverages(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.SetRGBA(0, 0, color.RGBA{0, 0, 0, 0xff})
+	src.SetRGBA(1, 0, color.RGBA{0xff, 0xff, 0xff, 0xff})
+
+	if got := boxResize(src, 1, 1).RGBAAt(0, 0); got != (color.RGBA{0x7f, 0x7f, 0x7f, 0xff}) {
+		t.Errorf("average = %v, want mid grey", got)
+	}
+}
@@ -0,0 +1,131 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+const orientationTag = 0x0112
+
+// exifOrientation reads the EXIF orientation (1-8) of a JPEG. Anything it
+// cannot read counts as 1, upright.
+func exifOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xff || data[1] != 0xd8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xff {
+			return 1
+		}
+		marker := data[i+1]
+		if marker == 0xd8 || marker == 0x01 || (marker >= 0xd0 && marker <= 0xd7) {
+			i += 2
+			continue
+		}
+		// Start of scan: the metadata segments are all before it.
+		if marker == 0xda || marker == 0xd9 {
+			return 1
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		end := i + 2 + length
+		if length < 2 || end > len(data) {
+			return 1
+		}
+
+		segment := data[i+4 : end]
+		if marker == 0xe1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+		i = end
+	}
+
+	return 1
+}
+
+// tiffOrientation finds the orientation tag in IFD0 of a TIFF header.
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+
+	count := int(order.Uint16(tiff[ifd:]))
+	for n := 0; n < count; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) != orientationTag {
+			continue
+		}
+
+		value := int(order.Uint16(tiff[entry+8:]))
+		if value < 1 || value > 8 {
+			return 1
+		}
+		return value
+	}
+
+	return 1
+}
+
+// orient applies an EXIF orientation, so the pixels are upright and the
+// tag can be dropped.
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	// source maps a destination pixel back to the pixel it comes from.
+	source := func(x, y int) (int, int) {
+		switch orientation {
+		case 2: // mirrored
+			return w - 1 - x, y
+		case 3: // rotated 180°
+			return w - 1 - x, h - 1 - y
+		case 4: // mirrored vertically
+			return x, h - 1 - y
+		case 5: // transposed
+			return y, x
+		case 6: // needs a 90° clockwise turn
+			return y, h - 1 - x
+		case 7: // transversed
+			return w - 1 - y, h - 1 - x
+		default: // 8, needs a 90° counter-clockwise turn
+			return w - 1 - y, x
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			sx, sy := source(x, y)
+			copy(dst.Pix[dst.PixOffset(x, y):][:4], src.Pix[src.PixOffset(sx, sy):][:4])
+		}
+	}
+
+	return dst
+}
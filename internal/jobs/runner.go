@@ -0,0 +1,190 @@
+// Package jobs runs long batch operations in background workers. Jobs are
+// rows in the jobs table, so queued work survives a server restart and
+// every instance can pick it up.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"grovia/internal/models"
+	"grovia/internal/repositories"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	pollInterval      = 2 * time.Second
+	heartbeatInterval = 30 * time.Second
+	staleAfter        = 5 * time.Minute
+	maxAttempts       = 3
+)
+
+// Progress reports how many of the job's items are done.
+type Progress func(done, total int)
+
+// Result is what a finished job leaves for the client: JSON data and,
+// optionally, a file to download.
+type Result struct {
+	Data     any
+	File     []byte
+	FileName string
+}
+
+type Handler func(ctx context.Context, job *models.Job, progress Progress) (*Result, error)
+
+type Runner struct {
+	repo     repositories.JobRepository
+	workers  int
+	handlers map[string]Handler
+}
+
+func NewRunner(repo repositories.JobRepository, workers int) *Runner {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Runner{repo: repo, workers: workers, handlers: map[string]Handler{}}
+}
+
+// Register sets the handler for a job type. It must be called before Start.
+func (r *Runner) Register(jobType string, handler Handler) {
+	r.handlers[jobType] = handler
+}
+
+// Start launches the workers and the stale job reaper. They stop when ctx
+// is cancelled.
+func (r *Runner) Start(ctx context.Context) {
+	types := make([]string, 0, len(r.handlers))
+	for t := range r.handlers {
+		types = append(types, t)
+	}
+
+	r.reap()
+	go r.reapLoop(ctx)
+
+	for i := 0; i < r.workers; i++ {
+		go r.work(ctx, types)
+	}
+}
+
+func (r *Runner) work(ctx context.Context, types []string) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		job, err := r.repo.ClaimNextJob(types)
+		switch {
+		case err == nil:
+			r.run(ctx, job)
+			continue
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			log.Printf("jobs: failed to claim job: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Runner) run(ctx context.Context, job *models.Job) {
+	stop := make(chan struct{})
+	defer close(stop)
+	go r.heartbeat(job.ID, stop)
+
+	result, err := r.execute(ctx, job)
+	if err != nil {
+		log.Printf("jobs: job %d (%s) failed: %v", job.ID, job.Type, err)
+		if err := r.repo.FailJob(job.ID, err.Error()); err != nil {
+			log.Printf("jobs: failed to mark job %d failed: %v", job.ID, err)
+		}
+		return
+	}
+
+	var data *string
+	var file []byte
+	var fileName string
+	if result != nil {
+		if result.Data != nil {
+			encoded, err := json.Marshal(result.Data)
+			if err != nil {
+				log.Printf("jobs: failed to encode result of job %d: %v", job.ID, err)
+			} else {
+				value := string(encoded)
+				data = &value
+			}
+		}
+		file, fileName = result.File, result.FileName
+	}
+
+	if err := r.repo.CompleteJob(job.ID, data, file, fileName); err != nil {
+		log.Printf("jobs: failed to store result of job %d: %v", job.ID, err)
+	}
+}
+
+func (r *Runner) execute(ctx context.Context, job *models.Job) (result *Result, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+
+	handler, ok := r.handlers[job.Type]
+	if !ok {
+		return nil, fmt.Errorf("tipe job %q tidak dikenali", job.Type)
+	}
+
+	progress := func(done, total int) {
+		if err := r.repo.UpdateJobProgress(job.ID, done, total); err != nil {
+			log.Printf("jobs: failed to update progress of job %d: %v", job.ID, err)
+		}
+	}
+
+	return handler(ctx, job, progress)
+}
+
+func (r *Runner) heartbeat(id int, stop <-chan struct{}) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := r.repo.Heartbeat(id); err != nil {
+				log.Printf("jobs: failed to send heartbeat for job %d: %v", id, err)
+			}
+		}
+	}
+}
+
+func (r *Runner) reapLoop(ctx context.Context) {
+	ticker := time.NewTicker(staleAfter)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.reap()
+		}
+	}
+}
+
+func (r *Runner) reap() {
+	n, err := r.repo.RequeueStaleJobs(time.Now().Add(-staleAfter), maxAttempts)
+	if err != nil {
+		log.Printf("jobs: failed to requeue stale jobs: %v", err)
+		return
+	}
+	if n > 0 {
+		log.Printf("jobs: requeued %d interrupted jobs", n)
+	}
+}
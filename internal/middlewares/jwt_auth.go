@@ -26,7 +26,7 @@ func JWTAuth() fiber.Handler {
 		tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
 		tokenStr = strings.TrimSpace(tokenStr)
 
-		claims, err := pkg.ValidateToken(tokenStr)
+		claims, err := pkg.ValidateTokenType(tokenStr, pkg.TokenTypeAccess)
 		if err != nil {
 			return ctx.Status(fiber.StatusUnauthorized).JSON(responses.BaseResponse{
 				Success: false,
@@ -40,9 +40,17 @@ func JWTAuth() fiber.Handler {
 		}
 
 		ctx.Locals("user_id", claims.UserID)
+		ctx.Locals("parent_id", claims.ParentID)
 		ctx.Locals("role", claims.Role)
 		ctx.Locals("location_id", claims.LocationID)
 
+		scope := pkg.NewAccessScope(claims.UserID, claims.Role, claims.LocationID)
+		if claims.ParentID != 0 {
+			scope = pkg.NewParentAccessScope(claims.ParentID, claims.LocationID)
+		}
+		ctx.Locals("scope", scope)
+		ctx.SetUserContext(pkg.WithAccessScope(ctx.UserContext(), scope))
+
 		return ctx.Next()
 	}
 }
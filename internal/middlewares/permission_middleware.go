@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"grovia/internal/dto/responses"
+	"grovia/pkg"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequirePermission allows the request when the role from the JWT has the
+// permission in pkg.RolePermissions. It must run after JWTAuth.
+func RequirePermission(permission string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, ok := c.Locals("role").(string)
+		if !ok || role == "" {
+			return c.Status(fiber.StatusForbidden).JSON(responses.BaseResponse{
+				Success: false,
+				Message: "Access Denied",
+				Data:    nil,
+				Error: responses.ErrorResponse{
+					Code:    "ACCESS_DENIED",
+					Message: "No role information",
+				},
+			})
+		}
+
+		if !pkg.HasPermission(role, permission) {
+			return c.Status(fiber.StatusForbidden).JSON(responses.BaseResponse{
+				Success: false,
+				Message: "Insufficient Permissions",
+				Data:    nil,
+				Error: responses.ErrorResponse{
+					Code:    "INSUFFICIENT_PERMISSIONS",
+					Message: "Missing permission " + permission,
+				},
+			})
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,20 @@
+package middlewares
+
+import (
+	"grovia/pkg"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestMeta puts the client IP and user agent in the request's user
+// context, where the audit log picks them up.
+func RequestMeta() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		ctx.SetUserContext(pkg.WithRequestMeta(ctx.UserContext(), pkg.RequestMeta{
+			IPAddress: ctx.IP(),
+			UserAgent: ctx.Get(fiber.HeaderUserAgent),
+		}))
+
+		return ctx.Next()
+	}
+}
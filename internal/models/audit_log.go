@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// AuditLog records one create, update or delete of a health record.
+// Changes maps each changed field to its value before and after.
+type AuditLog struct {
+	ID              int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	ActorID         *int      `json:"actorId"`
+	ActorRole       string    `json:"actorRole" gorm:"type:varchar(100)"`
+	ActorLocationID *int      `json:"actorLocationId"`
+	Action          string    `json:"action" gorm:"type:varchar(20);not null"`
+	Entity          string    `json:"entity" gorm:"type:varchar(50);not null"`
+	EntityID        int       `json:"entityId" gorm:"not null"`
+	Changes         *string   `json:"changes" gorm:"type:jsonb"`
+	IPAddress       string    `json:"ipAddress" gorm:"type:varchar(100)"`
+	UserAgent       string    `json:"userAgent" gorm:"type:varchar(255)"`
+	CreatedAt       time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type GroupPredictPreview struct {
+	ID          int        `json:"id" gorm:"primaryKey;autoIncrement"`
+	Token       string     `json:"token" gorm:"type:uuid;unique;not null"`
+	LocationID  int        `json:"locationId" gorm:"not null"`
+	CreatedByID int        `json:"createdByID" gorm:"not null"`
+	FileName    string     `json:"fileName" gorm:"type:varchar(255)"`
+	File        []byte     `json:"-" gorm:"type:bytea;not null"`
+	ExpiresAt   time.Time  `json:"expiresAt" gorm:"not null"`
+	ConfirmedAt *time.Time `json:"confirmedAt"`
+	CreatedAt   time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+}
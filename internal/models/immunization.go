@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Where a toddler stands on one dose of the schedule.
+const (
+	ImmunizationStatusGiven    = "given"
+	ImmunizationStatusOverdue  = "overdue"
+	ImmunizationStatusDue      = "due"
+	ImmunizationStatusUpcoming = "upcoming"
+)
+
+// Vaccine is one dose of the national immunization schedule. The dose is
+// due from MinAgeMonths and overdue from OverdueAgeMonths. Basic doses
+// make up imunisasi dasar lengkap (IDL).
+type Vaccine struct {
+	ID               int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Code             string    `json:"code" gorm:"type:varchar(20);unique;not null"`
+	Name             string    `json:"name" gorm:"type:varchar(100);not null"`
+	Antigen          string    `json:"antigen" gorm:"type:varchar(50);not null"`
+	Dose             int       `json:"dose" gorm:"not null"`
+	MinAgeMonths     int       `json:"minAgeMonths" gorm:"not null"`
+	OverdueAgeMonths int       `json:"overdueAgeMonths" gorm:"not null"`
+	Basic            bool      `json:"basic" gorm:"not null"`
+	SortOrder        int       `json:"sortOrder" gorm:"not null"`
+	CreatedAt        time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// Immunization records a dose given to a toddler, at most once per dose.
+// LocationID is the toddler's location when it was recorded.
+type Immunization struct {
+	ID           int        `json:"id" gorm:"primaryKey;autoIncrement"`
+	ToddlerID    int        `json:"toddlerId" gorm:"not null"`
+	VaccineID    int        `json:"vaccineId" gorm:"not null"`
+	Vaccine      Vaccine    `json:"vaccine" gorm:"foreignKey:VaccineID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	LocationID   int        `json:"locationId" gorm:"not null"`
+	SessionID    *int       `json:"sessionId"`
+	GivenAt      time.Time  `json:"givenAt" gorm:"type:date;not null"`
+	BatchNumber  string     `json:"batchNumber" gorm:"type:varchar(50)"`
+	Notes        string     `json:"notes" gorm:"type:text"`
+	RecordedByID int        `json:"recordedByID" gorm:"not null"`
+	DeletedByID  *int       `json:"deletedByID"`
+	CreatedAt    time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updatedAt" gorm:"autoUpdateTime"`
+	DeletedAt    *time.Time `json:"deletedAt" gorm:"index"`
+}
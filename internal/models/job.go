@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+)
+
+type Job struct {
+	ID             int        `json:"id" gorm:"primaryKey;autoIncrement"`
+	Type           string     `json:"type" gorm:"type:varchar(50);not null"`
+	Status         string     `json:"status" gorm:"type:varchar(20);not null"`
+	LocationID     int        `json:"locationId" gorm:"not null"`
+	CreatedByID    int        `json:"createdByID" gorm:"not null"`
+	Payload        *string    `json:"payload" gorm:"type:jsonb"`
+	Input          []byte     `json:"-" gorm:"type:bytea"`
+	Progress       int        `json:"progress" gorm:"not null;default:0"`
+	Total          int        `json:"total" gorm:"not null;default:0"`
+	Result         *string    `json:"result" gorm:"type:jsonb"`
+	ResultFile     []byte     `json:"-" gorm:"type:bytea"`
+	ResultFileName string     `json:"resultFileName" gorm:"type:varchar(255)"`
+	Error          *string    `json:"error" gorm:"type:text"`
+	Attempts       int        `json:"attempts" gorm:"not null;default:0"`
+	HeartbeatAt    *time.Time `json:"heartbeatAt"`
+	StartedAt      *time.Time `json:"startedAt"`
+	FinishedAt     *time.Time `json:"finishedAt"`
+	CreatedAt      time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updatedAt" gorm:"autoUpdateTime"`
+}
@@ -3,10 +3,14 @@ package models
 import "time"
 
 type Location struct {
-	ID        int       `json:"id" gorm:"primaryKey;autoIncrement"`
-	Name      string    `json:"name" gorm:"type:varchar(100);not null"`
-	Address   string    `json:"address" gorm:"type:varchar(100)"`
-	Picture   string    `json:"picture" gorm:"type:text"`
-	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
-	UpdatedAt time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+	ID      int    `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name    string `json:"name" gorm:"type:varchar(100);not null"`
+	Address string `json:"address" gorm:"type:varchar(100)"`
+	Picture string `json:"picture" gorm:"type:text"`
+	// ScheduleDay is the day of the month the posyandu is held, and
+	// ScheduleTime its opening time as HH:MM.
+	ScheduleDay  *int      `json:"scheduleDay" gorm:"type:smallint"`
+	ScheduleTime *string   `json:"scheduleTime" gorm:"type:varchar(5)"`
+	CreatedAt    time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
 }
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// PhoneOTP is a one-time code sent to a phone number. Only the hash of the
+// code is stored, together with the flow it was sent for.
+type PhoneOTP struct {
+	ID          int        `json:"id" gorm:"primaryKey;autoIncrement"`
+	PhoneNumber string     `json:"phoneNumber" gorm:"type:varchar(100);not null;index"`
+	Purpose     string     `json:"purpose" gorm:"type:varchar(20);not null"`
+	CodeHash    string     `json:"-" gorm:"type:varchar(100);not null"`
+	Attempts    int        `json:"attempts" gorm:"not null;default:0"`
+	ExpiresAt   time.Time  `json:"expiresAt" gorm:"not null"`
+	ConsumedAt  *time.Time `json:"consumedAt"`
+	CreatedAt   time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+func (PhoneOTP) TableName() string {
+	return "phone_otps"
+}
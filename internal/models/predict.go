@@ -3,19 +3,39 @@ package models
 import "time"
 
 type Predict struct {
-	ID                int       `json:"id" gorm:"primaryKey;autoIncrement"`
-	ToddlerID         int       `json:"toddlerId" gorm:"not null"`
-	LocationID        int       `json:"locationId" gorm:"not null"`
-	CreatedByID       int       `json:"createdByID" gorm:"not null"`
-	DeletedByID       *int       `json:"deletedByID"`
-	Toddler           Toddler   `json:"toddler" gorm:"foreignKey:ToddlerID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
-	Name              string    `json:"name" gorm:"type:varchar(100);not null"`
-	Height            float64   `json:"height" gorm:"type:decimal(4,1);not null"`
-	Age               int       `json:"age" gorm:"not null"`
-	Sex               string    `json:"sex" gorm:"not null"`
-	Zscore            float64   `json:"zscore" gorm:"type:decimal(4,1);not null"`
-	NutritionalStatus string    `json:"nutritionalStatus" gorm:"type:varchar(50);not null"`
-	CreatedAt         time.Time `json:"createdAt" gorm:"autoCreateTime"`
-	UpdatedAt         time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
-	DeletedAt         *time.Time `json:"deletedAt" gorm:"index"`
+	ID                   int        `json:"id" gorm:"primaryKey;autoIncrement"`
+	ToddlerID            int        `json:"toddlerId" gorm:"not null"`
+	LocationID           int        `json:"locationId" gorm:"not null"`
+	CreatedByID          int        `json:"createdByID" gorm:"not null"`
+	DeletedByID          *int       `json:"deletedByID"`
+	Toddler              Toddler    `json:"toddler" gorm:"foreignKey:ToddlerID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Name                 string     `json:"name" gorm:"type:varchar(100);not null"`
+	Height               float64    `json:"height" gorm:"type:decimal(4,1);not null"`
+	Weight               *float64   `json:"weight" gorm:"type:decimal(4,1)"`
+	HeadCircumference    *float64   `json:"headCircumference" gorm:"type:decimal(4,1)"`
+	Age                  int        `json:"age" gorm:"not null"`
+	Sex                  string     `json:"sex" gorm:"not null"`
+	Zscore               float64    `json:"zscore" gorm:"type:decimal(5,2);not null"`
+	NutritionalStatus    string     `json:"nutritionalStatus" gorm:"type:varchar(50);not null"`
+	WhoZscore            *float64   `json:"whoZscore" gorm:"type:decimal(5,2)"`
+	WhoNutritionalStatus *string    `json:"whoNutritionalStatus" gorm:"type:varchar(50)"`
+	Source               string     `json:"source" gorm:"type:varchar(10);not null"`
+	ModelName            *string    `json:"modelName" gorm:"type:varchar(100)"`
+	ModelVersion         *string    `json:"modelVersion" gorm:"type:varchar(50)"`
+	MLResponse           *string    `json:"mlResponse" gorm:"type:jsonb"`
+	MLLatencyMs          *int       `json:"mlLatencyMs"`
+	WfaZscore            *float64   `json:"wfaZscore" gorm:"type:decimal(5,2)"`
+	WfaStatus            *string    `json:"wfaStatus" gorm:"type:varchar(50)"`
+	WfhZscore            *float64   `json:"wfhZscore" gorm:"type:decimal(5,2)"`
+	WfhStatus            *string    `json:"wfhStatus" gorm:"type:varchar(50)"`
+	BfaZscore            *float64   `json:"bfaZscore" gorm:"type:decimal(5,2)"`
+	BfaStatus            *string    `json:"bfaStatus" gorm:"type:varchar(50)"`
+	HcfaZscore           *float64   `json:"hcfaZscore" gorm:"type:decimal(5,2)"`
+	HcfaStatus           *string    `json:"hcfaStatus" gorm:"type:varchar(50)"`
+	SessionID            *int       `json:"sessionId"`
+	JobID                *int       `json:"jobId"`
+	JobRow               *int       `json:"jobRow"`
+	CreatedAt            time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt            time.Time  `json:"updatedAt" gorm:"autoUpdateTime"`
+	DeletedAt            *time.Time `json:"deletedAt" gorm:"index"`
 }
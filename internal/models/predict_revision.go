@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// PredictRevision keeps a predict as it was before one correction, so the
+// original measurement and status survive every edit. MeasurementChanged
+// means the status was predicted again from corrected measurements;
+// StatusOverridden that a person set it, giving Reason.
+type PredictRevision struct {
+	ID                 int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	PredictID          int       `json:"predictId" gorm:"not null"`
+	EditedByID         int       `json:"editedByID" gorm:"not null"`
+	MeasurementChanged bool      `json:"measurementChanged" gorm:"not null"`
+	StatusOverridden   bool      `json:"statusOverridden" gorm:"not null"`
+	Reason             *string   `json:"reason" gorm:"type:text"`
+	Height             float64   `json:"height" gorm:"type:decimal(4,1);not null"`
+	Weight             *float64  `json:"weight" gorm:"type:decimal(4,1)"`
+	HeadCircumference  *float64  `json:"headCircumference" gorm:"type:decimal(4,1)"`
+	Age                int       `json:"age" gorm:"not null"`
+	Sex                string    `json:"sex" gorm:"not null"`
+	Zscore             float64   `json:"zscore" gorm:"type:decimal(5,2);not null"`
+	NutritionalStatus  string    `json:"nutritionalStatus" gorm:"type:varchar(50);not null"`
+	Source             string    `json:"source" gorm:"type:varchar(10);not null"`
+	ModelName          *string   `json:"modelName" gorm:"type:varchar(100)"`
+	ModelVersion       *string   `json:"modelVersion" gorm:"type:varchar(50)"`
+	CreatedAt          time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// RefreshToken is the server-side record of an issued refresh token.
+// Tokens rotated from the same login share a FamilyID.
+type RefreshToken struct {
+	ID         int        `json:"id" gorm:"primaryKey;autoIncrement"`
+	JTI        string     `json:"jti" gorm:"column:jti;type:uuid;unique;not null"`
+	FamilyID   string     `json:"familyId" gorm:"type:uuid;not null;index"`
+	UserID     int        `json:"userId" gorm:"not null;index"`
+	User       User       `json:"-" gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Device     string     `json:"device" gorm:"type:varchar(255)"`
+	ExpiresAt  time.Time  `json:"expiresAt" gorm:"not null"`
+	ReplacedBy *string    `json:"replacedBy" gorm:"type:uuid"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	CreatedAt  time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+}
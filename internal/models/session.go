@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+const (
+	SessionStatusOpen   = "open"
+	SessionStatusClosed = "closed"
+)
+
+// Session is one measurement day (hari posyandu) at a location. Predicts
+// made at the location while it is open are linked to it.
+type Session struct {
+	ID         int        `json:"id" gorm:"primaryKey;autoIncrement"`
+	LocationID int        `json:"locationId" gorm:"not null"`
+	Location   Location   `json:"location" gorm:"foreignKey:LocationID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Date       time.Time  `json:"date" gorm:"type:date;not null"`
+	Status     string     `json:"status" gorm:"type:varchar(10);not null"`
+	OpenedByID int        `json:"openedByID" gorm:"not null"`
+	ClosedByID *int       `json:"closedByID"`
+	OpenedAt   time.Time  `json:"openedAt" gorm:"not null"`
+	ClosedAt   *time.Time `json:"closedAt"`
+	Notes      string     `json:"notes" gorm:"type:text"`
+	CreatedAt  time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time  `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// SessionAttendance records that a toddler came to a session, measured or
+// not. RecordedByID is nil when a predict recorded it.
+type SessionAttendance struct {
+	ID           int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	SessionID    int       `json:"sessionId" gorm:"not null"`
+	ToddlerID    int       `json:"toddlerId" gorm:"not null"`
+	RecordedByID *int      `json:"recordedByID"`
+	CreatedAt    time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// ShadowPrediction is the classification a candidate ML model gave for a
+// predict. It never changes the predict; it only records the answer next
+// to the primary and WHO statuses the predict had when it was made.
+// Error is set instead of the result when the candidate could not answer.
+type ShadowPrediction struct {
+	ID                       int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	PredictID                int       `json:"predictId" gorm:"not null"`
+	ModelName                *string   `json:"modelName" gorm:"type:varchar(100)"`
+	ModelVersion             *string   `json:"modelVersion" gorm:"type:varchar(50)"`
+	Zscore                   *float64  `json:"zscore" gorm:"type:decimal(5,2)"`
+	NutritionalStatus        *string   `json:"nutritionalStatus" gorm:"type:varchar(50)"`
+	MLResponse               *string   `json:"mlResponse" gorm:"type:jsonb"`
+	MLLatencyMs              *int      `json:"mlLatencyMs"`
+	Error                    *string   `json:"error" gorm:"type:text"`
+	PrimarySource            string    `json:"primarySource" gorm:"type:varchar(10);not null"`
+	PrimaryZscore            float64   `json:"primaryZscore" gorm:"type:decimal(5,2);not null"`
+	PrimaryNutritionalStatus string    `json:"primaryNutritionalStatus" gorm:"type:varchar(50);not null"`
+	WhoZscore                *float64  `json:"whoZscore" gorm:"type:decimal(5,2)"`
+	WhoNutritionalStatus     *string   `json:"whoNutritionalStatus" gorm:"type:varchar(50)"`
+	CreatedAt                time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
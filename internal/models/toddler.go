@@ -15,6 +15,8 @@ type Toddler struct {
 	Birthdate         time.Time `json:"birthdate" gorm:"type:date;not null"`
 	Sex               string    `json:"sex" gorm:"type:varchar(10);not null"`
 	Height            float64   `json:"height" gorm:"type:decimal(4,1)"`
+	Weight            *float64  `json:"weight" gorm:"type:decimal(4,1)"`
+	HeadCircumference *float64  `json:"headCircumference" gorm:"type:decimal(4,1)"`
 	ProfilePicture    string    `json:"profilePicture" gorm:"type:text"`
 	NutritionalStatus string    `json:"nutritionalStatus" gorm:"type:varchar(50)"`
 	CreatedAt         time.Time `json:"createdAt" gorm:"autoCreateTime"`
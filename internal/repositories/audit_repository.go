@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"grovia/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AuditFilter struct {
+	Entity   string
+	EntityID int
+	ActorID  int
+	Action   string
+	From     *time.Time
+	To       *time.Time
+}
+
+type AuditRepository interface {
+	CreateAuditLog(log *models.AuditLog) error
+	GetAllAuditLog(filter AuditFilter, limit, offset int) ([]models.AuditLog, int, error)
+}
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+// CreateAuditLog implements AuditRepository.
+func (a *auditRepository) CreateAuditLog(log *models.AuditLog) error {
+	return a.db.Create(log).Error
+}
+
+// GetAllAuditLog implements AuditRepository.
+func (a *auditRepository) GetAllAuditLog(filter AuditFilter, limit, offset int) ([]models.AuditLog, int, error) {
+	var logs []models.AuditLog
+	var total int64
+
+	db := a.db.Model(&models.AuditLog{})
+
+	if filter.Entity != "" {
+		db = db.Where("entity = ?", filter.Entity)
+	}
+	if filter.EntityID != 0 {
+		db = db.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.ActorID != 0 {
+		db = db.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		db = db.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		db = db.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		db = db.Where("created_at < ?", *filter.To)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := db.Limit(limit).Offset(offset).Order("created_at DESC, id DESC").Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, int(total), nil
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &auditRepository{db: db}
+}
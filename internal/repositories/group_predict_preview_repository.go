@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"grovia/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type GroupPredictPreviewRepository interface {
+	CreatePreview(preview *models.GroupPredictPreview) (*models.GroupPredictPreview, error)
+	GetPreviewByToken(token string) (*models.GroupPredictPreview, error)
+	ConfirmPreview(id int) error
+}
+
+type groupPredictPreviewRepository struct {
+	db *gorm.DB
+}
+
+// CreatePreview implements GroupPredictPreviewRepository. Expired previews
+// that were never confirmed are removed at the same time.
+func (g *groupPredictPreviewRepository) CreatePreview(preview *models.GroupPredictPreview) (*models.GroupPredictPreview, error) {
+	if err := g.db.Where("expires_at < NOW() AND confirmed_at IS NULL").
+		Delete(&models.GroupPredictPreview{}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := g.db.Create(preview).Error; err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+// GetPreviewByToken implements GroupPredictPreviewRepository.
+func (g *groupPredictPreviewRepository) GetPreviewByToken(token string) (*models.GroupPredictPreview, error) {
+	var preview models.GroupPredictPreview
+	if err := g.db.Where("token = ?", token).First(&preview).Error; err != nil {
+		return nil, err
+	}
+
+	return &preview, nil
+}
+
+// ConfirmPreview implements GroupPredictPreviewRepository. It returns
+// gorm.ErrRecordNotFound when the preview was already confirmed, so a
+// batch cannot be committed twice.
+func (g *groupPredictPreviewRepository) ConfirmPreview(id int) error {
+	res := g.db.Model(&models.GroupPredictPreview{}).
+		Where("id = ? AND confirmed_at IS NULL", id).
+		Update("confirmed_at", gorm.Expr("NOW()"))
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func NewGroupPredictPreviewRepository(db *gorm.DB) GroupPredictPreviewRepository {
+	return &groupPredictPreviewRepository{db: db}
+}
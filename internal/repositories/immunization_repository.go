@@ -0,0 +1,311 @@
+package repositories
+
+import (
+	"grovia/internal/models"
+	"grovia/pkg"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DueFilter narrows the doses toddlers still need. A LocationID of 0 is
+// every location; empty Status and VaccineCode match everything.
+type DueFilter struct {
+	LocationID  int
+	On          time.Time
+	Status      string
+	VaccineCode string
+}
+
+// DueImmunizationRow is a dose a toddler has reached the age for but has
+// not been given.
+type DueImmunizationRow struct {
+	ToddlerID         int
+	Name              string
+	Birthdate         time.Time
+	Sex               string
+	AgeMonths         int
+	LocationID        int
+	LocationName      string
+	ParentName        string
+	ParentPhoneNumber string
+	VaccineCode       string
+	VaccineName       string
+	MinAgeMonths      int
+	OverdueAgeMonths  int
+	Status            string
+}
+
+// IDLRow counts, for one location, the toddlers who turned one during the
+// period and those of them who got every basic dose before that birthday.
+type IDLRow struct {
+	LocationID   int
+	LocationName string
+	Cohort       int
+	Complete     int
+}
+
+// VaccineCoverageRow counts the toddlers of the same cohort who got one
+// dose before their first birthday.
+type VaccineCoverageRow struct {
+	Code  string
+	Name  string
+	Basic bool
+	Given int
+}
+
+type ImmunizationRepository interface {
+	GetVaccines() ([]models.Vaccine, error)
+	GetVaccineByCode(code string) (*models.Vaccine, error)
+	CreateImmunization(immunization *models.Immunization) (*models.Immunization, error)
+	GetImmunizationByID(id int, scope pkg.AccessScope) (*models.Immunization, error)
+	GetImmunizationByVaccine(toddlerID, vaccineID int) (*models.Immunization, error)
+	GetImmunizationsByToddlerID(toddlerID int) ([]models.Immunization, error)
+	DeleteImmunizationByID(id int, scope pkg.AccessScope, userID int) error
+	GetDueImmunizations(filter DueFilter) ([]DueImmunizationRow, error)
+	GetIDL(locationID int, start, end time.Time) ([]IDLRow, error)
+	GetVaccineCoverage(locationID int, start, end time.Time) ([]VaccineCoverageRow, error)
+}
+
+type immunizationRepository struct {
+	db *gorm.DB
+}
+
+// GetVaccines implements ImmunizationRepository.
+func (i *immunizationRepository) GetVaccines() ([]models.Vaccine, error) {
+	var vaccines []models.Vaccine
+
+	if err := i.db.Order("sort_order, id").Find(&vaccines).Error; err != nil {
+		return nil, err
+	}
+
+	return vaccines, nil
+}
+
+// GetVaccineByCode implements ImmunizationRepository.
+func (i *immunizationRepository) GetVaccineByCode(code string) (*models.Vaccine, error) {
+	var vaccine models.Vaccine
+
+	if err := i.db.Where("code = ?", code).First(&vaccine).Error; err != nil {
+		return nil, err
+	}
+
+	return &vaccine, nil
+}
+
+// CreateImmunization implements ImmunizationRepository. A dose given on
+// the day of the session open at the location is linked to it, and the
+// toddler is recorded as present.
+func (i *immunizationRepository) CreateImmunization(immunization *models.Immunization) (*models.Immunization, error) {
+	err := i.db.Transaction(func(tx *gorm.DB) error {
+		if immunization.SessionID == nil {
+			session, err := openSession(tx, immunization.LocationID)
+			if err != nil {
+				return err
+			}
+			if session != nil && session.Date.Format(time.DateOnly) == immunization.GivenAt.Format(time.DateOnly) {
+				immunization.SessionID = &session.ID
+			}
+		}
+
+		if err := tx.Create(immunization).Error; err != nil {
+			return err
+		}
+
+		if immunization.SessionID == nil {
+			return nil
+		}
+		return recordAttendance(tx, *immunization.SessionID, immunization.ToddlerID, &immunization.RecordedByID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return i.GetImmunizationByID(immunization.ID, pkg.LocationScope(immunization.LocationID))
+}
+
+// GetImmunizationByID implements ImmunizationRepository.
+func (i *immunizationRepository) GetImmunizationByID(id int, scope pkg.AccessScope) (*models.Immunization, error) {
+	var immunization models.Immunization
+
+	if err := i.db.Scopes(ScopeLocation(scope)).
+		Preload("Vaccine").
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&immunization).Error; err != nil {
+		return nil, err
+	}
+
+	return &immunization, nil
+}
+
+// GetImmunizationByVaccine implements ImmunizationRepository.
+func (i *immunizationRepository) GetImmunizationByVaccine(toddlerID, vaccineID int) (*models.Immunization, error) {
+	var immunization models.Immunization
+
+	if err := i.db.Where("toddler_id = ? AND vaccine_id = ? AND deleted_at IS NULL", toddlerID, vaccineID).First(&immunization).Error; err != nil {
+		return nil, err
+	}
+
+	return &immunization, nil
+}
+
+// GetImmunizationsByToddlerID implements ImmunizationRepository.
+func (i *immunizationRepository) GetImmunizationsByToddlerID(toddlerID int) ([]models.Immunization, error) {
+	var immunizations []models.Immunization
+
+	if err := i.db.Preload("Vaccine").
+		Where("toddler_id = ? AND deleted_at IS NULL", toddlerID).
+		Order("given_at, id").
+		Find(&immunizations).Error; err != nil {
+		return nil, err
+	}
+
+	return immunizations, nil
+}
+
+// DeleteImmunizationByID implements ImmunizationRepository.
+func (i *immunizationRepository) DeleteImmunizationByID(id int, scope pkg.AccessScope, userID int) error {
+	res := i.db.Model(&models.Immunization{}).
+		Scopes(ScopeLocation(scope)).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]any{
+			"deleted_by_id": userID,
+			"deleted_at":    gorm.Expr("NOW()"),
+		})
+
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// GetDueImmunizations implements ImmunizationRepository. Ages are in
+// completed months on filter.On, and only toddlers under five are listed.
+func (i *immunizationRepository) GetDueImmunizations(filter DueFilter) ([]DueImmunizationRow, error) {
+	query := `
+WITH toddler_ages AS (
+	SELECT t.id, t.name, t.birthdate, t.sex, t.location_id, t.parent_id,
+		CAST(EXTRACT(YEAR FROM AGE(CAST(@on AS date), t.birthdate)) * 12
+			+ EXTRACT(MONTH FROM AGE(CAST(@on AS date), t.birthdate)) AS int) AS age_months
+	FROM toddlers t
+	WHERE t.deleted_at IS NULL
+		AND t.birthdate <= CAST(@on AS date)
+		AND t.birthdate > CAST(@on AS date) - INTERVAL '60 months'
+		AND (@location_id = 0 OR t.location_id = @location_id)
+),
+due AS (
+	SELECT ta.*, v.id AS vaccine_id, v.code AS vaccine_code, v.name AS vaccine_name,
+		v.min_age_months, v.overdue_age_months, v.sort_order,
+		CASE WHEN ta.age_months >= v.overdue_age_months THEN 'overdue' ELSE 'due' END AS status
+	FROM toddler_ages ta
+	JOIN vaccines v ON v.min_age_months <= ta.age_months
+	WHERE NOT EXISTS (
+		SELECT 1 FROM immunizations i
+		WHERE i.toddler_id = ta.id AND i.vaccine_id = v.id AND i.deleted_at IS NULL
+	)
+)
+SELECT
+	d.id AS toddler_id, d.name, d.birthdate, d.sex, d.age_months,
+	d.location_id, l.name AS location_name,
+	p.name AS parent_name, p.phone_number AS parent_phone_number,
+	d.vaccine_code, d.vaccine_name, d.min_age_months, d.overdue_age_months, d.status
+FROM due d
+JOIN locations l ON l.id = d.location_id
+JOIN parents p ON p.id = d.parent_id
+WHERE (@status = '' OR d.status = @status)
+	AND (@vaccine_code = '' OR d.vaccine_code = @vaccine_code)
+ORDER BY l.name, d.name, d.id, d.sort_order`
+
+	params := map[string]any{
+		"location_id":  filter.LocationID,
+		"on":           filter.On.Format(time.DateOnly),
+		"status":       filter.Status,
+		"vaccine_code": filter.VaccineCode,
+	}
+
+	var rows []DueImmunizationRow
+	if err := i.db.Raw(query, params).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// idlCohort selects, as cohort, the live toddlers who turned one in
+// [@start, @end) and, as given, the doses each of them got before that
+// birthday.
+const idlCohort = `
+WITH cohort AS (
+	SELECT t.id, t.location_id, t.birthdate
+	FROM toddlers t
+	WHERE t.deleted_at IS NULL
+		AND t.birthdate >= CAST(@start AS date) - INTERVAL '12 months'
+		AND t.birthdate < CAST(@end AS date) - INTERVAL '12 months'
+		AND (@location_id = 0 OR t.location_id = @location_id)
+),
+given AS (
+	SELECT c.id AS toddler_id, i.vaccine_id
+	FROM cohort c
+	JOIN immunizations i ON i.toddler_id = c.id
+		AND i.deleted_at IS NULL
+		AND i.given_at < c.birthdate + INTERVAL '12 months'
+)`
+
+func idlParams(locationID int, start, end time.Time) map[string]any {
+	return map[string]any{
+		"location_id": locationID,
+		"start":       start.Format(time.DateOnly),
+		"end":         end.Format(time.DateOnly),
+	}
+}
+
+// GetIDL implements ImmunizationRepository.
+func (i *immunizationRepository) GetIDL(locationID int, start, end time.Time) ([]IDLRow, error) {
+	query := idlCohort + `
+SELECT
+	l.id AS location_id, l.name AS location_name,
+	COUNT(*) AS cohort,
+	COUNT(*) FILTER (WHERE (
+		SELECT COUNT(*) FROM given g
+		JOIN vaccines v ON v.id = g.vaccine_id AND v.basic
+		WHERE g.toddler_id = c.id
+	) = (SELECT COUNT(*) FROM vaccines WHERE basic)) AS complete
+FROM cohort c
+JOIN locations l ON l.id = c.location_id
+GROUP BY l.id, l.name
+ORDER BY l.name`
+
+	var rows []IDLRow
+	if err := i.db.Raw(query, idlParams(locationID, start, end)).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// GetVaccineCoverage implements ImmunizationRepository. Only doses due
+// before the first birthday are listed.
+func (i *immunizationRepository) GetVaccineCoverage(locationID int, start, end time.Time) ([]VaccineCoverageRow, error) {
+	query := idlCohort + `
+SELECT v.code, v.name, v.basic, COUNT(g.toddler_id) AS given
+FROM vaccines v
+LEFT JOIN given g ON g.vaccine_id = v.id
+WHERE v.min_age_months < 12
+GROUP BY v.id, v.code, v.name, v.basic, v.sort_order
+ORDER BY v.sort_order, v.id`
+
+	var rows []VaccineCoverageRow
+	if err := i.db.Raw(query, idlParams(locationID, start, end)).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func NewImmunizationRepository(db *gorm.DB) ImmunizationRepository {
+	return &immunizationRepository{db: db}
+}
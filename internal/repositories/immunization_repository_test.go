@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestGetDueImmunizationsAppliesTheFilter(t *testing.T) {
+	db, recorder := dryRunDB(t)
+	repo := NewImmunizationRepository(db)
+
+	filter := DueFilter{LocationID: 4, On: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), Status: "overdue", VaccineCode: "MR-1"}
+	if _, err := repo.GetDueImmunizations(filter); !errors.Is(err, gorm.ErrDryRunModeUnsupported) {
+		t.Fatalf("GetDueImmunizations: %v", err)
+	}
+	sql := recorder.last(t)
+
+	for _, want := range []string{
+		"AGE(CAST('2026-10-18' AS date), t.birthdate)",
+		"t.birthdate > CAST('2026-10-18' AS date) - INTERVAL '60 months'",
+		"(4 = 0 OR t.location_id = 4)",
+		"i.deleted_at IS NULL",
+		"('overdue' = '' OR d.status = 'overdue')",
+		"('MR-1' = '' OR d.vaccine_code = 'MR-1')",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("query does not contain %q:\n%s", want, sql)
+		}
+	}
+}
+
+func TestIDLQueriesSelectTheCohort(t *testing.T) {
+	db, recorder := dryRunDB(t)
+	repo := NewImmunizationRepository(db)
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 6, 0)
+
+	queries := map[string]func() error{
+		"IDL":      func() error { _, err := repo.GetIDL(0, start, end); return err },
+		"coverage": func() error { _, err := repo.GetVaccineCoverage(0, start, end); return err },
+	}
+
+	for name, run := range queries {
+		t.Run(name, func(t *testing.T) {
+			if err := run(); !errors.Is(err, gorm.ErrDryRunModeUnsupported) {
+				t.Fatalf("query: %v", err)
+			}
+			sql := recorder.last(t)
+			for _, want := range []string{
+				"t.birthdate >= CAST('2026-01-01' AS date) - INTERVAL '12 months'",
+				"t.birthdate < CAST('2026-07-01' AS date) - INTERVAL '12 months'",
+				"i.given_at < c.birthdate + INTERVAL '12 months'",
+			} {
+				if !strings.Contains(sql, want) {
+					t.Errorf("query does not contain %q:\n%s", want, sql)
+				}
+			}
+		})
+	}
+}
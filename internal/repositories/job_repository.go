@@ -0,0 +1,188 @@
+package repositories
+
+import (
+	"grovia/internal/models"
+	"grovia/pkg"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type JobRepository interface {
+	CreateJob(job *models.Job) (*models.Job, error)
+	GetAllJob(scope pkg.AccessScope, limit, offset int) ([]models.Job, int, error)
+	GetJobByID(id int, scope pkg.AccessScope) (*models.Job, error)
+	GetJobResultFile(id int, scope pkg.AccessScope) (*models.Job, error)
+	ClaimNextJob(types []string) (*models.Job, error)
+	Heartbeat(id int) error
+	UpdateJobProgress(id, progress, total int) error
+	CompleteJob(id int, result *string, file []byte, fileName string) error
+	FailJob(id int, message string) error
+	RequeueStaleJobs(staleBefore time.Time, maxAttempts int) (int, error)
+}
+
+type jobRepository struct {
+	db *gorm.DB
+}
+
+// blobColumns are left out of listings; they can be several megabytes.
+var blobColumns = []string{"input", "result_file"}
+
+// CreateJob implements JobRepository.
+func (j *jobRepository) CreateJob(job *models.Job) (*models.Job, error) {
+	if err := j.db.Create(job).Error; err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// GetAllJob implements JobRepository.
+func (j *jobRepository) GetAllJob(scope pkg.AccessScope, limit, offset int) ([]models.Job, int, error) {
+	var jobs []models.Job
+	var total int64
+
+	db := j.db.Model(&models.Job{}).Scopes(ScopeLocation(scope))
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := db.Omit(blobColumns...).Limit(limit).Offset(offset).Order("created_at DESC").Find(&jobs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return jobs, int(total), nil
+}
+
+// GetJobByID implements JobRepository.
+func (j *jobRepository) GetJobByID(id int, scope pkg.AccessScope) (*models.Job, error) {
+	var job models.Job
+
+	db := j.db.Omit(blobColumns...).Scopes(ScopeLocation(scope)).Where("id = ?", id)
+
+	if err := db.First(&job).Error; err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// GetJobResultFile implements JobRepository.
+func (j *jobRepository) GetJobResultFile(id int, scope pkg.AccessScope) (*models.Job, error) {
+	var job models.Job
+
+	db := j.db.Select("id", "status", "result_file", "result_file_name").Scopes(ScopeLocation(scope)).Where("id = ?", id)
+
+	if err := db.First(&job).Error; err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// ClaimNextJob implements JobRepository. The oldest queued job of one of
+// the given types is locked and marked running; SKIP LOCKED lets several
+// workers, also across server instances, poll the same table. It returns
+// gorm.ErrRecordNotFound when there is nothing to do.
+func (j *jobRepository) ClaimNextJob(types []string) (*models.Job, error) {
+	var job models.Job
+
+	err := j.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND type IN ?", models.JobStatusQueued, types).
+			Order("id").
+			First(&job).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		job.Status = models.JobStatusRunning
+		job.Attempts++
+		job.StartedAt = &now
+		job.HeartbeatAt = &now
+
+		return tx.Model(&models.Job{}).Where("id = ?", job.ID).Updates(map[string]any{
+			"status":       job.Status,
+			"attempts":     job.Attempts,
+			"started_at":   now,
+			"heartbeat_at": now,
+			"updated_at":   now,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// Heartbeat implements JobRepository.
+func (j *jobRepository) Heartbeat(id int) error {
+	return j.db.Model(&models.Job{}).Where("id = ?", id).
+		Update("heartbeat_at", gorm.Expr("NOW()")).Error
+}
+
+// UpdateJobProgress implements JobRepository.
+func (j *jobRepository) UpdateJobProgress(id, progress, total int) error {
+	return j.db.Model(&models.Job{}).Where("id = ?", id).Updates(map[string]any{
+		"progress":     progress,
+		"total":        total,
+		"heartbeat_at": gorm.Expr("NOW()"),
+	}).Error
+}
+
+// CompleteJob implements JobRepository.
+func (j *jobRepository) CompleteJob(id int, result *string, file []byte, fileName string) error {
+	return j.db.Model(&models.Job{}).Where("id = ?", id).Updates(map[string]any{
+		"status":           models.JobStatusSucceeded,
+		"result":           result,
+		"result_file":      file,
+		"result_file_name": fileName,
+		"error":            nil,
+		"input":            nil,
+		"finished_at":      gorm.Expr("NOW()"),
+	}).Error
+}
+
+// FailJob implements JobRepository.
+func (j *jobRepository) FailJob(id int, message string) error {
+	return j.db.Model(&models.Job{}).Where("id = ?", id).Updates(map[string]any{
+		"status":      models.JobStatusFailed,
+		"error":       message,
+		"finished_at": gorm.Expr("NOW()"),
+	}).Error
+}
+
+// RequeueStaleJobs implements JobRepository. Running jobs whose worker
+// stopped sending heartbeats, typically because the server restarted, are
+// queued again, or failed once they used up their attempts. Handlers must
+// be safe to run again: group predict skips the rows it already stored.
+func (j *jobRepository) RequeueStaleJobs(staleBefore time.Time, maxAttempts int) (int, error) {
+	stale := j.db.Model(&models.Job{}).
+		Where("status = ? AND heartbeat_at < ?", models.JobStatusRunning, staleBefore).
+		Session(&gorm.Session{})
+
+	if err := stale.Where("attempts >= ?", maxAttempts).Updates(map[string]any{
+		"status":      models.JobStatusFailed,
+		"error":       "Job dihentikan karena server berhenti",
+		"finished_at": gorm.Expr("NOW()"),
+	}).Error; err != nil {
+		return 0, err
+	}
+
+	res := stale.Where("attempts < ?", maxAttempts).Updates(map[string]any{
+		"status":   models.JobStatusQueued,
+		"progress": 0,
+	})
+	if res.Error != nil {
+		return 0, res.Error
+	}
+
+	return int(res.RowsAffected), nil
+}
+
+func NewJobRepository(db *gorm.DB) JobRepository {
+	return &jobRepository{db: db}
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"errors"
 	"grovia/internal/models"
+	"grovia/pkg"
 	"strings"
 
 	"gorm.io/gorm"
@@ -10,10 +11,10 @@ import (
 
 type ParentRepository interface {
 	CreateParent(parent *models.Parent) (*models.Parent, error)
-	GetAllParent(locationID, limit, offset int, name string) ([]models.Parent, int, error)
-	GetParentByID(id, locationID int) (*models.Parent, error)
-	UpdateParentByID(id, locationID int, parent *models.Parent) (*models.Parent, error)
-	DeleteParentByID(id, locationID, userID int) error
+	GetAllParent(scope pkg.AccessScope, limit, offset int, name string) ([]models.Parent, int, error)
+	GetParentByID(id int, scope pkg.AccessScope) (*models.Parent, error)
+	UpdateParentByID(id int, scope pkg.AccessScope, parent *models.Parent) (*models.Parent, error)
+	DeleteParentByID(id int, scope pkg.AccessScope, userID int) error
 	FindParentByPhoneNumber(phoneNumber string) (*models.Parent, error)
 	GetAllParentAllLocation(name string, limit, offset int) ([]models.Parent, int, error)
 }
@@ -66,37 +67,21 @@ func (p *parentRepository) FindParentByPhoneNumber(phoneNumber string) (*models.
 }
 
 // DeleteParentByID implements ParentRepository.
-func (p *parentRepository) DeleteParentByID(id, locationID, userID int) error {
-	if locationID == 1 {
-		res := p.db.Model(&models.Parent{}).
-			Where("id = ?", id).
-			Updates(map[string]any{
-				"deleted_by_id": userID,
-				"deleted_at":    gorm.Expr("NOW()"),
-			})
-
-		if res.Error != nil {
-			return res.Error
-		}
+func (p *parentRepository) DeleteParentByID(id int, scope pkg.AccessScope, userID int) error {
+	res := p.db.Model(&models.Parent{}).
+		Scopes(ScopeLocation(scope)).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"deleted_by_id": userID,
+			"deleted_at":    gorm.Expr("NOW()"),
+		})
 
-		if res.RowsAffected == 0 {
-			return gorm.ErrRecordNotFound
-		}
-	} else {
-		res := p.db.Model(&models.Parent{}).
-			Where("id = ? AND location_id = ?", id, locationID).
-			Updates(map[string]any{
-				"deleted_by_id": userID,
-				"deleted_at":    gorm.Expr("NOW()"),
-			})
-
-		if res.Error != nil {
-			return res.Error
-		}
+	if res.Error != nil {
+		return res.Error
+	}
 
-		if res.RowsAffected == 0 {
-			return gorm.ErrRecordNotFound
-		}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
 	}
 
 	if err := p.db.Model(&models.Toddler{}).
@@ -107,18 +92,16 @@ func (p *parentRepository) DeleteParentByID(id, locationID, userID int) error {
 		}).Error; err != nil {
 		return err
 	}
-	
+
 	return nil
 }
 
 // GetAllParent implements ParentRepository.
-func (p *parentRepository) GetAllParent(locationID, limit, offset int, name string) ([]models.Parent, int, error) {
+func (p *parentRepository) GetAllParent(scope pkg.AccessScope, limit, offset int, name string) ([]models.Parent, int, error) {
 	var parents []models.Parent
 	var total int64
 
-	db := p.db.Model(&parents).Where("deleted_at IS NULL")
-
-	db = db.Where("location_id = ?", locationID)
+	db := p.db.Model(&parents).Scopes(ScopeLocation(scope)).Where("deleted_at IS NULL")
 
 	if strings.TrimSpace(name) != "" {
 		normalizedName := strings.ToLower(strings.ReplaceAll(name, " ", ""))
@@ -137,73 +120,39 @@ func (p *parentRepository) GetAllParent(locationID, limit, offset int, name stri
 }
 
 // GetParentByID implements ParentRepository.
-func (p *parentRepository) GetParentByID(id, locationID int) (*models.Parent, error) {
+func (p *parentRepository) GetParentByID(id int, scope pkg.AccessScope) (*models.Parent, error) {
 	var parent models.Parent
 
-	db := p.db.Preload("Toddlers", func(tx *gorm.DB) *gorm.DB {
+	tx := p.db.Preload("Toddlers", func(tx *gorm.DB) *gorm.DB {
 		return tx.Where("deleted_at IS NULL")
-	})
-
-	if locationID == 1 {
-		tx := db.Where("id = ?", id).Find(&parent)
+	}).Scopes(ScopeLocation(scope)).Where("id = ?", id).Find(&parent)
 
-		if tx.Error != nil {
-			return nil, tx.Error
-		}
-		if tx.RowsAffected == 0 {
-			return nil, gorm.ErrRecordNotFound
-		}
-	} else {
-		tx := db.Where("id = ? AND location_id = ?", id, locationID).Find(&parent)
-
-		if tx.Error != nil {
-			return nil, tx.Error
-		}
-		if tx.RowsAffected == 0 {
-			return nil, gorm.ErrRecordNotFound
-		}
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
 	}
+
 	return &parent, nil
 }
 
 // UpdateParentByID implements ParentRepository.
-func (p *parentRepository) UpdateParentByID(id, locationID int, parent *models.Parent) (*models.Parent, error) {
-
-	if locationID == 1 {
-		err := p.db.Model(parent).Where("id = ?", id).Updates(parent).Error
-
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		err := p.db.Model(parent).Where("id = ? AND location_id = ?", id, locationID).Updates(parent).Error
+func (p *parentRepository) UpdateParentByID(id int, scope pkg.AccessScope, parent *models.Parent) (*models.Parent, error) {
+	res := p.db.Model(&models.Parent{}).Scopes(ScopeLocation(scope)).Where("id = ?", id).Updates(parent)
 
-		if err != nil {
-			return nil, err
-		}
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
 	}
 
 	var parentResponse models.Parent
-
-	if locationID == 1 {
-		tx := p.db.Where("id = ?", id).First(&parentResponse)
-
-		if tx.Error != nil {
-			return nil, tx.Error
-		}
-		if tx.RowsAffected == 0 {
-			return nil, gorm.ErrRecordNotFound
-		}
-	} else {
-		tx := p.db.Where("id = ? AND location_id = ?", id, locationID).First(&parentResponse)
-
-		if tx.Error != nil {
-			return nil, tx.Error
-		}
-		if tx.RowsAffected == 0 {
-			return nil, gorm.ErrRecordNotFound
-		}
+	if err := p.db.Where("id = ?", id).First(&parentResponse).Error; err != nil {
+		return nil, err
 	}
+
 	return &parentResponse, nil
 }
 
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"grovia/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type PhoneOTPRepository interface {
+	CreateOTP(otp *models.PhoneOTP) (*models.PhoneOTP, error)
+	GetLatestOTPByPhoneNumber(phoneNumber, purpose string) (*models.PhoneOTP, error)
+	ClaimOTPAttempt(id, maxAttempts int) error
+	ConsumeOTP(id int) error
+}
+
+type phoneOTPRepository struct {
+	db *gorm.DB
+}
+
+// CreateOTP implements PhoneOTPRepository. Earlier codes for the same
+// number and purpose stop being valid, and expired ones are removed.
+func (p *phoneOTPRepository) CreateOTP(otp *models.PhoneOTP) (*models.PhoneOTP, error) {
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expires_at < NOW()").Delete(&models.PhoneOTP{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.PhoneOTP{}).
+			Where("phone_number = ? AND purpose = ? AND consumed_at IS NULL", otp.PhoneNumber, otp.Purpose).
+			Update("consumed_at", gorm.Expr("NOW()")).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(otp).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return otp, nil
+}
+
+// GetLatestOTPByPhoneNumber implements PhoneOTPRepository.
+func (p *phoneOTPRepository) GetLatestOTPByPhoneNumber(phoneNumber, purpose string) (*models.PhoneOTP, error) {
+	var otp models.PhoneOTP
+	if err := p.db.Where("phone_number = ? AND purpose = ?", phoneNumber, purpose).
+		Order("created_at DESC").
+		First(&otp).Error; err != nil {
+		return nil, err
+	}
+
+	return &otp, nil
+}
+
+// ClaimOTPAttempt implements PhoneOTPRepository. The attempt is counted
+// in the same statement that checks the limit, so concurrent guesses cannot
+// get past it; a code with no attempts left returns gorm.ErrRecordNotFound.
+func (p *phoneOTPRepository) ClaimOTPAttempt(id, maxAttempts int) error {
+	res := p.db.Model(&models.PhoneOTP{}).
+		Where("id = ? AND attempts < ?", id, maxAttempts).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// ConsumeOTP implements PhoneOTPRepository. A code that was already
+// consumed returns gorm.ErrRecordNotFound.
+func (p *phoneOTPRepository) ConsumeOTP(id int) error {
+	res := p.db.Model(&models.PhoneOTP{}).
+		Where("id = ? AND consumed_at IS NULL", id).
+		Update("consumed_at", gorm.Expr("NOW()"))
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func NewPhoneOTPRepository(db *gorm.DB) PhoneOTPRepository {
+	return &phoneOTPRepository{db: db}
+}
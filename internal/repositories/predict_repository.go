@@ -2,18 +2,32 @@ package repositories
 
 import (
 	"grovia/internal/models"
+	"grovia/pkg"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// PredictFilter narrows predict lists by provenance. Empty fields match
+// every predict.
+type PredictFilter struct {
+	Source       string
+	ModelName    string
+	ModelVersion string
+}
+
 type PredictRepository interface {
 	CreateIndividualPredict(predict *models.Predict, locationID, toddlerID int) (*models.Predict, error)
-	GetAllPredict(locationID, limit, offset int) ([]models.Predict, int, error)
-	GetAllPredictByToddlerID(locationID, toddlerID int) ([]models.Predict, error)
-	GetPredictByID(id int) (*models.Predict, error)
-	UpdatePredictByID(id int, predict *models.Predict) (*models.Predict, error)
-	DeletePredictByID(id, locationID, userID int) error
-	GetAllPredictAllLocation(limit, offset int) ([]models.Predict, int, error)
+	GetAllPredict(scope pkg.AccessScope, filter PredictFilter, limit, offset int) ([]models.Predict, int, error)
+	GetAllPredictByToddlerID(scope pkg.AccessScope, toddlerID int) ([]models.Predict, error)
+	GetPredictByID(id int, scope pkg.AccessScope) (*models.Predict, error)
+	UpdatePredictByID(id int, scope pkg.AccessScope, predict *models.Predict, revision *models.PredictRevision) (*models.Predict, error)
+	GetPredictRevisions(predictID int) ([]models.PredictRevision, error)
+	DeletePredictByID(id int, scope pkg.AccessScope, userID int) error
+	GetAllPredictAllLocation(filter PredictFilter, limit, offset int) ([]models.Predict, int, error)
+	GetRecentPredict(scope pkg.AccessScope, since time.Time) ([]models.Predict, error)
+	GetPredictByJobRow(jobID, row int) (*models.Predict, error)
 }
 
 type predictRepository struct {
@@ -21,11 +35,11 @@ type predictRepository struct {
 }
 
 // GetAllPredictAllLocation implements PredictRepository.
-func (p *predictRepository) GetAllPredictAllLocation(limit, offset int) ([]models.Predict, int, error) {
+func (p *predictRepository) GetAllPredictAllLocation(filter PredictFilter, limit, offset int) ([]models.Predict, int, error) {
 	var predicts []models.Predict
 	var total int64
 
-	db := p.db.Model(&predicts).Where("deleted_at IS NULL")
+	db := p.db.Model(&predicts).Scopes(scopePredictFilter(filter)).Where("deleted_at IS NULL")
 
 	if err := db.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -38,10 +52,33 @@ func (p *predictRepository) GetAllPredictAllLocation(limit, offset int) ([]model
 	return predicts, int(total), nil
 }
 
-// CreateIndividualPredict implements PredictRepository.
+// CreateIndividualPredict implements PredictRepository. A predict made
+// while a session is open at the location is linked to it, and the toddler
+// is recorded as present.
 func (p *predictRepository) CreateIndividualPredict(predict *models.Predict, locationID, toddlerID int) (*models.Predict, error) {
 	predict.ToddlerID = toddlerID
-	if err := p.db.Create(predict).Error; err != nil {
+
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		if predict.SessionID == nil {
+			session, err := openSession(tx, locationID)
+			if err != nil {
+				return err
+			}
+			if session != nil {
+				predict.SessionID = &session.ID
+			}
+		}
+
+		if err := tx.Create(predict).Error; err != nil {
+			return err
+		}
+
+		if predict.SessionID == nil {
+			return nil
+		}
+		return recordAttendance(tx, *predict.SessionID, toddlerID, nil)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -49,17 +86,14 @@ func (p *predictRepository) CreateIndividualPredict(predict *models.Predict, loc
 }
 
 // DeletePredictByID implements PredictRepository.
-func (p *predictRepository) DeletePredictByID(id int, locationID, userID int) error {
-	db := p.db.Model(&models.Predict{}).Where("id = ? AND deleted_at IS NULL", id)
-
-	if locationID != 1 {
-		db = db.Where("location_id = ?", locationID)
-	}
-
-	res := db.Updates(map[string]any{
-		"deleted_by_id": userID,
-		"deleted_at":    gorm.Expr("NOW()"),
-	})
+func (p *predictRepository) DeletePredictByID(id int, scope pkg.AccessScope, userID int) error {
+	res := p.db.Model(&models.Predict{}).
+		Scopes(ScopeLocation(scope), ScopeOwnToddlerRecords(scope)).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]any{
+			"deleted_by_id": userID,
+			"deleted_at":    gorm.Expr("NOW()"),
+		})
 
 	if res.Error != nil {
 		return res.Error
@@ -72,11 +106,11 @@ func (p *predictRepository) DeletePredictByID(id int, locationID, userID int) er
 }
 
 // GetAllPredict implements PredictRepository.
-func (p *predictRepository) GetAllPredict(locationID, limit, offset int) ([]models.Predict, int, error) {
+func (p *predictRepository) GetAllPredict(scope pkg.AccessScope, filter PredictFilter, limit, offset int) ([]models.Predict, int, error) {
 	var predicts []models.Predict
 	var total int64
 
-	db := p.db.Model(&predicts).Where("location_id = ? AND deleted_at IS NULL", locationID)
+	db := p.db.Model(&predicts).Scopes(ScopeLocation(scope), ScopeOwnToddlerRecords(scope), scopePredictFilter(filter)).Where("deleted_at IS NULL")
 
 	if err := db.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -90,14 +124,10 @@ func (p *predictRepository) GetAllPredict(locationID, limit, offset int) ([]mode
 }
 
 // GetAllPredictByToddlerID implements PredictRepository.
-func (p *predictRepository) GetAllPredictByToddlerID(locationID, toddlerID int) ([]models.Predict, error) {
+func (p *predictRepository) GetAllPredictByToddlerID(scope pkg.AccessScope, toddlerID int) ([]models.Predict, error) {
 	var predicts []models.Predict
 
-	db := p.db.Where("toddler_id = ? AND deleted_at IS NULL", toddlerID)
-
-	if locationID != 1 {
-		db = db.Where("location_id = ?", locationID)
-	}
+	db := p.db.Scopes(ScopeLocation(scope), ScopeOwnToddlerRecords(scope)).Where("toddler_id = ? AND deleted_at IS NULL", toddlerID)
 
 	if err := db.Order("created_at DESC").Find(&predicts).Error; err != nil {
 		return nil, err
@@ -107,35 +137,153 @@ func (p *predictRepository) GetAllPredictByToddlerID(locationID, toddlerID int)
 }
 
 // GetPredictByID implements PredictRepository.
-func (p *predictRepository) GetPredictByID(id int) (*models.Predict, error) {
+func (p *predictRepository) GetPredictByID(id int, scope pkg.AccessScope) (*models.Predict, error) {
 	var predict models.Predict
 
-	if err := p.db.Where("id = ? AND deleted_at IS NULL", id).First(&predict).Error; err != nil {
+	if err := p.db.Scopes(ScopeLocation(scope), ScopeOwnToddlerRecords(scope)).Where("id = ? AND deleted_at IS NULL", id).First(&predict).Error; err != nil {
 		return nil, err
 	}
 
 	return &predict, nil
 }
 
-// UpdatePredictByID implements PredictRepository.
-func (p *predictRepository) UpdatePredictByID(id int, predict *models.Predict) (*models.Predict, error) {
-	res := p.db.Model(&models.Predict{}).Where("id = ? AND deleted_at IS NULL", id).Updates(predict)
+// predictCorrectionColumns are the columns a correction rewrites. They
+// are written even when empty, so indicators that no longer apply are
+// cleared.
+var predictCorrectionColumns = []string{
+	"height", "weight", "head_circumference", "age", "sex",
+	"zscore", "nutritional_status", "who_zscore", "who_nutritional_status",
+	"wfa_zscore", "wfa_status", "wfh_zscore", "wfh_status",
+	"bfa_zscore", "bfa_status", "hcfa_zscore", "hcfa_status",
+	"source", "model_name", "model_version", "ml_response", "ml_latency_ms",
+	"updated_at",
+}
 
-	if res.Error != nil {
-		return nil, res.Error
+// UpdatePredictByID implements PredictRepository. The predict is locked
+// and saved as it was in revision before the correction is written, and a
+// correction of the toddler's latest predict updates the toddler's status,
+// all in one transaction.
+func (p *predictRepository) UpdatePredictByID(id int, scope pkg.AccessScope, predict *models.Predict, revision *models.PredictRevision) (*models.Predict, error) {
+	var updated models.Predict
+
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		var current models.Predict
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Scopes(ScopeLocation(scope), ScopeOwnToddlerRecords(scope)).
+			Where("id = ? AND deleted_at IS NULL", id).
+			First(&current).Error; err != nil {
+			return err
+		}
+
+		revision.PredictID = current.ID
+		revision.Height = current.Height
+		revision.Weight = current.Weight
+		revision.HeadCircumference = current.HeadCircumference
+		revision.Age = current.Age
+		revision.Sex = current.Sex
+		revision.Zscore = current.Zscore
+		revision.NutritionalStatus = current.NutritionalStatus
+		revision.Source = current.Source
+		revision.ModelName = current.ModelName
+		revision.ModelVersion = current.ModelVersion
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.Predict{}).Where("id = ?", id).Select(predictCorrectionColumns).Updates(predict).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("id = ?", id).First(&updated).Error; err != nil {
+			return err
+		}
+
+		return syncToddlerStatus(tx, &updated)
+	})
+	if err != nil {
+		return nil, err
 	}
-	if res.RowsAffected == 0 {
-		return nil, gorm.ErrRecordNotFound
+
+	return &updated, nil
+}
+
+// syncToddlerStatus copies the predict's status to its toddler when it is
+// the toddler's latest live predict, which is the one the toddler shows.
+func syncToddlerStatus(db *gorm.DB, predict *models.Predict) error {
+	query := `
+UPDATE toddlers SET nutritional_status = @status
+WHERE id = @toddler_id
+	AND NOT EXISTS (
+		SELECT 1 FROM predicts p
+		WHERE p.toddler_id = @toddler_id
+			AND p.deleted_at IS NULL
+			AND (p.created_at, p.id) > (@created_at, @id)
+	)`
+
+	return db.Exec(query, map[string]any{
+		"status":     predict.NutritionalStatus,
+		"toddler_id": predict.ToddlerID,
+		"created_at": predict.CreatedAt,
+		"id":         predict.ID,
+	}).Error
+}
+
+// GetPredictRevisions implements PredictRepository.
+func (p *predictRepository) GetPredictRevisions(predictID int) ([]models.PredictRevision, error) {
+	var revisions []models.PredictRevision
+
+	if err := p.db.Where("predict_id = ?", predictID).Order("id DESC").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// GetRecentPredict implements PredictRepository.
+func (p *predictRepository) GetRecentPredict(scope pkg.AccessScope, since time.Time) ([]models.Predict, error) {
+	var predicts []models.Predict
+
+	err := p.db.
+		Scopes(ScopeLocation(scope), ScopeOwnToddlerRecords(scope)).
+		Joins("JOIN toddlers ON toddlers.id = predicts.toddler_id AND toddlers.deleted_at IS NULL").
+		Where("predicts.deleted_at IS NULL AND predicts.created_at >= ?", since).
+		Order("predicts.toddler_id, predicts.created_at").
+		Find(&predicts).Error
+
+	if err != nil {
+		return nil, err
 	}
 
-	var predictResponse models.Predict
-	if err := p.db.Where("id = ?", id).First(&predictResponse).Error; err != nil {
+	return predicts, nil
+}
+
+// GetPredictByJobRow implements PredictRepository.
+func (p *predictRepository) GetPredictByJobRow(jobID, row int) (*models.Predict, error) {
+	var predict models.Predict
+
+	if err := p.db.Where("job_id = ? AND job_row = ?", jobID, row).First(&predict).Error; err != nil {
 		return nil, err
 	}
 
-	return &predictResponse, nil
+	return &predict, nil
+}
+
+// scopePredictFilter applies the non-empty fields of filter.
+func scopePredictFilter(filter PredictFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.Source != "" {
+			db = db.Where("predicts.source = ?", filter.Source)
+		}
+		if filter.ModelName != "" {
+			db = db.Where("predicts.model_name = ?", filter.ModelName)
+		}
+		if filter.ModelVersion != "" {
+			db = db.Where("predicts.model_version = ?", filter.ModelVersion)
+		}
+		return db
+	}
 }
 
 func NewPredictRepository(db *gorm.DB) PredictRepository {
 	return &predictRepository{db: db}
-}
\ No newline at end of file
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"grovia/internal/models"
+	"grovia/pkg"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetAllPredictFiltersByProvenance(t *testing.T) {
+	db, recorder := dryRunDB(t)
+	repo := NewPredictRepository(db)
+
+	filter := PredictFilter{Source: pkg.PredictSourceML, ModelVersion: "2026.10.1"}
+	if _, _, err := repo.GetAllPredict(pkg.AccessScope{LocationID: 3}, filter, 20, 0); err != nil {
+		t.Fatalf("GetAllPredict: %v", err)
+	}
+	sql := recorder.last(t)
+
+	for _, want := range []string{
+		"predicts.source = 'ml'",
+		"predicts.model_version = '2026.10.1'",
+		`"predicts"."location_id" = 3`,
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("query does not contain %q:\n%s", want, sql)
+		}
+	}
+	if strings.Contains(sql, "model_name") {
+		t.Errorf("empty model name still filtered:\n%s", sql)
+	}
+}
+
+func TestGetAllPredictAllLocationWithoutFilter(t *testing.T) {
+	db, recorder := dryRunDB(t)
+	repo := NewPredictRepository(db)
+
+	if _, _, err := repo.GetAllPredictAllLocation(PredictFilter{}, 20, 0); err != nil {
+		t.Fatalf("GetAllPredictAllLocation: %v", err)
+	}
+	if sql := recorder.last(t); strings.Contains(sql, "source") || strings.Contains(sql, "model_") {
+		t.Errorf("unexpected provenance filter:\n%s", sql)
+	}
+}
+
+func TestSyncToddlerStatusOnlyForTheLatestPredict(t *testing.T) {
+	db, recorder := dryRunDB(t)
+
+	predict := &models.Predict{ID: 9, ToddlerID: 4, NutritionalStatus: "stunted", CreatedAt: time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)}
+	if err := syncToddlerStatus(db, predict); err != nil {
+		t.Fatalf("syncToddlerStatus: %v", err)
+	}
+	sql := recorder.last(t)
+
+	for _, want := range []string{
+		"UPDATE toddlers SET nutritional_status = 'stunted'",
+		"WHERE id = 4",
+		"p.toddler_id = 4",
+		"p.deleted_at IS NULL",
+		"(p.created_at, p.id) > ('2026-10-01 08:00:00', 9)",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("query does not contain %q:\n%s", want, sql)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"grovia/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepository interface {
+	CreateRefreshToken(token *models.RefreshToken) (*models.RefreshToken, error)
+	FindRefreshTokenByJTI(jti string) (*models.RefreshToken, error)
+	RotateRefreshToken(oldJTI string, next *models.RefreshToken) error
+	RevokeRefreshTokenFamily(familyID string) error
+	RevokeAllRefreshTokenByUserID(userID int) error
+}
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+// CreateRefreshToken implements RefreshTokenRepository. Expired tokens of
+// the same user are removed at the same time.
+func (r *refreshTokenRepository) CreateRefreshToken(token *models.RefreshToken) (*models.RefreshToken, error) {
+	if err := r.db.Where("user_id = ? AND expires_at < NOW()", token.UserID).
+		Delete(&models.RefreshToken{}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Create(token).Error; err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// FindRefreshTokenByJTI implements RefreshTokenRepository.
+func (r *refreshTokenRepository) FindRefreshTokenByJTI(jti string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	if err := r.db.Where("jti = ?", jti).First(&token).Error; err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// RotateRefreshToken implements RefreshTokenRepository. The old token is
+// revoked and linked to its replacement in the same transaction; if it
+// was revoked concurrently, gorm.ErrRecordNotFound is returned and
+// nothing is stored.
+func (r *refreshTokenRepository) RotateRefreshToken(oldJTI string, next *models.RefreshToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.RefreshToken{}).
+			Where("jti = ? AND revoked_at IS NULL", oldJTI).
+			Updates(map[string]any{
+				"revoked_at":  gorm.Expr("NOW()"),
+				"replaced_by": next.JTI,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Create(next).Error
+	})
+}
+
+// RevokeRefreshTokenFamily implements RefreshTokenRepository.
+func (r *refreshTokenRepository) RevokeRefreshTokenFamily(familyID string) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", gorm.Expr("NOW()")).Error
+}
+
+// RevokeAllRefreshTokenByUserID implements RefreshTokenRepository.
+func (r *refreshTokenRepository) RevokeAllRefreshTokenByUserID(userID int) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", gorm.Expr("NOW()")).Error
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
@@ -0,0 +1,209 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SKDNRow holds the SKDN figures of one location for one month.
+type SKDNRow struct {
+	LocationID   int
+	LocationName string
+	S            int
+	K            int
+	D            int
+	N            int
+	T            int
+	O            int
+}
+
+// Dimensions a prevalence aggregate can be grouped by.
+const (
+	PrevalenceByLocation = "location"
+	PrevalenceByMonth    = "month"
+	PrevalenceBySex      = "sex"
+	PrevalenceByAgeBand  = "ageBand"
+)
+
+type PrevalenceFilter struct {
+	LocationID int
+	From       time.Time
+	To         time.Time
+	GroupBy    []string
+}
+
+// PrevalenceRow counts toddlers with one nutritional status. Dimensions
+// that are not grouped on are nil.
+type PrevalenceRow struct {
+	LocationID        *int
+	LocationName      *string
+	Month             *string
+	Sex               *string
+	AgeBand           *string
+	NutritionalStatus string
+	Total             int
+}
+
+type ReportRepository interface {
+	GetSKDN(locationID int, start, end time.Time) ([]SKDNRow, error)
+	GetPrevalence(filter PrevalenceFilter) ([]PrevalenceRow, error)
+}
+
+type reportRepository struct {
+	db *gorm.DB
+}
+
+// skdnQuery counts, per location, the toddlers under five registered by
+// the end of the month (S), those with a KMS record, i.e. at least one
+// measurement so far (K), those weighed during the month (D) and those
+// whose last weight of the month is higher than the last weight of the
+// previous month (N). T and O split the rest of D: weighed in both months
+// without gaining, and not weighed the month before.
+const skdnQuery = `
+WITH registered AS (
+	SELECT t.id, t.location_id
+	FROM toddlers t
+	WHERE t.deleted_at IS NULL
+		AND t.created_at < @end
+		AND t.birthdate > CAST(@end AS timestamptz) - INTERVAL '60 months'
+		AND (@location_id = 0 OR t.location_id = @location_id)
+),
+current_weight AS (
+	SELECT DISTINCT ON (p.toddler_id) p.toddler_id, p.weight
+	FROM predicts p
+	WHERE p.deleted_at IS NULL AND p.weight IS NOT NULL
+		AND p.created_at >= @start AND p.created_at < @end
+	ORDER BY p.toddler_id, p.created_at DESC
+),
+previous_weight AS (
+	SELECT DISTINCT ON (p.toddler_id) p.toddler_id, p.weight
+	FROM predicts p
+	WHERE p.deleted_at IS NULL AND p.weight IS NOT NULL
+		AND p.created_at >= @previous_start AND p.created_at < @start
+	ORDER BY p.toddler_id, p.created_at DESC
+),
+with_kms AS (
+	SELECT DISTINCT p.toddler_id
+	FROM predicts p
+	WHERE p.deleted_at IS NULL AND p.created_at < @end
+)
+SELECT
+	r.location_id,
+	l.name AS location_name,
+	COUNT(*) AS s,
+	COUNT(k.toddler_id) AS k,
+	COUNT(c.toddler_id) AS d,
+	COUNT(*) FILTER (WHERE c.weight > pw.weight) AS n,
+	COUNT(*) FILTER (WHERE c.weight <= pw.weight) AS t,
+	COUNT(*) FILTER (WHERE c.toddler_id IS NOT NULL AND pw.toddler_id IS NULL) AS o
+FROM registered r
+JOIN locations l ON l.id = r.location_id
+LEFT JOIN with_kms k ON k.toddler_id = r.id
+LEFT JOIN current_weight c ON c.toddler_id = r.id
+LEFT JOIN previous_weight pw ON pw.toddler_id = r.id
+GROUP BY r.location_id, l.name
+ORDER BY r.location_id`
+
+// GetSKDN implements ReportRepository. A locationID of 0 returns every
+// location.
+func (r *reportRepository) GetSKDN(locationID int, start, end time.Time) ([]SKDNRow, error) {
+	var rows []SKDNRow
+
+	err := r.db.Raw(skdnQuery, map[string]any{
+		"location_id":    locationID,
+		"start":          start,
+		"end":            end,
+		"previous_start": start.AddDate(0, -1, 0),
+	}).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// prevalenceColumns maps each dimension to the expressions selected for
+// it; the aliases match the PrevalenceRow fields.
+var prevalenceColumns = map[string][]string{
+	PrevalenceByLocation: {"latest.location_id AS location_id", "l.name AS location_name"},
+	PrevalenceByMonth:    {"TO_CHAR(latest.month, 'YYYY-MM') AS month"},
+	PrevalenceBySex: {`CASE
+		WHEN LOWER(latest.sex) IN ('m', 'male', 'l', 'laki-laki') THEN 'male'
+		WHEN LOWER(latest.sex) IN ('f', 'female', 'p', 'perempuan') THEN 'female'
+		ELSE LOWER(latest.sex) END AS sex`},
+	PrevalenceByAgeBand: {`CASE
+		WHEN latest.age < 12 THEN '0-11'
+		WHEN latest.age < 24 THEN '12-23'
+		WHEN latest.age < 36 THEN '24-35'
+		WHEN latest.age < 48 THEN '36-47'
+		ELSE '48-59' END AS age_band`},
+}
+
+// GetPrevalence implements ReportRepository. Only the latest predict of
+// each toddler in the period counts, or the latest of each month when
+// grouping by month, so repeated measurements do not inflate the figures.
+func (r *reportRepository) GetPrevalence(filter PrevalenceFilter) ([]PrevalenceRow, error) {
+	distinctOn := "p.toddler_id"
+	for _, dim := range filter.GroupBy {
+		if dim == PrevalenceByMonth {
+			distinctOn = "p.toddler_id, DATE_TRUNC('month', p.created_at)"
+		}
+	}
+
+	var columns []string
+	for _, dim := range filter.GroupBy {
+		expressions, ok := prevalenceColumns[dim]
+		if !ok {
+			return nil, fmt.Errorf("unknown prevalence dimension %q", dim)
+		}
+		columns = append(columns, expressions...)
+	}
+	columns = append(columns, "latest.nutritional_status", "COUNT(*) AS total")
+
+	var groupBy []string
+	for i := 1; i < len(columns); i++ {
+		groupBy = append(groupBy, fmt.Sprint(i))
+	}
+
+	query := fmt.Sprintf(`
+WITH latest AS (
+	SELECT DISTINCT ON (%s)
+		p.location_id, p.sex, p.age, p.nutritional_status,
+		DATE_TRUNC('month', p.created_at) AS month
+	FROM predicts p
+	JOIN toddlers t ON t.id = p.toddler_id AND t.deleted_at IS NULL
+	WHERE p.deleted_at IS NULL
+		AND p.age BETWEEN 0 AND 59
+		AND p.created_at >= @from AND p.created_at < @to
+		AND (@location_id = 0 OR p.location_id = @location_id)
+	ORDER BY %s, p.created_at DESC
+)
+SELECT %s
+FROM latest
+JOIN locations l ON l.id = latest.location_id
+GROUP BY %s
+ORDER BY %s`,
+		distinctOn, distinctOn,
+		strings.Join(columns, ",\n\t"),
+		strings.Join(groupBy, ", "),
+		strings.Join(groupBy, ", "))
+
+	var rows []PrevalenceRow
+	err := r.db.Raw(query, map[string]any{
+		"location_id": filter.LocationID,
+		"from":        filter.From,
+		"to":          filter.To,
+	}).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func NewReportRepository(db *gorm.DB) ReportRepository {
+	return &reportRepository{db: db}
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestGetSKDNBindsMonthBounds(t *testing.T) {
+	db, recorder := dryRunDB(t)
+	repo := NewReportRepository(db)
+
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := repo.GetSKDN(7, start, start.AddDate(0, 1, 0)); !errors.Is(err, gorm.ErrDryRunModeUnsupported) {
+		t.Fatalf("GetSKDN: %v", err)
+	}
+	sql := recorder.last(t)
+
+	for _, want := range []string{
+		"t.created_at < '2026-04-01 00:00:00'",
+		"p.created_at >= '2026-03-01 00:00:00' AND p.created_at < '2026-04-01 00:00:00'",
+		"p.created_at >= '2026-02-01 00:00:00' AND p.created_at < '2026-03-01 00:00:00'",
+		"(7 = 0 OR t.location_id = 7)",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("SKDN query does not contain %q:\n%s", want, sql)
+		}
+	}
+}
+
+func TestGetPrevalenceGroupsByDimensions(t *testing.T) {
+	db, recorder := dryRunDB(t)
+	repo := NewReportRepository(db)
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := repo.GetPrevalence(PrevalenceFilter{
+		From:    from,
+		To:      from.AddDate(0, 6, 0),
+		GroupBy: []string{PrevalenceByMonth, PrevalenceBySex},
+	})
+	if !errors.Is(err, gorm.ErrDryRunModeUnsupported) {
+		t.Fatalf("GetPrevalence: %v", err)
+	}
+	sql := recorder.last(t)
+
+	for _, want := range []string{
+		"DISTINCT ON (p.toddler_id, DATE_TRUNC('month', p.created_at))",
+		"TO_CHAR(latest.month, 'YYYY-MM') AS month",
+		"END AS sex",
+		"GROUP BY 1, 2, 3",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("prevalence query does not contain %q:\n%s", want, sql)
+		}
+	}
+
+	if _, err := repo.GetPrevalence(PrevalenceFilter{GroupBy: []string{"ward"}}); err == nil || errors.Is(err, gorm.ErrDryRunModeUnsupported) {
+		t.Error("unknown dimension was accepted")
+	}
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// sqlRecorder is a gorm logger that keeps the statements it is given, so
+// tests can inspect the SQL a repository builds without a database.
+type sqlRecorder struct {
+	logger.Interface
+	statements []string
+}
+
+func (r *sqlRecorder) LogMode(logger.LogLevel) logger.Interface {
+	return r
+}
+
+func (r *sqlRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	sql, _ := fc()
+	r.statements = append(r.statements, sql)
+}
+
+func (r *sqlRecorder) last(t *testing.T) string {
+	t.Helper()
+	if len(r.statements) == 0 {
+		t.Fatal("no statement was built")
+	}
+	return r.statements[len(r.statements)-1]
+}
+
+// dryRunDB returns a Postgres dialect session that builds statements
+// without connecting. Raw queries are still recorded but return
+// gorm.ErrDryRunModeUnsupported.
+func dryRunDB(t *testing.T) (*gorm.DB, *sqlRecorder) {
+	t.Helper()
+
+	recorder := &sqlRecorder{Interface: logger.Discard}
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               recorder,
+	})
+	if err != nil {
+		t.Fatalf("open dry run session: %v", err)
+	}
+
+	return db, recorder
+}
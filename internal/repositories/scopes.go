@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"grovia/pkg"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ScopeLocation limits a query to the locations the access scope may see.
+// The column is qualified with the query's own table, so it can be used
+// on joined queries too.
+func ScopeLocation(scope pkg.AccessScope) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if scope.AllLocations {
+			return db
+		}
+
+		return db.Where(clause.Eq{
+			Column: clause.Column{Table: clause.CurrentTable, Name: "location_id"},
+			Value:  scope.LocationID,
+		})
+	}
+}
+
+// ScopeOwnToddlers limits a toddlers query to the parent's own children.
+// Staff scopes are not limited.
+func ScopeOwnToddlers(scope pkg.AccessScope) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if scope.ParentID == 0 {
+			return db
+		}
+
+		return db.Where(clause.Eq{
+			Column: clause.Column{Table: clause.CurrentTable, Name: "parent_id"},
+			Value:  scope.ParentID,
+		})
+	}
+}
+
+// ScopeOwnToddlerRecords does the same for tables that reference a
+// toddler, such as predicts.
+func ScopeOwnToddlerRecords(scope pkg.AccessScope) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if scope.ParentID == 0 {
+			return db
+		}
+
+		return db.Where(clause.Expr{
+			SQL:  "? IN (SELECT id FROM toddlers WHERE parent_id = ?)",
+			Vars: []any{clause.Column{Table: clause.CurrentTable, Name: "toddler_id"}, scope.ParentID},
+		})
+	}
+}
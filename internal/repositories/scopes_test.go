@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"grovia/internal/models"
+	"grovia/pkg"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// scopedSQL builds a query with the scopes applied; gorm adds scope
+// conditions after the query's own ones.
+func scopedSQL(t *testing.T, model any, scopes ...func(*gorm.DB) *gorm.DB) string {
+	t.Helper()
+	db, _ := dryRunDB(t)
+
+	return db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(model).Scopes(scopes...).Where("deleted_at IS NULL").Find(&[]map[string]any{})
+	})
+}
+
+func whereClause(sql string) string {
+	if i := strings.Index(sql, "WHERE "); i >= 0 {
+		return sql[i+len("WHERE "):]
+	}
+	return ""
+}
+
+func TestScopesPerRole(t *testing.T) {
+	scopes := map[string]pkg.AccessScope{
+		pkg.RoleAdmin:          pkg.NewAccessScope(1, pkg.RoleAdmin, 2),
+		pkg.RoleKepalaPosyandu: pkg.NewAccessScope(2, pkg.RoleKepalaPosyandu, 3),
+		pkg.RoleKader:          pkg.NewAccessScope(3, pkg.RoleKader, 3),
+		pkg.RoleParent:         pkg.NewParentAccessScope(7, 3),
+	}
+
+	tests := []struct {
+		name  string
+		model any
+		build func(pkg.AccessScope) []func(*gorm.DB) *gorm.DB
+		want  map[string]string
+	}{
+		{
+			name:  "ScopeLocation on locations-bound records",
+			model: &models.Parent{},
+			build: func(s pkg.AccessScope) []func(*gorm.DB) *gorm.DB {
+				return []func(*gorm.DB) *gorm.DB{ScopeLocation(s)}
+			},
+			want: map[string]string{
+				pkg.RoleAdmin:          `deleted_at IS NULL`,
+				pkg.RoleKepalaPosyandu: `deleted_at IS NULL AND "parents"."location_id" = 3`,
+				pkg.RoleKader:          `deleted_at IS NULL AND "parents"."location_id" = 3`,
+				pkg.RoleParent:         `deleted_at IS NULL AND "parents"."location_id" = 3`,
+			},
+		},
+		{
+			name:  "ScopeOwnToddlers on toddlers",
+			model: &models.Toddler{},
+			build: func(s pkg.AccessScope) []func(*gorm.DB) *gorm.DB {
+				return []func(*gorm.DB) *gorm.DB{ScopeLocation(s), ScopeOwnToddlers(s)}
+			},
+			want: map[string]string{
+				pkg.RoleAdmin:          `deleted_at IS NULL`,
+				pkg.RoleKepalaPosyandu: `deleted_at IS NULL AND "toddlers"."location_id" = 3`,
+				pkg.RoleKader:          `deleted_at IS NULL AND "toddlers"."location_id" = 3`,
+				pkg.RoleParent:         `deleted_at IS NULL AND "toddlers"."location_id" = 3 AND "toddlers"."parent_id" = 7`,
+			},
+		},
+		{
+			name:  "ScopeOwnToddlerRecords on predicts",
+			model: &models.Predict{},
+			build: func(s pkg.AccessScope) []func(*gorm.DB) *gorm.DB {
+				return []func(*gorm.DB) *gorm.DB{ScopeLocation(s), ScopeOwnToddlerRecords(s)}
+			},
+			want: map[string]string{
+				pkg.RoleAdmin:          `deleted_at IS NULL`,
+				pkg.RoleKepalaPosyandu: `deleted_at IS NULL AND "predicts"."location_id" = 3`,
+				pkg.RoleKader:          `deleted_at IS NULL AND "predicts"."location_id" = 3`,
+				pkg.RoleParent:         `deleted_at IS NULL AND "predicts"."location_id" = 3 AND "predicts"."toddler_id" IN (SELECT id FROM toddlers WHERE parent_id = 7)`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for role, want := range tt.want {
+			t.Run(tt.name+"/"+role, func(t *testing.T) {
+				got := whereClause(scopedSQL(t, tt.model, tt.build(scopes[role])...))
+				if got != want {
+					t.Errorf("WHERE %s\nwant  %s", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestLocationScopeIgnoresParentFilters(t *testing.T) {
+	scope := pkg.LocationScope(5)
+
+	got := whereClause(scopedSQL(t, &models.Predict{}, ScopeLocation(scope), ScopeOwnToddlerRecords(scope)))
+
+	if want := `deleted_at IS NULL AND "predicts"."location_id" = 5`; got != want {
+		t.Errorf("WHERE %s\nwant  %s", got, want)
+	}
+}
+
+func TestScopeLocationOnJoinedQuery(t *testing.T) {
+	db, _ := dryRunDB(t)
+	scope := pkg.NewAccessScope(3, pkg.RoleKader, 4)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&models.Predict{}).
+			Scopes(ScopeLocation(scope)).
+			Joins("JOIN toddlers ON toddlers.id = predicts.toddler_id").
+			Find(&[]models.Predict{})
+	})
+
+	if !strings.Contains(sql, `WHERE "predicts"."location_id" = 4`) {
+		t.Errorf("location column is not qualified with the query's table:\n%s", sql)
+	}
+}
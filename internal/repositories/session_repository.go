@@ -0,0 +1,253 @@
+package repositories
+
+import (
+	"errors"
+	"grovia/internal/models"
+	"grovia/pkg"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreateSession returns these when a unique index turns the session down,
+// which happens when another request created the same session first.
+var (
+	ErrSessionAlreadyOpen = errors.New("a session is already open at the location")
+	ErrSessionDateTaken   = errors.New("a session already exists at the location on the date")
+)
+
+// SessionCounts summarises a session: the toddlers under five registered
+// at the location on its date, how many came and how many were measured.
+type SessionCounts struct {
+	Registered int
+	Attended   int
+	Measured   int
+}
+
+type SessionRepository interface {
+	CreateSession(session *models.Session) (*models.Session, error)
+	GetAllSession(scope pkg.AccessScope, locationID, limit, offset int) ([]models.Session, int, error)
+	GetSessionByID(id int, scope pkg.AccessScope) (*models.Session, error)
+	GetOpenSession(locationID int) (*models.Session, error)
+	GetSessionByDate(locationID int, date time.Time) (*models.Session, error)
+	CloseSession(id int, scope pkg.AccessScope, userID int, closedAt time.Time) (*models.Session, error)
+	RecordAttendance(sessionID, toddlerID int, recordedByID *int) error
+	GetSessionCounts(session *models.Session) (*SessionCounts, error)
+	GetMeasuredPredicts(sessionID int) ([]models.Predict, error)
+	GetAbsentToddlers(session *models.Session) ([]models.Toddler, error)
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+// registeredToddlers selects the live toddlers of the session's location
+// who were registered and under five on its date. It takes the session's
+// @location_id and @date.
+const registeredToddlers = `
+SELECT t.id FROM toddlers t
+WHERE t.location_id = @location_id
+	AND t.deleted_at IS NULL
+	AND t.created_at < CAST(@date AS date) + INTERVAL '1 day'
+	AND t.birthdate <= CAST(@date AS date)
+	AND t.birthdate > CAST(@date AS date) - INTERVAL '60 months'`
+
+func sessionParams(session *models.Session) map[string]any {
+	return map[string]any{
+		"session_id":  session.ID,
+		"location_id": session.LocationID,
+		"date":        session.Date.Format(time.DateOnly),
+	}
+}
+
+// CreateSession implements SessionRepository.
+func (s *sessionRepository) CreateSession(session *models.Session) (*models.Session, error) {
+	if err := s.db.Create(session).Error; err != nil {
+		return nil, sessionConflict(err)
+	}
+
+	return session, nil
+}
+
+// sessionConflict translates a violation of the sessions unique indexes;
+// any other error is returned as is.
+func sessionConflict(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		return err
+	}
+
+	switch pgErr.ConstraintName {
+	case "idx_sessions_location_open":
+		return ErrSessionAlreadyOpen
+	case "idx_sessions_location_date":
+		return ErrSessionDateTaken
+	default:
+		return err
+	}
+}
+
+// GetAllSession implements SessionRepository. A locationID of 0 lists every
+// location in scope.
+func (s *sessionRepository) GetAllSession(scope pkg.AccessScope, locationID, limit, offset int) ([]models.Session, int, error) {
+	var sessions []models.Session
+	var total int64
+
+	db := s.db.Model(&models.Session{}).Scopes(ScopeLocation(scope))
+	if locationID != 0 {
+		db = db.Where("location_id = ?", locationID)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := db.Preload("Location").Limit(limit).Offset(offset).Order("date DESC, id DESC").Find(&sessions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return sessions, int(total), nil
+}
+
+// GetSessionByID implements SessionRepository.
+func (s *sessionRepository) GetSessionByID(id int, scope pkg.AccessScope) (*models.Session, error) {
+	var session models.Session
+
+	if err := s.db.Scopes(ScopeLocation(scope)).Preload("Location").Where("id = ?", id).First(&session).Error; err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// GetOpenSession implements SessionRepository.
+func (s *sessionRepository) GetOpenSession(locationID int) (*models.Session, error) {
+	var session models.Session
+
+	if err := s.db.Where("location_id = ? AND status = ?", locationID, models.SessionStatusOpen).First(&session).Error; err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// GetSessionByDate implements SessionRepository.
+func (s *sessionRepository) GetSessionByDate(locationID int, date time.Time) (*models.Session, error) {
+	var session models.Session
+
+	if err := s.db.Where("location_id = ? AND date = ?", locationID, date.Format(time.DateOnly)).First(&session).Error; err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// CloseSession implements SessionRepository. Only an open session is
+// closed; anything else is gorm.ErrRecordNotFound.
+func (s *sessionRepository) CloseSession(id int, scope pkg.AccessScope, userID int, closedAt time.Time) (*models.Session, error) {
+	res := s.db.Model(&models.Session{}).
+		Scopes(ScopeLocation(scope)).
+		Where("id = ? AND status = ?", id, models.SessionStatusOpen).
+		Updates(map[string]any{
+			"status":       models.SessionStatusClosed,
+			"closed_by_id": userID,
+			"closed_at":    closedAt,
+			"updated_at":   closedAt,
+		})
+
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return s.GetSessionByID(id, scope)
+}
+
+// RecordAttendance implements SessionRepository. Recording a toddler twice
+// keeps the first record.
+func (s *sessionRepository) RecordAttendance(sessionID, toddlerID int, recordedByID *int) error {
+	return recordAttendance(s.db, sessionID, toddlerID, recordedByID)
+}
+
+// openSession returns the session open at the location, or nil when there
+// is none.
+func openSession(db *gorm.DB, locationID int) (*models.Session, error) {
+	var session models.Session
+
+	err := db.Where("location_id = ? AND status = ?", locationID, models.SessionStatusOpen).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func recordAttendance(db *gorm.DB, sessionID, toddlerID int, recordedByID *int) error {
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.SessionAttendance{
+		SessionID:    sessionID,
+		ToddlerID:    toddlerID,
+		RecordedByID: recordedByID,
+	}).Error
+}
+
+// GetSessionCounts implements SessionRepository.
+func (s *sessionRepository) GetSessionCounts(session *models.Session) (*SessionCounts, error) {
+	var counts SessionCounts
+
+	query := `
+SELECT
+	(SELECT COUNT(*) FROM (` + registeredToddlers + `) registered) AS registered,
+	(SELECT COUNT(*) FROM session_attendances a
+		JOIN toddlers t ON t.id = a.toddler_id AND t.deleted_at IS NULL
+		WHERE a.session_id = @session_id) AS attended,
+	(SELECT COUNT(DISTINCT p.toddler_id) FROM predicts p
+		WHERE p.session_id = @session_id AND p.deleted_at IS NULL) AS measured`
+
+	if err := s.db.Raw(query, sessionParams(session)).Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	return &counts, nil
+}
+
+// GetMeasuredPredicts implements SessionRepository.
+func (s *sessionRepository) GetMeasuredPredicts(sessionID int) ([]models.Predict, error) {
+	var predicts []models.Predict
+
+	if err := s.db.Where("session_id = ? AND deleted_at IS NULL", sessionID).Order("name, created_at").Find(&predicts).Error; err != nil {
+		return nil, err
+	}
+
+	return predicts, nil
+}
+
+// GetAbsentToddlers implements SessionRepository. Parents are loaded so
+// kader can follow up.
+func (s *sessionRepository) GetAbsentToddlers(session *models.Session) ([]models.Toddler, error) {
+	var toddlers []models.Toddler
+
+	err := s.db.Preload("Parent").
+		Where(`id IN (`+registeredToddlers+`)
+	AND NOT EXISTS (
+		SELECT 1 FROM session_attendances a
+		WHERE a.session_id = @session_id AND a.toddler_id = toddlers.id
+	)`, sessionParams(session)).
+		Order("name, id").
+		Find(&toddlers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toddlers, nil
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{db: db}
+}
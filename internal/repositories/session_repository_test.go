@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"errors"
+	"grovia/internal/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+func testSession() *models.Session {
+	return &models.Session{ID: 12, LocationID: 4, Date: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)}
+}
+
+func TestGetAbsentToddlers(t *testing.T) {
+	db, recorder := dryRunDB(t)
+	repo := NewSessionRepository(db)
+
+	if _, err := repo.GetAbsentToddlers(testSession()); err != nil {
+		t.Fatalf("GetAbsentToddlers: %v", err)
+	}
+	sql := recorder.last(t)
+
+	for _, want := range []string{
+		"t.location_id = 4",
+		"t.deleted_at IS NULL",
+		"t.created_at < CAST('2026-10-18' AS date) + INTERVAL '1 day'",
+		"t.birthdate <= CAST('2026-10-18' AS date)",
+		"t.birthdate > CAST('2026-10-18' AS date) - INTERVAL '60 months'",
+		"a.session_id = 12 AND a.toddler_id = toddlers.id",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("query does not contain %q:\n%s", want, sql)
+		}
+	}
+}
+
+func TestGetSessionCounts(t *testing.T) {
+	db, recorder := dryRunDB(t)
+	repo := NewSessionRepository(db)
+
+	if _, err := repo.GetSessionCounts(testSession()); !errors.Is(err, gorm.ErrDryRunModeUnsupported) {
+		t.Fatalf("GetSessionCounts: %v", err)
+	}
+	sql := recorder.last(t)
+
+	for _, want := range []string{
+		"t.location_id = 4",
+		"a.session_id = 12",
+		"p.session_id = 12 AND p.deleted_at IS NULL",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("query does not contain %q:\n%s", want, sql)
+		}
+	}
+}
+
+func TestSessionConflict(t *testing.T) {
+	foreignKey := &pgconn.PgError{Code: "23503", ConstraintName: "fk_sessions_location"}
+	other := errors.New("connection reset")
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"open index", &pgconn.PgError{Code: "23505", ConstraintName: "idx_sessions_location_open"}, ErrSessionAlreadyOpen},
+		{"date index", &pgconn.PgError{Code: "23505", ConstraintName: "idx_sessions_location_date"}, ErrSessionDateTaken},
+		{"foreign key", foreignKey, foreignKey},
+		{"other error", other, other},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sessionConflict(tt.err); got != tt.want {
+				t.Errorf("sessionConflict = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"fmt"
+	"grovia/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// References the shadow model can be compared against.
+const (
+	ShadowAgainstPrimary = "primary"
+	ShadowAgainstWHO     = "who"
+)
+
+type ShadowFilter struct {
+	LocationID   int
+	ModelVersion string
+	From         time.Time
+	To           time.Time
+}
+
+// ShadowSummaryRow counts the shadow calls made and those that failed.
+type ShadowSummaryRow struct {
+	Total  int
+	Failed int
+}
+
+// ShadowConfusionRow counts the predicts the reference classified as
+// Reference and the shadow model as Shadow.
+type ShadowConfusionRow struct {
+	Reference string
+	Shadow    string
+	Total     int
+}
+
+// ShadowDisagreementRow is a predict where the shadow model disagrees with
+// the primary model or with the WHO standard.
+type ShadowDisagreementRow struct {
+	PredictID                int
+	ToddlerID                int
+	LocationID               int
+	Name                     string
+	Age                      int
+	Sex                      string
+	Height                   float64
+	ModelVersion             *string
+	Zscore                   float64
+	NutritionalStatus        string
+	PrimarySource            string
+	PrimaryZscore            float64
+	PrimaryNutritionalStatus string
+	WhoZscore                *float64
+	WhoNutritionalStatus     *string
+	CreatedAt                time.Time
+}
+
+type ShadowPredictionRepository interface {
+	CreateShadowPrediction(shadow *models.ShadowPrediction) error
+	GetShadowSummary(filter ShadowFilter) (*ShadowSummaryRow, error)
+	GetShadowConfusion(filter ShadowFilter, against string) ([]ShadowConfusionRow, error)
+	GetShadowDisagreements(filter ShadowFilter, limit int) ([]ShadowDisagreementRow, error)
+}
+
+type shadowPredictionRepository struct {
+	db *gorm.DB
+}
+
+// shadowFrom selects the shadow predictions of live predicts matching the
+// filter, as s joined with p.
+const shadowFrom = `
+FROM shadow_predictions s
+JOIN predicts p ON p.id = s.predict_id AND p.deleted_at IS NULL
+WHERE s.created_at >= @from AND s.created_at < @to
+	AND (@location_id = 0 OR p.location_id = @location_id)
+	AND (@model_version = '' OR s.model_version = @model_version)`
+
+// shadowReferences is the column each reference is read from and which
+// shadow predictions it can be compared on. The primary model is only
+// the ML API when the predict did not fall back to WHO.
+var shadowReferences = map[string]struct{ column, condition string }{
+	ShadowAgainstPrimary: {"s.primary_nutritional_status", "s.primary_source = 'ml'"},
+	ShadowAgainstWHO:     {"s.who_nutritional_status", "s.who_nutritional_status IS NOT NULL"},
+}
+
+func shadowParams(filter ShadowFilter) map[string]any {
+	return map[string]any{
+		"location_id":   filter.LocationID,
+		"model_version": filter.ModelVersion,
+		"from":          filter.From,
+		"to":            filter.To,
+	}
+}
+
+// CreateShadowPrediction implements ShadowPredictionRepository.
+func (s *shadowPredictionRepository) CreateShadowPrediction(shadow *models.ShadowPrediction) error {
+	return s.db.Create(shadow).Error
+}
+
+// GetShadowSummary implements ShadowPredictionRepository.
+func (s *shadowPredictionRepository) GetShadowSummary(filter ShadowFilter) (*ShadowSummaryRow, error) {
+	var row ShadowSummaryRow
+
+	query := `SELECT COUNT(*) AS total, COUNT(s.error) AS failed` + shadowFrom
+	if err := s.db.Raw(query, shadowParams(filter)).Scan(&row).Error; err != nil {
+		return nil, err
+	}
+
+	return &row, nil
+}
+
+// GetShadowConfusion implements ShadowPredictionRepository.
+func (s *shadowPredictionRepository) GetShadowConfusion(filter ShadowFilter, against string) ([]ShadowConfusionRow, error) {
+	reference, ok := shadowReferences[against]
+	if !ok {
+		return nil, fmt.Errorf("unknown shadow reference %q", against)
+	}
+
+	query := fmt.Sprintf(`
+SELECT %s AS reference, s.nutritional_status AS shadow, COUNT(*) AS total%s
+	AND s.error IS NULL
+	AND %s
+GROUP BY 1, 2
+ORDER BY 1, 2`, reference.column, shadowFrom, reference.condition)
+
+	var rows []ShadowConfusionRow
+	if err := s.db.Raw(query, shadowParams(filter)).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// GetShadowDisagreements implements ShadowPredictionRepository.
+func (s *shadowPredictionRepository) GetShadowDisagreements(filter ShadowFilter, limit int) ([]ShadowDisagreementRow, error) {
+	query := `
+SELECT
+	p.id AS predict_id, p.toddler_id, p.location_id, p.name, p.age, p.sex, p.height,
+	s.model_version, s.zscore, s.nutritional_status,
+	s.primary_source, s.primary_zscore, s.primary_nutritional_status,
+	s.who_zscore, s.who_nutritional_status, s.created_at` + shadowFrom + `
+	AND s.error IS NULL
+	AND (
+		(s.primary_source = 'ml' AND s.nutritional_status <> s.primary_nutritional_status)
+		OR s.nutritional_status <> s.who_nutritional_status
+	)
+ORDER BY s.created_at DESC, s.id DESC
+LIMIT @limit`
+
+	params := shadowParams(filter)
+	params["limit"] = limit
+
+	var rows []ShadowDisagreementRow
+	if err := s.db.Raw(query, params).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func NewShadowPredictionRepository(db *gorm.DB) ShadowPredictionRepository {
+	return &shadowPredictionRepository{db: db}
+}
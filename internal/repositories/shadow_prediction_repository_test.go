@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestShadowQueriesApplyTheFilter(t *testing.T) {
+	db, recorder := dryRunDB(t)
+	repo := NewShadowPredictionRepository(db)
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := ShadowFilter{LocationID: 4, ModelVersion: "2026.11.0-rc1", From: from, To: from.AddDate(0, 3, 0)}
+
+	common := []string{
+		"p.deleted_at IS NULL",
+		"s.created_at >= '2026-01-01 00:00:00' AND s.created_at < '2026-04-01 00:00:00'",
+		"(4 = 0 OR p.location_id = 4)",
+		"('2026.11.0-rc1' = '' OR s.model_version = '2026.11.0-rc1')",
+	}
+
+	queries := []struct {
+		name string
+		run  func() error
+		want []string
+	}{
+		{"summary", func() error { _, err := repo.GetShadowSummary(filter); return err }, []string{"COUNT(s.error) AS failed"}},
+		{"against primary", func() error { _, err := repo.GetShadowConfusion(filter, ShadowAgainstPrimary); return err }, []string{
+			"s.primary_nutritional_status AS reference", "s.primary_source = 'ml'", "s.error IS NULL",
+		}},
+		{"against WHO", func() error { _, err := repo.GetShadowConfusion(filter, ShadowAgainstWHO); return err }, []string{
+			"s.who_nutritional_status AS reference", "s.who_nutritional_status IS NOT NULL",
+		}},
+		{"disagreements", func() error { _, err := repo.GetShadowDisagreements(filter, 100); return err }, []string{
+			"s.nutritional_status <> s.who_nutritional_status", "LIMIT 100",
+		}},
+	}
+
+	for _, q := range queries {
+		t.Run(q.name, func(t *testing.T) {
+			if err := q.run(); !errors.Is(err, gorm.ErrDryRunModeUnsupported) {
+				t.Fatalf("query: %v", err)
+			}
+			sql := recorder.last(t)
+			for _, want := range append(common, q.want...) {
+				if !strings.Contains(sql, want) {
+					t.Errorf("query does not contain %q:\n%s", want, sql)
+				}
+			}
+		})
+	}
+
+	if _, err := repo.GetShadowConfusion(filter, "toddler"); err == nil || errors.Is(err, gorm.ErrDryRunModeUnsupported) {
+		t.Errorf("unknown reference: %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"errors"
 	"grovia/internal/models"
+	"grovia/pkg"
 	"strings"
 
 	"gorm.io/gorm"
@@ -10,10 +11,10 @@ import (
 
 type ToddlerRepository interface {
 	CreateToddler(toddler *models.Toddler) (*models.Toddler, error)
-	GetAllToddler(locationID, limit, offset int, name string) ([]models.Toddler, int, error)
-	GetToddlerByID(id, locationID int) (*models.Toddler, error)
-	UpdateToddlerByID(id, locationID int, toddler *models.Toddler) (*models.Toddler, error)
-	DeleteToddlerByID(id, locationID, userID int) error
+	GetAllToddler(scope pkg.AccessScope, limit, offset int, name string) ([]models.Toddler, int, error)
+	GetToddlerByID(id int, scope pkg.AccessScope) (*models.Toddler, error)
+	UpdateToddlerByID(id int, scope pkg.AccessScope, toddler *models.Toddler) (*models.Toddler, error)
+	DeleteToddlerByID(id int, scope pkg.AccessScope, userID int) error
 	FindToddlerByName(parentID int, name string) (bool, *models.Toddler, error)
 	GetAllToddlerAllLocation(name string, limit, offset int) ([]models.Toddler, int, error)
 }
@@ -67,12 +68,12 @@ func (t *toddlerRepository) FindToddlerByName(parentID int, name string) (bool,
 	normalizedName := strings.ToLower(strings.ReplaceAll(name, " ", ""))
 
 	var toddler models.Toddler
-	err := t.db.Where("parent_id = ? AND REPLACE(LOWER(name), ' ', '') = ?", parentID, normalizedName).
+	err := t.db.Where("parent_id = ? AND REPLACE(LOWER(name), ' ', '') = ? AND deleted_at IS NULL", parentID, normalizedName).
 		First(&toddler).Error
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return false, nil, err
+			return false, nil, nil
 		}
 		return false, nil, err
 	}
@@ -89,31 +90,19 @@ func (t *toddlerRepository) CreateToddler(toddler *models.Toddler) (*models.Todd
 }
 
 // DeleteToddlerByID implements ToddlerRepository.
-func (t *toddlerRepository) DeleteToddlerByID(id int, locationID, userID int) error {
-	db := t.db.Model(&models.Toddler{})
-
-	if locationID == 1 {
-		res := db.Where("id = ?", id).Updates(map[string]any{
-			"deleted_by_id": userID,
-			"deleted_at":    gorm.Expr("NOW()"),
-		})
-		if res.Error != nil {
-			return res.Error
-		}
-		if res.RowsAffected == 0 {
-			return gorm.ErrRecordNotFound
-		}
-	} else {
-		res := db.Where("id = ? AND location_id = ?", id, locationID).Updates(map[string]any{
+func (t *toddlerRepository) DeleteToddlerByID(id int, scope pkg.AccessScope, userID int) error {
+	res := t.db.Model(&models.Toddler{}).
+		Scopes(ScopeLocation(scope), ScopeOwnToddlers(scope)).
+		Where("id = ?", id).
+		Updates(map[string]any{
 			"deleted_by_id": userID,
 			"deleted_at":    gorm.Expr("NOW()"),
 		})
-		if res.Error != nil {
-			return res.Error
-		}
-		if res.RowsAffected == 0 {
-			return gorm.ErrRecordNotFound
-		}
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
 	}
 
 	if err := t.db.Model(&models.Predict{}).
@@ -129,10 +118,10 @@ func (t *toddlerRepository) DeleteToddlerByID(id int, locationID, userID int) er
 }
 
 // GetAllToddler implements ToddlerRepository.
-func (t *toddlerRepository) GetAllToddler(locationID, limit, offset int, name string) ([]models.Toddler, int, error) {
+func (t *toddlerRepository) GetAllToddler(scope pkg.AccessScope, limit, offset int, name string) ([]models.Toddler, int, error) {
 	var toddlers []models.Toddler
 	var total int64
-	db := t.db.Model(&toddlers).Where("location_id = ? AND deleted_at IS NULL", locationID)
+	db := t.db.Model(&toddlers).Scopes(ScopeLocation(scope), ScopeOwnToddlers(scope)).Where("deleted_at IS NULL")
 
 	if strings.TrimSpace(name) != "" {
 		normalizedName := strings.ToLower(strings.ReplaceAll(name, " ", ""))
@@ -151,16 +140,10 @@ func (t *toddlerRepository) GetAllToddler(locationID, limit, offset int, name st
 }
 
 // GetToddlerByID implements ToddlerRepository.
-func (t *toddlerRepository) GetToddlerByID(id int, locationID int) (*models.Toddler, error) {
+func (t *toddlerRepository) GetToddlerByID(id int, scope pkg.AccessScope) (*models.Toddler, error) {
 	var toddler models.Toddler
 
-	db := t.db.Where("id = ? AND deleted_at IS NULL", id)
-
-	if locationID != 1 {
-		db = db.Where("location_id = ?", locationID)
-	}
-
-	if err := db.First(&toddler).Error; err != nil {
+	if err := t.db.Scopes(ScopeLocation(scope), ScopeOwnToddlers(scope)).Where("id = ? AND deleted_at IS NULL", id).First(&toddler).Error; err != nil {
 		return nil, err
 	}
 
@@ -168,29 +151,22 @@ func (t *toddlerRepository) GetToddlerByID(id int, locationID int) (*models.Todd
 }
 
 // UpdateToddlerByID implements ToddlerRepository.
-func (t *toddlerRepository) UpdateToddlerByID(id int, locationID int, toddler *models.Toddler) (*models.Toddler, error) {
-	db := t.db.Model(toddler).Where("id = ? AND deleted_at IS NULL", id)
-
-	if locationID != 1 {
-		db = db.Where("location_id = ?", locationID)
-	}
-
-	if err := db.Updates(toddler).Error; err != nil {
-		return nil, err
-	}
-
-	if db.RowsAffected == 0 {
+func (t *toddlerRepository) UpdateToddlerByID(id int, scope pkg.AccessScope, toddler *models.Toddler) (*models.Toddler, error) {
+	res := t.db.Model(&models.Toddler{}).
+		Scopes(ScopeLocation(scope), ScopeOwnToddlers(scope)).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(toddler)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
 		return nil, gorm.ErrRecordNotFound
 	}
 
+	// The update may have moved the toddler to another location, so it is
+	// read back by ID only.
 	var toddlerResponse models.Toddler
-	dbFetch := t.db.Where("id = ?", id)
-
-	if locationID != 1 {
-		dbFetch = dbFetch.Where("location_id = ?", locationID)
-	}
-
-	if err := dbFetch.First(&toddlerResponse).Error; err != nil {
+	if err := t.db.Where("id = ?", id).First(&toddlerResponse).Error; err != nil {
 		return nil, err
 	}
 
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"grovia/internal/models"
+	"grovia/pkg"
 	"strings"
 
 	"gorm.io/gorm"
@@ -14,7 +15,7 @@ type UserRepository interface {
 	UpdateUser(id int, user *models.User) (*models.User, error)
 	DeleteUser(id int) error
 	FindRoleById(id int) (string, error)
-	FindUsersByRole(roles []string, name string, locationID, limit, offset int) ([]models.User, int, error)
+	FindUsersByRole(roles []string, name string, scope pkg.AccessScope, limit, offset int) ([]models.User, int, error)
 }
 
 type userRepository struct {
@@ -22,16 +23,14 @@ type userRepository struct {
 }
 
 // FindUsersByRole implements UserRepository.
-func (u *userRepository) FindUsersByRole(roles []string, name string, locationID, limit, offset int) ([]models.User, int, error) {
+func (u *userRepository) FindUsersByRole(roles []string, name string, scope pkg.AccessScope, limit, offset int) ([]models.User, int, error) {
 	var users []models.User
 	var total int64
 	db := u.db.Model(&models.User{})
 
 	db = db.Where("is_active = true AND role IN ?", roles)
 
-	if locationID != 1 {
-		db = db.Where("location_id = ?", locationID)
-	}
+	db = db.Scopes(ScopeLocation(scope))
 
 	if strings.TrimSpace(name) != "" {
 		normalizedName := strings.ToLower(strings.ReplaceAll(name, " ", ""))
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"grovia/internal/handlers"
+	"grovia/internal/middlewares"
+	"grovia/internal/services"
+	"grovia/pkg"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func AuditRouter(app *fiber.App, auditService services.AuditService) {
+	auditHandler := handlers.NewAuditHandler(auditService)
+
+	r := app.Group("/api/audit")
+
+	r.Use(middlewares.JWTAuth())
+
+	r.Get("/", middlewares.RequirePermission(pkg.PermissionAuditView), auditHandler.GetAllAuditLog)
+}
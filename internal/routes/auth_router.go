@@ -2,6 +2,7 @@ package routes
 
 import (
 	"grovia/internal/handlers"
+	"grovia/internal/middlewares"
 	"grovia/internal/repositories"
 	"grovia/internal/services"
 
@@ -9,16 +10,24 @@ import (
 	"gorm.io/gorm"
 )
 
-func AuthRouter(db *gorm.DB, app *fiber.App) {
+func AuthRouter(db *gorm.DB, app *fiber.App, verifier services.PhoneVerifier, audit services.AuditService) {
 	var (
 		authRepo    = repositories.NewAuthRepository(db)
-		authService = services.NewAuthService(authRepo)
+		refreshRepo = repositories.NewRefreshTokenRepository(db)
+		parentRepo  = repositories.NewParentRepository(db)
+		authService = services.NewAuthService(authRepo, refreshRepo, parentRepo, verifier, audit)
 		authHandler = handlers.NewAuthHandler(authService)
 	)
 
 	public := app.Group("/api/auth")
 
 	public.Post("/login", authHandler.Login)
+	public.Post("/reset-password/send-code", authHandler.SendResetCode)
 	public.Post("/reset-password", authHandler.ResetPassword)
 	public.Post("/refresh-token", authHandler.RefreshToken)
+	public.Post("/logout", authHandler.Logout)
+	public.Post("/logout-all", middlewares.JWTAuth(), authHandler.LogoutEverywhere)
+
+	public.Post("/parent/send-code", authHandler.SendParentLoginCode)
+	public.Post("/parent/login", authHandler.ParentLogin)
 }
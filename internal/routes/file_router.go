@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"grovia/internal/handlers"
+	"grovia/internal/storage"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FileRouter serves the signed URLs of the local and memory storage. S3
+// URLs point at S3 itself, so nothing is registered for it.
+func FileRouter(app *fiber.App, store storage.ObjectStorage) {
+	verifier, ok := store.(storage.URLVerifier)
+	if !ok {
+		return
+	}
+
+	fileHandler := handlers.NewFileHandler(store, verifier)
+
+	app.Get(storage.FilesPath+"*", fileHandler.GetFile)
+}
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"grovia/internal/handlers"
+	"grovia/internal/middlewares"
+	"grovia/internal/repositories"
+	"grovia/internal/services"
+	"grovia/pkg"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func ImmunizationRouter(app *fiber.App, db *gorm.DB, audit services.AuditService) {
+	repo := repositories.NewImmunizationRepository(db)
+	toddlerRepo := repositories.NewToddlerRepository(db)
+	sessionRepo := repositories.NewSessionRepository(db)
+	service := services.NewImmunizationService(repo, toddlerRepo, sessionRepo, audit)
+	handler := handlers.NewImmunizationHandler(service)
+
+	r := app.Group("/api/immunizations")
+
+	r.Use(middlewares.JWTAuth())
+
+	r.Get("/vaccines", middlewares.RequirePermission(pkg.PermissionImmunizationView), handler.GetVaccines)
+	r.Get("/due", middlewares.RequirePermission(pkg.PermissionImmunizationView), handler.GetDueImmunizations)
+	r.Get("/toddlers/:id", middlewares.RequirePermission(pkg.PermissionImmunizationView), handler.GetToddlerImmunizations)
+	r.Post("/", middlewares.RequirePermission(pkg.PermissionImmunizationManage), handler.CreateImmunization)
+	r.Delete("/:id", middlewares.RequirePermission(pkg.PermissionImmunizationManage), handler.DeleteImmunizationByID)
+}
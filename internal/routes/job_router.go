@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"grovia/internal/handlers"
+	"grovia/internal/middlewares"
+	"grovia/internal/services"
+	"grovia/pkg"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func JobRouter(app *fiber.App, jobService services.JobService) {
+	jobHandler := handlers.NewJobHandler(jobService)
+
+	r := app.Group("/api/jobs")
+
+	r.Use(middlewares.JWTAuth())
+
+	r.Get("/", middlewares.RequirePermission(pkg.PermissionJobView), jobHandler.GetAllJob)
+
+	r.Get("/:id/result", middlewares.RequirePermission(pkg.PermissionJobView), jobHandler.GetJobResultFile)
+
+	r.Get("/:id", middlewares.RequirePermission(pkg.PermissionJobView), jobHandler.GetJobByID)
+}
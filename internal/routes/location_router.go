@@ -5,23 +5,25 @@ import (
 	"grovia/internal/middlewares"
 	"grovia/internal/repositories"
 	"grovia/internal/services"
+	"grovia/internal/storage"
+	"grovia/pkg"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
-func LocationRouter(app *fiber.App, db *gorm.DB, s3 *services.S3Service) {
+func LocationRouter(app *fiber.App, db *gorm.DB, store storage.ObjectStorage, audit services.AuditService) {
 	repo := repositories.NewLocationRepository(db)
-	service := services.NewLocationService(repo, s3)
+	service := services.NewLocationService(repo, store, audit)
 	handler := handlers.NewLocationHandler(service)
 
 	r := app.Group("/api/locations")
 
 	r.Use(middlewares.JWTAuth())
 
-	r.Post("/", middlewares.RoleMiddleware("admin"), handler.CreateLocation)
-	r.Get("/", handler.GetAllLocation)
-	r.Get("/:id", handler.GetLocationByID)
-	r.Patch("/:id", middlewares.RoleMiddleware("admin"), handler.UpdateLocationByID)
-	r.Delete("/:id", middlewares.RoleMiddleware("admin"), handler.DeleteLocationByID)
+	r.Post("/", middlewares.RequirePermission(pkg.PermissionLocationCreate), handler.CreateLocation)
+	r.Get("/", middlewares.RequirePermission(pkg.PermissionLocationViewAll), handler.GetAllLocation)
+	r.Get("/:id", middlewares.RequirePermission(pkg.PermissionLocationView), handler.GetLocationByID)
+	r.Patch("/:id", middlewares.RequirePermission(pkg.PermissionLocationUpdate), handler.UpdateLocationByID)
+	r.Delete("/:id", middlewares.RequirePermission(pkg.PermissionLocationDelete), handler.DeleteLocationByID)
 }
@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"grovia/internal/handlers"
+	"grovia/internal/middlewares"
+	"grovia/internal/repositories"
+	"grovia/internal/services"
+	"grovia/internal/storage"
+	"grovia/pkg"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func MeRouter(db *gorm.DB, app *fiber.App, store storage.ObjectStorage, predict services.PredictService, audit services.AuditService) {
+	var (
+		toddlerRepo     = repositories.NewToddlerRepository(db)
+		parentRepo      = repositories.NewParentRepository(db)
+		predictRepo     = repositories.NewPredictRepository(db)
+		locationRepo    = repositories.NewLocationRepository(db)
+		growthService   = services.NewGrowthService(predictRepo)
+		toddlerService  = services.NewToddlerService(toddlerRepo, parentRepo, store, predict, growthService, audit)
+		locationService = services.NewLocationService(locationRepo, store, audit)
+		meHandler       = handlers.NewMeHandler(toddlerService, predict, locationService)
+	)
+
+	r := app.Group("/api/me")
+
+	r.Use(middlewares.JWTAuth())
+
+	r.Get("/children", middlewares.RequirePermission(pkg.PermissionChildView), meHandler.GetChildren)
+
+	r.Get("/children/:id", middlewares.RequirePermission(pkg.PermissionChildView), meHandler.GetChildByID)
+
+	r.Get("/children/:id/predicts", middlewares.RequirePermission(pkg.PermissionChildView), meHandler.GetChildPredicts)
+
+	r.Get("/children/:id/growth-chart", middlewares.RequirePermission(pkg.PermissionChildView), meHandler.GetChildGrowthChart)
+
+	r.Get("/schedule", middlewares.RequirePermission(pkg.PermissionChildView), meHandler.GetSchedule)
+}
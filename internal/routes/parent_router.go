@@ -5,15 +5,17 @@ import (
 	"grovia/internal/middlewares"
 	"grovia/internal/repositories"
 	"grovia/internal/services"
+	"grovia/internal/storage"
+	"grovia/pkg"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
-func ParentRouter(db *gorm.DB, app *fiber.App) {
+func ParentRouter(db *gorm.DB, app *fiber.App, store storage.ObjectStorage, audit services.AuditService) {
 	var (
 		parentRepo    = repositories.NewParentRepository(db)
-		parentService = services.NewParentService(parentRepo)
+		parentService = services.NewParentService(parentRepo, store, audit)
 		parentHandler = handlers.NewParentHandler(parentService)
 	)
 
@@ -21,17 +23,17 @@ func ParentRouter(db *gorm.DB, app *fiber.App) {
 
 	r.Use(middlewares.JWTAuth())
 
-	r.Get("/check-phone", parentHandler.CheckPhoneExists)
+	r.Get("/check-phone", middlewares.RequirePermission(pkg.PermissionParentView), parentHandler.CheckPhoneExists)
 
-	r.Post("/", parentHandler.CreateParent)
+	r.Post("/", middlewares.RequirePermission(pkg.PermissionParentCreate), parentHandler.CreateParent)
 
-	r.Get("/", parentHandler.GetAllParent)
+	r.Get("/", middlewares.RequirePermission(pkg.PermissionParentView), parentHandler.GetAllParent)
 
-	r.Get("/all", parentHandler.GetAllPredictAllLocation)
+	r.Get("/all", middlewares.RequirePermission(pkg.PermissionParentViewAll), parentHandler.GetAllPredictAllLocation)
 
-	r.Get("/:id", parentHandler.GetParentByID)
+	r.Get("/:id", middlewares.RequirePermission(pkg.PermissionParentView), parentHandler.GetParentByID)
 
-	r.Patch("/:id", parentHandler.UpdateParentByID)
+	r.Patch("/:id", middlewares.RequirePermission(pkg.PermissionParentUpdate), parentHandler.UpdateParentByID)
 
-	r.Delete("/:id", parentHandler.DeleteParentByID)
+	r.Delete("/:id", middlewares.RequirePermission(pkg.PermissionParentDelete), parentHandler.DeleteParentByID)
 }
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"grovia/internal/handlers"
+	"grovia/internal/middlewares"
+	"grovia/internal/services"
+	"grovia/pkg"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func PermissionRouter(app *fiber.App) {
+	var (
+		permissionService = services.NewPermissionService()
+		permissionHandler = handlers.NewPermissionHandler(permissionService)
+	)
+
+	r := app.Group("/api/permissions")
+
+	r.Use(middlewares.JWTAuth())
+
+	r.Get("/", middlewares.RequirePermission(pkg.PermissionPermissionView), permissionHandler.GetPermissionMatrix)
+
+	// Deliberately open to every logged-in role, parents included: it only
+	// lists the caller's own permissions, which clients need to build their
+	// menus before they know what they may access.
+	r.Get("/current", permissionHandler.GetCurrentPermissions)
+}
@@ -3,35 +3,34 @@ package routes
 import (
 	"grovia/internal/handlers"
 	"grovia/internal/middlewares"
-	"grovia/internal/repositories"
 	"grovia/internal/services"
+	"grovia/pkg"
 
 	"github.com/gofiber/fiber/v2"
-	"gorm.io/gorm"
 )
 
-func PredictRouter(db *gorm.DB, app *fiber.App, mlAPIURL string) {
-	var (
-		predictRepo    = repositories.NewPredictRepository(db)
-		predictService = services.NewPredictService(predictRepo, mlAPIURL)
-		predictHandler = handlers.NewPredictHandler(predictService)
-	)
+func PredictRouter(app *fiber.App, predictService services.PredictService) {
+	predictHandler := handlers.NewPredictHandler(predictService)
 
 	r := app.Group("/api/predicts")
 
 	r.Use(middlewares.JWTAuth())
 
-	r.Post("/group", predictHandler.CreateGroupPredict)
+	r.Post("/group/preview", middlewares.RequirePermission(pkg.PermissionPredictCreate), predictHandler.PreviewGroupPredict)
 
-	r.Get("/", predictHandler.GetAllPredict)
+	r.Post("/group/confirm", middlewares.RequirePermission(pkg.PermissionPredictCreate), predictHandler.ConfirmGroupPredict)
 
-	r.Get("/all", predictHandler.GetAllPredictAllLocation)
+	r.Get("/", middlewares.RequirePermission(pkg.PermissionPredictView), predictHandler.GetAllPredict)
 
-	r.Get("/toddler/:id", predictHandler.GetAllPredictByToddlerID)
+	r.Get("/all", middlewares.RequirePermission(pkg.PermissionPredictViewAll), predictHandler.GetAllPredictAllLocation)
 
-	r.Get("/:id", predictHandler.GetPredictByID)
+	r.Get("/toddler/:id", middlewares.RequirePermission(pkg.PermissionPredictView), predictHandler.GetAllPredictByToddlerID)
 
-	r.Patch("/:id", predictHandler.UpdatePredictByID)
+	r.Get("/:id", middlewares.RequirePermission(pkg.PermissionPredictView), predictHandler.GetPredictByID)
 
-	r.Delete("/:id", predictHandler.DeletePredictByID)
+	r.Get("/:id/revisions", middlewares.RequirePermission(pkg.PermissionPredictView), predictHandler.GetPredictRevisions)
+
+	r.Patch("/:id", middlewares.RequirePermission(pkg.PermissionPredictUpdate), predictHandler.UpdatePredictByID)
+
+	r.Delete("/:id", middlewares.RequirePermission(pkg.PermissionPredictDelete), predictHandler.DeletePredictByID)
 }
@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"grovia/internal/handlers"
+	"grovia/internal/middlewares"
+	"grovia/internal/repositories"
+	"grovia/internal/services"
+	"grovia/pkg"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func ReportRouter(db *gorm.DB, app *fiber.App) {
+	var (
+		reportRepo       = repositories.NewReportRepository(db)
+		shadowRepo       = repositories.NewShadowPredictionRepository(db)
+		immunizationRepo = repositories.NewImmunizationRepository(db)
+		reportService    = services.NewReportService(reportRepo, shadowRepo, immunizationRepo)
+		reportHandler    = handlers.NewReportHandler(reportService)
+	)
+
+	r := app.Group("/api/reports")
+
+	r.Use(middlewares.JWTAuth())
+
+	r.Get("/skdn", middlewares.RequirePermission(pkg.PermissionReportView), reportHandler.GetSKDN)
+
+	r.Get("/prevalence", middlewares.RequirePermission(pkg.PermissionReportView), reportHandler.GetPrevalence)
+
+	r.Get("/immunization", middlewares.RequirePermission(pkg.PermissionReportView), reportHandler.GetImmunizationReport)
+
+	// Evaluating a candidate model is an admin task across locations.
+	r.Get("/shadow", middlewares.RequirePermission(pkg.PermissionPredictViewAll), reportHandler.GetShadowReport)
+}
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"grovia/internal/handlers"
+	"grovia/internal/middlewares"
+	"grovia/internal/repositories"
+	"grovia/internal/services"
+	"grovia/pkg"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func SessionRouter(app *fiber.App, db *gorm.DB, audit services.AuditService) {
+	repo := repositories.NewSessionRepository(db)
+	toddlerRepo := repositories.NewToddlerRepository(db)
+	service := services.NewSessionService(repo, toddlerRepo, audit)
+	handler := handlers.NewSessionHandler(service)
+
+	r := app.Group("/api/sessions")
+
+	r.Use(middlewares.JWTAuth())
+
+	r.Post("/", middlewares.RequirePermission(pkg.PermissionSessionManage), handler.OpenSession)
+	r.Get("/", middlewares.RequirePermission(pkg.PermissionSessionView), handler.GetAllSession)
+	r.Get("/:id", middlewares.RequirePermission(pkg.PermissionSessionView), handler.GetSessionByID)
+	r.Post("/:id/close", middlewares.RequirePermission(pkg.PermissionSessionManage), handler.CloseSession)
+	r.Get("/:id/measured", middlewares.RequirePermission(pkg.PermissionSessionView), handler.GetMeasured)
+	r.Get("/:id/absent", middlewares.RequirePermission(pkg.PermissionSessionView), handler.GetAbsent)
+	r.Post("/:id/attendance", middlewares.RequirePermission(pkg.PermissionSessionManage), handler.RecordAttendance)
+}
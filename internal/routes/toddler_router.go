@@ -5,16 +5,20 @@ import (
 	"grovia/internal/middlewares"
 	"grovia/internal/repositories"
 	"grovia/internal/services"
+	"grovia/internal/storage"
+	"grovia/pkg"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
-func ToddlerRouter(db *gorm.DB, app *fiber.App, s3 *services.S3Service, predict services.PredictService) {
+func ToddlerRouter(db *gorm.DB, app *fiber.App, store storage.ObjectStorage, predict services.PredictService, audit services.AuditService) {
 	var (
 		toddlerRepo    = repositories.NewToddlerRepository(db)
 		parentRepo     = repositories.NewParentRepository(db)
-		toddlerService = services.NewToddlerService(toddlerRepo, parentRepo, s3, predict)
+		predictRepo    = repositories.NewPredictRepository(db)
+		growthService  = services.NewGrowthService(predictRepo)
+		toddlerService = services.NewToddlerService(toddlerRepo, parentRepo, store, predict, growthService, audit)
 		toddlerHandler = handlers.NewToddlerHandler(toddlerService)
 	)
 
@@ -22,22 +26,26 @@ func ToddlerRouter(db *gorm.DB, app *fiber.App, s3 *services.S3Service, predict
 
 	r.Use(middlewares.JWTAuth())
 
-	r.Post("/", toddlerHandler.CreateToddler)
+	r.Post("/", middlewares.RequirePermission(pkg.PermissionToddlerCreate), toddlerHandler.CreateToddler)
 
-	r.Post("/with-parent", toddlerHandler.CreateToddlerWithParent)
+	r.Post("/with-parent", middlewares.RequirePermission(pkg.PermissionToddlerCreate), toddlerHandler.CreateToddlerWithParent)
 
-	r.Get("/check-toddler", toddlerHandler.CheckToddlerExists)
+	r.Get("/check-toddler", middlewares.RequirePermission(pkg.PermissionToddlerView), toddlerHandler.CheckToddlerExists)
 
-	r.Get("/", toddlerHandler.GetAllToddler)
+	r.Get("/", middlewares.RequirePermission(pkg.PermissionToddlerView), toddlerHandler.GetAllToddler)
 
-	r.Get("/all", toddlerHandler.GetAllToddlerAllLocation)
+	r.Get("/all", middlewares.RequirePermission(pkg.PermissionToddlerViewAll), toddlerHandler.GetAllToddlerAllLocation)
 
-	r.Patch("/without-predict/:id", toddlerHandler.UpdateToddlerByIDWithoutPredict)
+	r.Get("/faltering", middlewares.RequirePermission(pkg.PermissionToddlerView), toddlerHandler.GetFalteringToddlers)
 
-	r.Get("/:id", toddlerHandler.GetToddlerByID)
+	r.Patch("/without-predict/:id", middlewares.RequirePermission(pkg.PermissionToddlerUpdate), toddlerHandler.UpdateToddlerByIDWithoutPredict)
 
-	r.Patch("/:id", toddlerHandler.UpdateToddlerByID)
+	r.Get("/:id/growth-chart", middlewares.RequirePermission(pkg.PermissionToddlerView), toddlerHandler.GetGrowthChart)
 
-	r.Delete("/:id", toddlerHandler.DeleteToddlerByID)
+	r.Get("/:id", middlewares.RequirePermission(pkg.PermissionToddlerView), toddlerHandler.GetToddlerByID)
+
+	r.Patch("/:id", middlewares.RequirePermission(pkg.PermissionToddlerUpdate), toddlerHandler.UpdateToddlerByID)
+
+	r.Delete("/:id", middlewares.RequirePermission(pkg.PermissionToddlerDelete), toddlerHandler.DeleteToddlerByID)
 
 }
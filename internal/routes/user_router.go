@@ -5,28 +5,30 @@ import (
 	"grovia/internal/middlewares"
 	"grovia/internal/repositories"
 	"grovia/internal/services"
+	"grovia/internal/storage"
+	"grovia/pkg"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
-func UserRouter(app *fiber.App, db *gorm.DB, s3 *services.S3Service) {
+func UserRouter(app *fiber.App, db *gorm.DB, store storage.ObjectStorage, audit services.AuditService) {
 	repo := repositories.NewUserRepository(db)
-	service := services.NewUserService(repo, s3)
+	service := services.NewUserService(repo, store, audit)
 	handler := handlers.NewUserHandler(service)
 
 	r := app.Group("/api/users")
 
 	r.Use(middlewares.JWTAuth())
 
-	r.Post("/", middlewares.RoleMiddleware("admin", "kepala_posyandu"), handler.CreateUser)
+	r.Post("/", middlewares.RequirePermission(pkg.PermissionUserCreate), handler.CreateUser)
 
 	r.Get("/current", handler.GetCurrentUser)
 	r.Patch("/current", handler.UpdateCurrentUser)
 	r.Delete("/current", handler.DeleteCurrentUser)
 
-	r.Get("/", middlewares.RoleMiddleware("admin", "kepala_posyandu"), handler.GetUsersByRole)
-	r.Get("/:id", middlewares.RoleMiddleware("admin", "kepala_posyandu"), handler.GetUserByID)
-	r.Patch("/:id", middlewares.RoleMiddleware("admin", "kepala_posyandu"), handler.UpdateUserByID)
-	r.Delete("/:id", middlewares.RoleMiddleware("admin", "kepala_posyandu"), handler.DeleteUserByID)
+	r.Get("/", middlewares.RequirePermission(pkg.PermissionUserView), handler.GetUsersByRole)
+	r.Get("/:id", middlewares.RequirePermission(pkg.PermissionUserView), handler.GetUserByID)
+	r.Patch("/:id", middlewares.RequirePermission(pkg.PermissionUserUpdate), handler.UpdateUserByID)
+	r.Delete("/:id", middlewares.RequirePermission(pkg.PermissionUserDelete), handler.DeleteUserByID)
 }
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"grovia/internal/dto/requests"
+	"grovia/internal/dto/responses"
+	"grovia/internal/models"
+	"grovia/internal/repositories"
+	"grovia/pkg"
+	"log"
+	"math"
+	"reflect"
+	"time"
+)
+
+// auditIgnoredFields change on every write and would only add noise.
+var auditIgnoredFields = map[string]bool{
+	"createdAt":   true,
+	"updatedAt":   true,
+	"updatedByID": true,
+	"deletedByID": true,
+	"deletedAt":   true,
+}
+
+type AuditService interface {
+	Record(ctx context.Context, action, entity string, entityID int, before, after any)
+	GetAllAuditLog(query requests.AuditLogQuery) ([]responses.AuditLogResponse, *responses.PaginationMeta, error)
+}
+
+type auditService struct {
+	repo repositories.AuditRepository
+}
+
+type auditChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// Record stores who changed which fields of an entity. Before is nil for a
+// create and after is nil for a delete. The actor and client come from
+// the access scope and request meta in ctx. A failure is logged rather
+// than returned, because the change itself has already been saved.
+func (a *auditService) Record(ctx context.Context, action, entity string, entityID int, before, after any) {
+	changes, err := auditChanges(before, after)
+	if err != nil {
+		log.Printf("audit: failed to diff %s %d: %v", entity, entityID, err)
+		return
+	}
+
+	auditLog := &models.AuditLog{
+		Action:   action,
+		Entity:   entity,
+		EntityID: entityID,
+		Changes:  changes,
+	}
+
+	if scope, ok := pkg.AccessScopeFromContext(ctx); ok {
+		if scope.UserID != 0 {
+			auditLog.ActorID = &scope.UserID
+		}
+		if scope.LocationID != 0 {
+			auditLog.ActorLocationID = &scope.LocationID
+		}
+		auditLog.ActorRole = scope.Role
+	}
+
+	if meta, ok := pkg.RequestMetaFromContext(ctx); ok {
+		auditLog.IPAddress = meta.IPAddress
+		auditLog.UserAgent = meta.UserAgent
+		if len(auditLog.UserAgent) > 255 {
+			auditLog.UserAgent = auditLog.UserAgent[:255]
+		}
+	}
+
+	if err := a.repo.CreateAuditLog(auditLog); err != nil {
+		log.Printf("audit: failed to record %s %s %d: %v", action, entity, entityID, err)
+	}
+}
+
+func (a *auditService) GetAllAuditLog(query requests.AuditLogQuery) ([]responses.AuditLogResponse, *responses.PaginationMeta, error) {
+	if err := pkg.ValidateStruct(query); err != nil {
+		return nil, nil, pkg.NewBadRequestError(err.Error())
+	}
+
+	filter := repositories.AuditFilter{
+		Entity:   query.Entity,
+		EntityID: query.EntityID,
+		ActorID:  query.ActorID,
+		Action:   query.Action,
+	}
+
+	if query.From != "" {
+		from, err := time.Parse(time.DateOnly, query.From)
+		if err != nil {
+			return nil, nil, pkg.NewBadRequestError("Format from harus YYYY-MM-DD")
+		}
+		filter.From = &from
+	}
+	if query.To != "" {
+		to, err := time.Parse(time.DateOnly, query.To)
+		if err != nil {
+			return nil, nil, pkg.NewBadRequestError("Format to harus YYYY-MM-DD")
+		}
+		// The to date is inclusive.
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	page, limit := query.Page, query.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	offset := (page - 1) * limit
+
+	logs, total, err := a.repo.GetAllAuditLog(filter, limit, offset)
+	if err != nil {
+		return nil, nil, pkg.NewInternalServerError("Gagal mengambil audit log")
+	}
+
+	auditResponses := []responses.AuditLogResponse{}
+	for _, l := range logs {
+		response := responses.AuditLogResponse{
+			ID:              l.ID,
+			ActorID:         l.ActorID,
+			ActorRole:       l.ActorRole,
+			ActorLocationID: l.ActorLocationID,
+			Action:          l.Action,
+			Entity:          l.Entity,
+			EntityID:        l.EntityID,
+			IPAddress:       l.IPAddress,
+			UserAgent:       l.UserAgent,
+			CreatedAt:       l.CreatedAt,
+		}
+		if l.Changes != nil {
+			response.Changes = json.RawMessage(*l.Changes)
+		}
+		auditResponses = append(auditResponses, response)
+	}
+
+	meta := responses.PaginationMeta{
+		Page:      page,
+		Limit:     limit,
+		TotalData: total,
+		TotalPage: int(math.Ceil(float64(total) / float64(limit))),
+	}
+
+	return auditResponses, &meta, nil
+}
+
+// auditChanges compares the JSON form of two records field by field.
+// Nested objects and lists, i.e. preloaded relations, are skipped; they
+// are audited as entities of their own.
+func auditChanges(before, after any) (*string, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]auditChange{}
+	for _, fields := range []map[string]any{beforeFields, afterFields} {
+		for key := range fields {
+			if _, done := changes[key]; done || auditIgnoredFields[key] {
+				continue
+			}
+
+			from, to := beforeFields[key], afterFields[key]
+			if isNested(from) || isNested(to) || reflect.DeepEqual(from, to) {
+				continue
+			}
+			changes[key] = auditChange{From: from, To: to}
+		}
+	}
+
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	result := string(encoded)
+	return &result, nil
+}
+
+func auditFields(record any) (map[string]any, error) {
+	if record == nil {
+		return nil, nil
+	}
+
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func isNested(value any) bool {
+	switch value.(type) {
+	case map[string]any, []any:
+		return true
+	}
+	return false
+}
+
+func NewAuditService(repo repositories.AuditRepository) AuditService {
+	return &auditService{repo: repo}
+}
@@ -1,45 +1,128 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"grovia/internal/dto/requests"
 	"grovia/internal/dto/responses"
+	"grovia/internal/models"
 	"grovia/internal/repositories"
 	"grovia/pkg"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type AuthService interface {
-	Login(req requests.LoginRequest) (*responses.TokenResponse, error)
-	ResetPassword(req requests.ResetPasswordRequest) error
-	RefreshToken(refreshToken string) (*responses.TokenResponse, error)
+	Login(req requests.LoginRequest, device string) (*responses.TokenResponse, error)
+	SendResetCode(req requests.SendResetCodeRequest) error
+	ResetPassword(ctx context.Context, req requests.ResetPasswordRequest) error
+	RefreshToken(refreshToken, device string) (*responses.TokenResponse, error)
+	Logout(refreshToken string) error
+	LogoutEverywhere(userID int) error
+	SendParentLoginCode(req requests.ParentSendCodeRequest) error
+	ParentLogin(req requests.ParentLoginRequest) (*responses.TokenResponse, error)
 }
 
 type authService struct {
-	repo repositories.AuthRepository
+	repo        repositories.AuthRepository
+	refreshRepo repositories.RefreshTokenRepository
+	parentRepo  repositories.ParentRepository
+	verifier    PhoneVerifier
+	audit       AuditService
 }
 
-func (a *authService) RefreshToken(refreshToken string) (*responses.TokenResponse, error) {
-	claims, err := pkg.ValidateToken(refreshToken)
+// RefreshToken exchanges a refresh token for a new pair. The presented
+// token is revoked; presenting it again afterwards is treated as theft and
+// revokes every token rotated from the same login.
+func (a *authService) RefreshToken(refreshToken, device string) (*responses.TokenResponse, error) {
+	claims, err := pkg.ValidateTokenType(refreshToken, pkg.TokenTypeRefresh)
 	if err != nil {
 		return nil, pkg.NewUnauthorizedError("Token tidak valid atau sudah kadaluarsa")
 	}
 
-	user, err := a.repo.FindByID(claims.UserID)
+	stored, err := a.refreshRepo.FindRefreshTokenByJTI(claims.ID)
 	if err != nil {
-		return nil, pkg.NewNotFoundError("User tidak ditemukan")
+		return nil, pkg.NewUnauthorizedError("Token tidak valid atau sudah kadaluarsa")
 	}
 
-	accessToken, newRefreshToken, err := pkg.GenerateJWT(user.ID, user.LocationID, user.Role)
+	if stored.RevokedAt != nil {
+		if err := a.refreshRepo.RevokeRefreshTokenFamily(stored.FamilyID); err != nil {
+			return nil, pkg.NewInternalServerError("Gagal mencabut token")
+		}
+		return nil, pkg.NewUnauthorizedError("Token sudah digunakan, silakan login kembali")
+	}
+
+	user, err := a.repo.FindByID(stored.UserID)
 	if err != nil {
+		return nil, pkg.NewNotFoundError("User tidak ditemukan")
+	}
+
+	if device == "" {
+		device = stored.Device
+	}
+
+	next := newRefreshToken(user.ID, stored.FamilyID, device)
+	if err := a.refreshRepo.RotateRefreshToken(stored.JTI, next); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Another request rotated the same token first.
+			if err := a.refreshRepo.RevokeRefreshTokenFamily(stored.FamilyID); err != nil {
+				return nil, pkg.NewInternalServerError("Gagal mencabut token")
+			}
+			return nil, pkg.NewUnauthorizedError("Token sudah digunakan, silakan login kembali")
+		}
 		return nil, pkg.NewInternalServerError("Gagal membuat token baru")
 	}
 
-	return &responses.TokenResponse{
-		AccessToken:  accessToken,
-		RefreshToken: newRefreshToken,
-	}, nil
+	return a.tokenPair(user, next)
+}
+
+func (a *authService) Logout(refreshToken string) error {
+	claims, err := pkg.ValidateTokenType(refreshToken, pkg.TokenTypeRefresh)
+	if err != nil {
+		return pkg.NewUnauthorizedError("Token tidak valid atau sudah kadaluarsa")
+	}
+
+	stored, err := a.refreshRepo.FindRefreshTokenByJTI(claims.ID)
+	if err != nil {
+		return pkg.NewUnauthorizedError("Token tidak valid atau sudah kadaluarsa")
+	}
+
+	if err := a.refreshRepo.RevokeRefreshTokenFamily(stored.FamilyID); err != nil {
+		return pkg.NewInternalServerError("Gagal logout")
+	}
+
+	return nil
+}
+
+func (a *authService) LogoutEverywhere(userID int) error {
+	if err := a.refreshRepo.RevokeAllRefreshTokenByUserID(userID); err != nil {
+		return pkg.NewInternalServerError("Gagal logout dari semua perangkat")
+	}
+
+	return nil
+}
+
+// SendResetCode starts phone verification for a password reset. Unknown
+// numbers are ignored without an error so the endpoint cannot be used to
+// send messages to arbitrary numbers.
+func (a *authService) SendResetCode(req requests.SendResetCodeRequest) error {
+	if err := pkg.ValidateStruct(req); err != nil {
+		return pkg.NewBadRequestError(err.Error())
+	}
+
+	if _, err := a.repo.FindByPhoneNumber(req.PhoneNumber); err != nil {
+		return nil
+	}
+
+	return a.verifier.SendCode(context.Background(), req.PhoneNumber, pkg.OTPPurposePasswordReset)
 }
 
-func (a *authService) ResetPassword(req requests.ResetPasswordRequest) error {
+// ResetPassword sets a new password once the phone number is verified and
+// signs the user out everywhere. The reset is audited as the user's own
+// change; the hash itself is never recorded.
+func (a *authService) ResetPassword(ctx context.Context, req requests.ResetPasswordRequest) error {
 	if err := pkg.ValidateStruct(req); err != nil {
 		return pkg.NewBadRequestError(err.Error())
 	}
@@ -48,9 +131,16 @@ func (a *authService) ResetPassword(req requests.ResetPasswordRequest) error {
 		return pkg.NewBadRequestError("Password dan Confirm Password tidak cocok")
 	}
 
-	_, err := VerifyFirebaseToken(req.FirebaseToken)
-	if err != nil {
-		return pkg.NewUnauthorizedError("Firebase token tidak valid")
+	proof := req.Code
+	if proof == "" {
+		proof = req.FirebaseToken
+	}
+	if proof == "" {
+		return pkg.NewBadRequestError("Kode verifikasi wajib diisi")
+	}
+
+	if err := a.verifier.Verify(ctx, req.PhoneNumber, pkg.OTPPurposePasswordReset, proof); err != nil {
+		return err
 	}
 
 	hashed, err := pkg.HashPassword(req.Password)
@@ -63,10 +153,22 @@ func (a *authService) ResetPassword(req requests.ResetPasswordRequest) error {
 		return pkg.NewInternalServerError("Gagal mereset password")
 	}
 
+	user, err := a.repo.FindByPhoneNumber(req.PhoneNumber)
+	if err == nil {
+		ctx = pkg.WithAccessScope(ctx, pkg.NewAccessScope(user.ID, user.Role, user.LocationID))
+		a.audit.Record(ctx, pkg.AuditActionUpdate, pkg.AuditEntityUser, user.ID,
+			map[string]any{"passwordResetAt": nil},
+			map[string]any{"passwordResetAt": time.Now()})
+
+		if err := a.refreshRepo.RevokeAllRefreshTokenByUserID(user.ID); err != nil {
+			return pkg.NewInternalServerError("Gagal mencabut sesi lama")
+		}
+	}
+
 	return nil
 }
 
-func (a *authService) Login(req requests.LoginRequest) (*responses.TokenResponse, error) {
+func (a *authService) Login(req requests.LoginRequest, device string) (*responses.TokenResponse, error) {
 	if err := pkg.ValidateStruct(req); err != nil {
 		return nil, pkg.NewBadRequestError(err.Error())
 	}
@@ -80,19 +182,96 @@ func (a *authService) Login(req requests.LoginRequest) (*responses.TokenResponse
 		return nil, pkg.NewUnauthorizedError("Password salah")
 	}
 
-	accessToken, refreshToken, err := pkg.GenerateJWT(user.ID, user.LocationID, user.Role)
+	if req.Device != "" {
+		device = req.Device
+	}
+
+	token := newRefreshToken(user.ID, uuid.New().String(), device)
+	if _, err := a.refreshRepo.CreateRefreshToken(token); err != nil {
+		return nil, pkg.NewInternalServerError("Gagal membuat token")
+	}
+
+	return a.tokenPair(user, token)
+}
+
+// SendParentLoginCode starts phone verification for a parent signing in.
+// Like SendResetCode, unknown numbers are ignored without an error.
+func (a *authService) SendParentLoginCode(req requests.ParentSendCodeRequest) error {
+	if err := pkg.ValidateStruct(req); err != nil {
+		return pkg.NewBadRequestError(err.Error())
+	}
+
+	parent, err := a.parentRepo.FindParentByPhoneNumber(req.PhoneNumber)
+	if err != nil || parent.DeletedAt != nil {
+		return nil
+	}
+
+	return a.verifier.SendCode(context.Background(), req.PhoneNumber, pkg.OTPPurposeParentLogin)
+}
+
+// ParentLogin signs a parent in with a verified phone number. The access
+// token is limited to the parent's own children.
+func (a *authService) ParentLogin(req requests.ParentLoginRequest) (*responses.TokenResponse, error) {
+	if err := pkg.ValidateStruct(req); err != nil {
+		return nil, pkg.NewBadRequestError(err.Error())
+	}
+
+	proof := req.Code
+	if proof == "" {
+		proof = req.FirebaseToken
+	}
+	if proof == "" {
+		return nil, pkg.NewBadRequestError("Kode verifikasi wajib diisi")
+	}
+
+	if err := a.verifier.Verify(context.Background(), req.PhoneNumber, pkg.OTPPurposeParentLogin, proof); err != nil {
+		return nil, err
+	}
+
+	parent, err := a.parentRepo.FindParentByPhoneNumber(req.PhoneNumber)
+	if err != nil || parent.DeletedAt != nil {
+		return nil, pkg.NewNotFoundError("Nomor telepon tidak terdaftar")
+	}
+
+	accessToken, err := pkg.GenerateParentAccessToken(parent.ID, parent.LocationID)
 	if err != nil {
 		return nil, pkg.NewInternalServerError("Gagal membuat token")
 	}
 
-	tokenResponse := responses.TokenResponse{
+	return &responses.TokenResponse{AccessToken: accessToken}, nil
+}
+
+func (a *authService) tokenPair(user *models.User, token *models.RefreshToken) (*responses.TokenResponse, error) {
+	accessToken, err := pkg.GenerateAccessToken(user.ID, user.LocationID, user.Role)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal membuat token")
+	}
+
+	refreshToken, err := pkg.GenerateRefreshToken(user.ID, user.LocationID, user.Role, token.JTI, token.ExpiresAt)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal membuat token")
+	}
+
+	return &responses.TokenResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
+	}, nil
+}
+
+func newRefreshToken(userID int, familyID, device string) *models.RefreshToken {
+	if len(device) > 255 {
+		device = device[:255]
 	}
 
-	return &tokenResponse, nil
+	return &models.RefreshToken{
+		JTI:       uuid.New().String(),
+		FamilyID:  familyID,
+		UserID:    userID,
+		Device:    device,
+		ExpiresAt: time.Now().Add(pkg.RefreshTokenTTL),
+	}
 }
 
-func NewAuthService(repo repositories.AuthRepository) AuthService {
-	return &authService{repo: repo}
+func NewAuthService(repo repositories.AuthRepository, refreshRepo repositories.RefreshTokenRepository, parentRepo repositories.ParentRepository, verifier PhoneVerifier, audit AuditService) AuthService {
+	return &authService{repo: repo, refreshRepo: refreshRepo, parentRepo: parentRepo, verifier: verifier, audit: audit}
 }
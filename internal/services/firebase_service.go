@@ -2,25 +2,59 @@ package services
 
 import (
 	"context"
+	"strings"
+	"unicode"
 
 	"grovia/internal/firebase"
 	"grovia/pkg"
-
-	"firebase.google.com/go/v4/auth"
 )
 
-func VerifyFirebaseToken(idToken string) (*auth.Token, error) {
-	ctx := context.Background()
+type firebaseVerifier struct{}
+
+// SendCode implements PhoneVerifier. With Firebase phone auth the app
+// requests the SMS from Firebase directly.
+func (f *firebaseVerifier) SendCode(ctx context.Context, phoneNumber, purpose string) error {
+	return pkg.NewBadRequestError("Kode OTP dikirim melalui aplikasi")
+}
 
+// Verify implements PhoneVerifier. The proof is a Firebase ID token, which
+// must belong to the same phone number. Firebase tokens carry no purpose;
+// each flow verifies the token on its own request.
+func (f *firebaseVerifier) Verify(ctx context.Context, phoneNumber, purpose, proof string) error {
 	client, err := firebase.FirebaseApp.Auth(ctx)
 	if err != nil {
-		return nil, pkg.NewInternalServerError("Error")
+		return pkg.NewInternalServerError("Gagal menghubungi Firebase")
 	}
 
-	token, err := client.VerifyIDToken(ctx, idToken)
+	token, err := client.VerifyIDToken(ctx, proof)
 	if err != nil {
-		return nil, pkg.NewBadRequestError("Firebase token invalid")
+		return pkg.NewUnauthorizedError("Firebase token tidak valid")
 	}
 
-	return token, nil
+	tokenPhone, _ := token.Claims["phone_number"].(string)
+	if localPhoneNumber(tokenPhone) != localPhoneNumber(phoneNumber) {
+		return pkg.NewUnauthorizedError("Firebase token bukan untuk nomor telepon ini")
+	}
+
+	return nil
+}
+
+// localPhoneNumber turns +62 numbers, as Firebase reports them, into the
+// 08.. form stored for users.
+func localPhoneNumber(phoneNumber string) string {
+	digits := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, phoneNumber)
+
+	if strings.HasPrefix(digits, "62") {
+		return "0" + strings.TrimPrefix(digits, "62")
+	}
+	return digits
+}
+
+func NewFirebaseVerifier() PhoneVerifier {
+	return &firebaseVerifier{}
 }
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"grovia/internal/dto/responses"
+	"grovia/internal/growth"
+	"grovia/internal/models"
+	"grovia/internal/repositories"
+	"grovia/pkg"
+	"time"
+)
+
+// falteringLookback limits the location-wide scan to the visits that can
+// still influence the T/2T and trajectory flags.
+const falteringLookback = 6
+
+type GrowthService interface {
+	GetToddlerFaltering(scope pkg.AccessScope, toddlerID int) (*responses.GrowthFalteringResponse, error)
+	GetFaltering(scope pkg.AccessScope) ([]responses.GrowthFalteringResponse, error)
+}
+
+type growthService struct {
+	predictRepo repositories.PredictRepository
+}
+
+func (g *growthService) GetToddlerFaltering(scope pkg.AccessScope, toddlerID int) (*responses.GrowthFalteringResponse, error) {
+	predicts, err := g.predictRepo.GetAllPredictByToddlerID(scope, toddlerID)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal mengambil riwayat pengukuran")
+	}
+
+	response := toGrowthFalteringResponse(predicts)
+	response.ToddlerID = toddlerID
+
+	return &response, nil
+}
+
+func (g *growthService) GetFaltering(scope pkg.AccessScope) ([]responses.GrowthFalteringResponse, error) {
+	since := time.Now().AddDate(0, -falteringLookback, 0)
+
+	predicts, err := g.predictRepo.GetRecentPredict(scope, since)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal mengambil riwayat pengukuran")
+	}
+
+	byToddler := map[int][]models.Predict{}
+	var order []int
+	for _, p := range predicts {
+		if _, ok := byToddler[p.ToddlerID]; !ok {
+			order = append(order, p.ToddlerID)
+		}
+		byToddler[p.ToddlerID] = append(byToddler[p.ToddlerID], p)
+	}
+
+	result := []responses.GrowthFalteringResponse{}
+	for _, toddlerID := range order {
+		response := toGrowthFalteringResponse(byToddler[toddlerID])
+		if len(response.Flags) == 0 {
+			continue
+		}
+		result = append(result, response)
+	}
+
+	return result, nil
+}
+
+func toGrowthFalteringResponse(predicts []models.Predict) responses.GrowthFalteringResponse {
+	points := make([]growth.Point, 0, len(predicts))
+	for _, p := range predicts {
+		hfa := p.Zscore
+		points = append(points, growth.Point{
+			MeasuredAt: p.CreatedAt,
+			Height:     p.Height,
+			Weight:     p.Weight,
+			HfaZscore:  &hfa,
+			WfaZscore:  p.WfaZscore,
+		})
+	}
+
+	faltering := growth.DetectFaltering(points)
+
+	response := responses.GrowthFalteringResponse{
+		Flags:           faltering.Flags,
+		ConsecutiveT:    faltering.ConsecutiveT,
+		LastMeasuredAt:  faltering.LastMeasuredAt,
+		HfaZscoreChange: faltering.HfaZscoreChange,
+		WfaZscoreChange: faltering.WfaZscoreChange,
+	}
+
+	if len(predicts) > 0 {
+		latest := predicts[0]
+		for _, p := range predicts {
+			if p.CreatedAt.After(latest.CreatedAt) {
+				latest = p
+			}
+		}
+		response.ToddlerID = latest.ToddlerID
+		response.Name = latest.Name
+		response.LocationID = latest.LocationID
+	}
+
+	return response
+}
+
+func NewGrowthService(predictRepo repositories.PredictRepository) GrowthService {
+	return &growthService{predictRepo: predictRepo}
+}
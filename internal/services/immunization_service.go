@@ -0,0 +1,294 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"grovia/internal/dto/requests"
+	"grovia/internal/dto/responses"
+	"grovia/internal/models"
+	"grovia/internal/repositories"
+	"grovia/pkg"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ImmunizationService interface {
+	GetVaccines() ([]responses.VaccineResponse, error)
+	CreateImmunization(ctx context.Context, scope pkg.AccessScope, userID int, req requests.CreateImmunizationRequest) (*responses.ImmunizationResponse, error)
+	GetToddlerImmunizations(toddlerID int, scope pkg.AccessScope) (*responses.ToddlerImmunizationResponse, error)
+	DeleteImmunizationByID(ctx context.Context, id int, scope pkg.AccessScope, userID int) error
+	GetDueImmunizations(scope pkg.AccessScope, query requests.DueImmunizationQuery) ([]responses.DueImmunizationResponse, error)
+}
+
+type immunizationService struct {
+	repo        repositories.ImmunizationRepository
+	toddlerRepo repositories.ToddlerRepository
+	sessionRepo repositories.SessionRepository
+	audit       AuditService
+	now         func() time.Time
+}
+
+func (i *immunizationService) GetVaccines() ([]responses.VaccineResponse, error) {
+	vaccines, err := i.repo.GetVaccines()
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal mengambil data vaksin")
+	}
+
+	vaccineResponses := []responses.VaccineResponse{}
+	for _, vaccine := range vaccines {
+		vaccineResponses = append(vaccineResponses, toVaccineResponse(&vaccine))
+	}
+
+	return vaccineResponses, nil
+}
+
+func (i *immunizationService) CreateImmunization(ctx context.Context, scope pkg.AccessScope, userID int, req requests.CreateImmunizationRequest) (*responses.ImmunizationResponse, error) {
+	if err := pkg.ValidateStruct(req); err != nil {
+		return nil, pkg.NewBadRequestError(err.Error())
+	}
+
+	toddler, err := i.toddlerRepo.GetToddlerByID(req.ToddlerID, scope)
+	if err != nil {
+		return nil, pkg.NewNotFoundError("Toddler tidak ditemukan")
+	}
+
+	vaccine, err := i.repo.GetVaccineByCode(strings.ToUpper(strings.TrimSpace(req.VaccineCode)))
+	if err != nil {
+		return nil, pkg.NewBadRequestError("Kode vaksin tidak dikenal")
+	}
+
+	today := dateOf(i.now())
+	givenAt := today
+	if req.GivenAt != nil {
+		givenAt, _ = time.Parse(time.DateOnly, *req.GivenAt)
+	}
+	if givenAt.After(today) {
+		return nil, pkg.NewBadRequestError("Tanggal imunisasi tidak boleh di masa depan")
+	}
+	if givenAt.Before(dateOf(toddler.Birthdate)) {
+		return nil, pkg.NewBadRequestError("Tanggal imunisasi tidak boleh sebelum tanggal lahir")
+	}
+
+	if req.SessionID != nil {
+		if _, err := i.sessionRepo.GetSessionByID(*req.SessionID, pkg.LocationScope(toddler.LocationID)); err != nil {
+			return nil, pkg.NewBadRequestError("Sesi tidak ditemukan di lokasi balita ini")
+		}
+	}
+
+	if _, err := i.repo.GetImmunizationByVaccine(toddler.ID, vaccine.ID); err == nil {
+		return nil, pkg.NewConflictError("Imunisasi " + vaccine.Name + " sudah tercatat untuk balita ini")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, pkg.NewInternalServerError("Gagal mencatat imunisasi")
+	}
+
+	immunization := &models.Immunization{
+		ToddlerID:    toddler.ID,
+		VaccineID:    vaccine.ID,
+		LocationID:   toddler.LocationID,
+		SessionID:    req.SessionID,
+		GivenAt:      givenAt,
+		RecordedByID: userID,
+	}
+	if req.BatchNumber != nil {
+		immunization.BatchNumber = strings.TrimSpace(*req.BatchNumber)
+	}
+	if req.Notes != nil {
+		immunization.Notes = strings.TrimSpace(*req.Notes)
+	}
+
+	saved, err := i.repo.CreateImmunization(immunization)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal mencatat imunisasi")
+	}
+
+	i.audit.Record(ctx, pkg.AuditActionCreate, pkg.AuditEntityImmunization, saved.ID, nil, saved)
+
+	response := toImmunizationResponse(saved)
+	return &response, nil
+}
+
+// GetToddlerImmunizations returns the toddler's card: every dose of the
+// schedule with its dates and status as of today.
+func (i *immunizationService) GetToddlerImmunizations(toddlerID int, scope pkg.AccessScope) (*responses.ToddlerImmunizationResponse, error) {
+	toddler, err := i.toddlerRepo.GetToddlerByID(toddlerID, scope)
+	if err != nil {
+		return nil, pkg.NewNotFoundError("Toddler tidak ditemukan")
+	}
+
+	vaccines, err := i.repo.GetVaccines()
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal mengambil data vaksin")
+	}
+
+	immunizations, err := i.repo.GetImmunizationsByToddlerID(toddler.ID)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal mengambil data imunisasi")
+	}
+
+	given := map[int]*models.Immunization{}
+	for idx := range immunizations {
+		given[immunizations[idx].VaccineID] = &immunizations[idx]
+	}
+
+	age := ageInMonths(toddler.Birthdate, i.now())
+	card := &responses.ToddlerImmunizationResponse{
+		ToddlerID:   toddler.ID,
+		Name:        toddler.Name,
+		Birthdate:   toddler.Birthdate,
+		AgeMonths:   age,
+		IDLComplete: isIDLComplete(vaccines, given, toddler.Birthdate),
+		Schedule:    []responses.ImmunizationScheduleResponse{},
+	}
+
+	for _, vaccine := range vaccines {
+		entry := responses.ImmunizationScheduleResponse{
+			Vaccine:     toVaccineResponse(&vaccine),
+			DueDate:     toddler.Birthdate.AddDate(0, vaccine.MinAgeMonths, 0).Format(time.DateOnly),
+			OverdueDate: toddler.Birthdate.AddDate(0, vaccine.OverdueAgeMonths, 0).Format(time.DateOnly),
+			Status:      immunizationStatus(&vaccine, age, given[vaccine.ID] != nil),
+		}
+		if immunization, ok := given[vaccine.ID]; ok {
+			response := toImmunizationResponse(immunization)
+			entry.Immunization = &response
+		}
+		card.Schedule = append(card.Schedule, entry)
+	}
+
+	return card, nil
+}
+
+func (i *immunizationService) DeleteImmunizationByID(ctx context.Context, id int, scope pkg.AccessScope, userID int) error {
+	before, err := i.repo.GetImmunizationByID(id, scope)
+	if err != nil {
+		return pkg.NewNotFoundError("Imunisasi tidak ditemukan")
+	}
+
+	if err := i.repo.DeleteImmunizationByID(id, scope, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewNotFoundError("Imunisasi tidak ditemukan")
+		}
+		return pkg.NewInternalServerError("Gagal menghapus imunisasi")
+	}
+
+	i.audit.Record(ctx, pkg.AuditActionDelete, pkg.AuditEntityImmunization, id, before, nil)
+
+	return nil
+}
+
+// GetDueImmunizations lists, as of today, the doses toddlers have reached
+// the age for but not been given.
+func (i *immunizationService) GetDueImmunizations(scope pkg.AccessScope, query requests.DueImmunizationQuery) ([]responses.DueImmunizationResponse, error) {
+	if err := pkg.ValidateStruct(query); err != nil {
+		return nil, pkg.NewBadRequestError(err.Error())
+	}
+
+	locationID, err := reportLocation(scope, query.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := i.repo.GetDueImmunizations(repositories.DueFilter{
+		LocationID:  locationID,
+		On:          dateOf(i.now()),
+		Status:      query.Status,
+		VaccineCode: strings.ToUpper(strings.TrimSpace(query.Vaccine)),
+	})
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal mengambil data imunisasi yang jatuh tempo")
+	}
+
+	dueResponses := []responses.DueImmunizationResponse{}
+	for _, row := range rows {
+		dueResponses = append(dueResponses, responses.DueImmunizationResponse{
+			ToddlerID:         row.ToddlerID,
+			Name:              row.Name,
+			Birthdate:         row.Birthdate,
+			Sex:               row.Sex,
+			AgeMonths:         row.AgeMonths,
+			LocationID:        row.LocationID,
+			LocationName:      row.LocationName,
+			ParentName:        row.ParentName,
+			ParentPhoneNumber: row.ParentPhoneNumber,
+			VaccineCode:       row.VaccineCode,
+			VaccineName:       row.VaccineName,
+			DueDate:           row.Birthdate.AddDate(0, row.MinAgeMonths, 0).Format(time.DateOnly),
+			OverdueDate:       row.Birthdate.AddDate(0, row.OverdueAgeMonths, 0).Format(time.DateOnly),
+			Status:            row.Status,
+		})
+	}
+
+	return dueResponses, nil
+}
+
+// immunizationStatus places a dose for a toddler of ageMonths. It matches
+// the status GetDueImmunizations computes in SQL.
+func immunizationStatus(vaccine *models.Vaccine, ageMonths int, given bool) string {
+	switch {
+	case given:
+		return models.ImmunizationStatusGiven
+	case ageMonths >= vaccine.OverdueAgeMonths:
+		return models.ImmunizationStatusOverdue
+	case ageMonths >= vaccine.MinAgeMonths:
+		return models.ImmunizationStatusDue
+	default:
+		return models.ImmunizationStatusUpcoming
+	}
+}
+
+// isIDLComplete reports whether every basic dose was given before the
+// first birthday.
+func isIDLComplete(vaccines []models.Vaccine, given map[int]*models.Immunization, birthdate time.Time) bool {
+	firstBirthday := dateOf(birthdate).AddDate(1, 0, 0)
+	for _, vaccine := range vaccines {
+		if !vaccine.Basic {
+			continue
+		}
+		immunization, ok := given[vaccine.ID]
+		if !ok || !immunization.GivenAt.Before(firstBirthday) {
+			return false
+		}
+	}
+	return true
+}
+
+// dateOf is the calendar day of t, as a date column is read back.
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func toVaccineResponse(vaccine *models.Vaccine) responses.VaccineResponse {
+	return responses.VaccineResponse{
+		ID:               vaccine.ID,
+		Code:             vaccine.Code,
+		Name:             vaccine.Name,
+		Antigen:          vaccine.Antigen,
+		Dose:             vaccine.Dose,
+		MinAgeMonths:     vaccine.MinAgeMonths,
+		OverdueAgeMonths: vaccine.OverdueAgeMonths,
+		Basic:            vaccine.Basic,
+	}
+}
+
+func toImmunizationResponse(immunization *models.Immunization) responses.ImmunizationResponse {
+	return responses.ImmunizationResponse{
+		ID:           immunization.ID,
+		ToddlerID:    immunization.ToddlerID,
+		VaccineID:    immunization.VaccineID,
+		VaccineCode:  immunization.Vaccine.Code,
+		VaccineName:  immunization.Vaccine.Name,
+		LocationID:   immunization.LocationID,
+		SessionID:    immunization.SessionID,
+		GivenAt:      immunization.GivenAt.Format(time.DateOnly),
+		BatchNumber:  immunization.BatchNumber,
+		Notes:        immunization.Notes,
+		RecordedByID: immunization.RecordedByID,
+		CreatedAt:    immunization.CreatedAt,
+		UpdatedAt:    immunization.UpdatedAt,
+	}
+}
+
+func NewImmunizationService(repo repositories.ImmunizationRepository, toddlerRepo repositories.ToddlerRepository, sessionRepo repositories.SessionRepository, audit AuditService) ImmunizationService {
+	return &immunizationService{repo: repo, toddlerRepo: toddlerRepo, sessionRepo: sessionRepo, audit: audit, now: time.Now}
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"grovia/internal/dto/requests"
+	"grovia/internal/models"
+	"grovia/internal/repositories"
+	"grovia/pkg"
+	"net/http"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type fakeImmunizationRepository struct {
+	repositories.ImmunizationRepository
+	vaccines      []models.Vaccine
+	immunizations []models.Immunization
+	idl           []repositories.IDLRow
+	coverage      []repositories.VaccineCoverageRow
+
+	created *models.Immunization
+}
+
+func (f *fakeImmunizationRepository) GetVaccines() ([]models.Vaccine, error) {
+	return f.vaccines, nil
+}
+
+func (f *fakeImmunizationRepository) GetVaccineByCode(code string) (*models.Vaccine, error) {
+	for _, vaccine := range f.vaccines {
+		if vaccine.Code == code {
+			return &vaccine, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeImmunizationRepository) GetImmunizationByVaccine(toddlerID, vaccineID int) (*models.Immunization, error) {
+	for _, immunization := range f.immunizations {
+		if immunization.ToddlerID == toddlerID && immunization.VaccineID == vaccineID {
+			return &immunization, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeImmunizationRepository) GetImmunizationsByToddlerID(toddlerID int) ([]models.Immunization, error) {
+	return f.immunizations, nil
+}
+
+func (f *fakeImmunizationRepository) CreateImmunization(immunization *models.Immunization) (*models.Immunization, error) {
+	f.created = immunization
+	saved := *immunization
+	saved.ID = 1
+	return &saved, nil
+}
+
+func (f *fakeImmunizationRepository) GetIDL(locationID int, start, end time.Time) ([]repositories.IDLRow, error) {
+	return f.idl, nil
+}
+
+func (f *fakeImmunizationRepository) GetVaccineCoverage(locationID int, start, end time.Time) ([]repositories.VaccineCoverageRow, error) {
+	return f.coverage, nil
+}
+
+func testVaccines() []models.Vaccine {
+	return []models.Vaccine{
+		{ID: 1, Code: "HB0", Name: "Hepatitis B 0 (HB0)", MinAgeMonths: 0, OverdueAgeMonths: 1, Basic: true},
+		{ID: 2, Code: "BCG", Name: "BCG", MinAgeMonths: 1, OverdueAgeMonths: 2, Basic: true},
+		{ID: 3, Code: "MR-1", Name: "Campak-Rubela (MR) 1", MinAgeMonths: 9, OverdueAgeMonths: 10, Basic: true},
+		{ID: 4, Code: "MR-2", Name: "Campak-Rubela (MR) lanjutan", MinAgeMonths: 18, OverdueAgeMonths: 19},
+	}
+}
+
+var immunizationTestBirthdate = time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+
+func newTestImmunizationService(repo *fakeImmunizationRepository, sessions ...models.Session) *immunizationService {
+	toddlers := &fakeToddlerRepository{toddlers: []models.Toddler{{ID: 8, Name: "Sari", LocationID: 3, Birthdate: immunizationTestBirthdate}}}
+	s := NewImmunizationService(repo, toddlers, &fakeSessionRepository{sessions: sessions}, fakeAuditService{}).(*immunizationService)
+	s.now = func() time.Time { return time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC) }
+	return s
+}
+
+func TestImmunizationStatus(t *testing.T) {
+	mr := &models.Vaccine{MinAgeMonths: 9, OverdueAgeMonths: 10}
+
+	tests := []struct {
+		age   int
+		given bool
+		want  string
+	}{
+		{8, false, models.ImmunizationStatusUpcoming},
+		{9, false, models.ImmunizationStatusDue},
+		{10, false, models.ImmunizationStatusOverdue},
+		{10, true, models.ImmunizationStatusGiven},
+		{3, true, models.ImmunizationStatusGiven},
+	}
+
+	for _, tt := range tests {
+		if got := immunizationStatus(mr, tt.age, tt.given); got != tt.want {
+			t.Errorf("immunizationStatus(age %d, given %v) = %q, want %q", tt.age, tt.given, got, tt.want)
+		}
+	}
+}
+
+func TestIsIDLComplete(t *testing.T) {
+	vaccines := testVaccines()
+	dose := func(vaccineID int, givenAt time.Time) *models.Immunization {
+		return &models.Immunization{VaccineID: vaccineID, GivenAt: givenAt}
+	}
+	early := immunizationTestBirthdate.AddDate(0, 10, 0)
+	firstBirthday := immunizationTestBirthdate.AddDate(1, 0, 0)
+
+	complete := map[int]*models.Immunization{1: dose(1, immunizationTestBirthdate), 2: dose(2, early), 3: dose(3, early)}
+	if !isIDLComplete(vaccines, complete, immunizationTestBirthdate) {
+		t.Error("every basic dose given, but IDL is not complete")
+	}
+
+	late := map[int]*models.Immunization{1: dose(1, immunizationTestBirthdate), 2: dose(2, early), 3: dose(3, firstBirthday)}
+	if isIDLComplete(vaccines, late, immunizationTestBirthdate) {
+		t.Error("MR given on the first birthday still counted for IDL")
+	}
+
+	missing := map[int]*models.Immunization{1: dose(1, immunizationTestBirthdate), 3: dose(3, early), 4: dose(4, early)}
+	if isIDLComplete(vaccines, missing, immunizationTestBirthdate) {
+		t.Error("BCG missing, but IDL is complete")
+	}
+}
+
+func TestGetToddlerImmunizations(t *testing.T) {
+	repo := &fakeImmunizationRepository{
+		vaccines:      testVaccines(),
+		immunizations: []models.Immunization{{ID: 5, ToddlerID: 8, VaccineID: 1, GivenAt: immunizationTestBirthdate}},
+	}
+	s := newTestImmunizationService(repo)
+
+	card, err := s.GetToddlerImmunizations(8, pkg.AccessScope{LocationID: 3})
+	if err != nil {
+		t.Fatalf("GetToddlerImmunizations: %v", err)
+	}
+
+	if card.AgeMonths != 9 || card.IDLComplete {
+		t.Errorf("card = %+v", card)
+	}
+	want := []string{
+		models.ImmunizationStatusGiven,
+		models.ImmunizationStatusOverdue,
+		models.ImmunizationStatusDue,
+		models.ImmunizationStatusUpcoming,
+	}
+	for i, entry := range card.Schedule {
+		if entry.Status != want[i] {
+			t.Errorf("%s is %q, want %q", entry.Vaccine.Code, entry.Status, want[i])
+		}
+	}
+	if mr := card.Schedule[2]; mr.DueDate != "2026-10-10" || mr.OverdueDate != "2026-11-10" {
+		t.Errorf("MR dates = %s, %s", mr.DueDate, mr.OverdueDate)
+	}
+	if card.Schedule[0].Immunization == nil || card.Schedule[0].Immunization.ID != 5 {
+		t.Errorf("HB0 record missing from the card")
+	}
+}
+
+func TestCreateImmunization(t *testing.T) {
+	repo := &fakeImmunizationRepository{vaccines: testVaccines()}
+	s := newTestImmunizationService(repo)
+
+	batch := " MR2611A "
+	_, err := s.CreateImmunization(context.Background(), pkg.AccessScope{LocationID: 3}, 4, requests.CreateImmunizationRequest{ToddlerID: 8, VaccineCode: "mr-1", BatchNumber: &batch})
+	if err != nil {
+		t.Fatalf("CreateImmunization: %v", err)
+	}
+
+	got := repo.created
+	if got.VaccineID != 3 || got.LocationID != 3 || got.RecordedByID != 4 || got.BatchNumber != "MR2611A" {
+		t.Errorf("created immunization = %+v", got)
+	}
+	if got.GivenAt.Format(time.DateOnly) != "2026-10-18" {
+		t.Errorf("given at %s, want today", got.GivenAt)
+	}
+}
+
+func TestCreateImmunizationRejects(t *testing.T) {
+	future, beforeBirth := "2026-10-19", "2026-01-09"
+	otherSession := 2
+
+	tests := []struct {
+		name string
+		req  requests.CreateImmunizationRequest
+		want int
+	}{
+		{"toddler of another location", requests.CreateImmunizationRequest{ToddlerID: 9, VaccineCode: "BCG"}, http.StatusNotFound},
+		{"unknown vaccine", requests.CreateImmunizationRequest{ToddlerID: 8, VaccineCode: "DPT"}, http.StatusBadRequest},
+		{"given in the future", requests.CreateImmunizationRequest{ToddlerID: 8, VaccineCode: "BCG", GivenAt: &future}, http.StatusBadRequest},
+		{"given before birth", requests.CreateImmunizationRequest{ToddlerID: 8, VaccineCode: "BCG", GivenAt: &beforeBirth}, http.StatusBadRequest},
+		{"session of another location", requests.CreateImmunizationRequest{ToddlerID: 8, VaccineCode: "BCG", SessionID: &otherSession}, http.StatusBadRequest},
+		{"already recorded", requests.CreateImmunizationRequest{ToddlerID: 8, VaccineCode: "HB0"}, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeImmunizationRepository{
+				vaccines:      testVaccines(),
+				immunizations: []models.Immunization{{ToddlerID: 8, VaccineID: 1}},
+			}
+			s := newTestImmunizationService(repo, models.Session{ID: 2, LocationID: 4})
+
+			_, err := s.CreateImmunization(context.Background(), pkg.AccessScope{LocationID: 3}, 4, tt.req)
+			if statusCode(err) != tt.want {
+				t.Errorf("error = %v, want status %d", err, tt.want)
+			}
+			if repo.created != nil {
+				t.Error("immunization was recorded")
+			}
+		})
+	}
+}
+
+func TestGetImmunizationReport(t *testing.T) {
+	repo := &fakeImmunizationRepository{
+		idl: []repositories.IDLRow{
+			{LocationID: 1, LocationName: "Posyandu Mawar", Cohort: 8, Complete: 6},
+			{LocationID: 2, LocationName: "Posyandu Melati", Cohort: 2, Complete: 1},
+		},
+		coverage: []repositories.VaccineCoverageRow{{Code: "HB0", Name: "Hepatitis B 0 (HB0)", Basic: true, Given: 9}},
+	}
+	service := NewReportService(&fakeReportRepository{}, nil, repo)
+
+	report, err := service.GetImmunizationReport(pkg.AccessScope{AllLocations: true}, "", "2026-01", "2026-06")
+	if err != nil {
+		t.Fatalf("GetImmunizationReport: %v", err)
+	}
+
+	if report.From != "2026-01" || report.To != "2026-06" {
+		t.Errorf("period = %s to %s", report.From, report.To)
+	}
+	if report.Locations[0].Percentage != 75 || report.Total.Cohort != 10 || report.Total.Complete != 7 || report.Total.Percentage != 70 {
+		t.Errorf("IDL = %+v, total %+v", report.Locations, report.Total)
+	}
+	if report.Vaccines[0].Percentage != 90 {
+		t.Errorf("HB0 coverage = %+v", report.Vaccines[0])
+	}
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"encoding/json"
+	"grovia/internal/dto/responses"
+	"grovia/internal/models"
+	"grovia/internal/repositories"
+	"grovia/pkg"
+	"math"
+	"strconv"
+)
+
+type JobService interface {
+	Enqueue(jobType string, payload any, input []byte, locationID, userID int) (*responses.JobResponse, error)
+	GetAllJob(scope pkg.AccessScope, pageStr, limitStr string) ([]responses.JobResponse, *responses.PaginationMeta, error)
+	GetJobByID(id int, scope pkg.AccessScope) (*responses.JobResponse, error)
+	GetJobResultFile(id int, scope pkg.AccessScope) ([]byte, string, error)
+}
+
+type jobService struct {
+	repo repositories.JobRepository
+}
+
+func (j *jobService) Enqueue(jobType string, payload any, input []byte, locationID, userID int) (*responses.JobResponse, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal membuat job")
+	}
+	payloadStr := string(encoded)
+
+	job, err := j.repo.CreateJob(&models.Job{
+		Type:        jobType,
+		Status:      models.JobStatusQueued,
+		LocationID:  locationID,
+		CreatedByID: userID,
+		Payload:     &payloadStr,
+		Input:       input,
+	})
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal membuat job")
+	}
+
+	response := toJobResponse(job)
+	return &response, nil
+}
+
+func (j *jobService) GetAllJob(scope pkg.AccessScope, pageStr, limitStr string) ([]responses.JobResponse, *responses.PaginationMeta, error) {
+	page, _ := strconv.Atoi(pageStr)
+	limit, _ := strconv.Atoi(limitStr)
+
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 {
+		limit = 10
+	}
+
+	offset := (page - 1) * limit
+
+	jobs, total, err := j.repo.GetAllJob(scope, limit, offset)
+	if err != nil {
+		return nil, nil, pkg.NewInternalServerError("Gagal mengambil data job")
+	}
+
+	jobResponses := []responses.JobResponse{}
+	for _, job := range jobs {
+		jobResponses = append(jobResponses, toJobResponse(&job))
+	}
+
+	meta := responses.PaginationMeta{
+		Page:      page,
+		Limit:     limit,
+		TotalData: total,
+		TotalPage: int(math.Ceil(float64(total) / float64(limit))),
+	}
+
+	return jobResponses, &meta, nil
+}
+
+func (j *jobService) GetJobByID(id int, scope pkg.AccessScope) (*responses.JobResponse, error) {
+	job, err := j.repo.GetJobByID(id, scope)
+	if err != nil {
+		return nil, pkg.NewNotFoundError("Job tidak ditemukan")
+	}
+
+	response := toJobResponse(job)
+	return &response, nil
+}
+
+func (j *jobService) GetJobResultFile(id int, scope pkg.AccessScope) ([]byte, string, error) {
+	job, err := j.repo.GetJobResultFile(id, scope)
+	if err != nil {
+		return nil, "", pkg.NewNotFoundError("Job tidak ditemukan")
+	}
+
+	if job.Status != models.JobStatusSucceeded {
+		return nil, "", pkg.NewConflictError("Job belum selesai")
+	}
+	if len(job.ResultFile) == 0 {
+		return nil, "", pkg.NewNotFoundError("Job tidak menghasilkan file")
+	}
+
+	return job.ResultFile, job.ResultFileName, nil
+}
+
+func toJobResponse(job *models.Job) responses.JobResponse {
+	response := responses.JobResponse{
+		ID:             job.ID,
+		Type:           job.Type,
+		Status:         job.Status,
+		LocationID:     job.LocationID,
+		CreatedByID:    job.CreatedByID,
+		Progress:       job.Progress,
+		Total:          job.Total,
+		ResultFileName: job.ResultFileName,
+		Error:          job.Error,
+		Attempts:       job.Attempts,
+		StartedAt:      job.StartedAt,
+		FinishedAt:     job.FinishedAt,
+		CreatedAt:      job.CreatedAt,
+		UpdatedAt:      job.UpdatedAt,
+	}
+
+	if job.Result != nil {
+		response.Result = json.RawMessage(*job.Result)
+	}
+
+	return response
+}
+
+func NewJobService(repo repositories.JobRepository) JobService {
+	return &jobService{repo: repo}
+}
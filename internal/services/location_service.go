@@ -6,10 +6,12 @@ import (
 	"grovia/internal/dto/responses"
 	"grovia/internal/models"
 	"grovia/internal/repositories"
+	"grovia/internal/storage"
 	"grovia/pkg"
 	"log"
 	"math"
 	"strconv"
+	"time"
 )
 
 type LocationService interface {
@@ -17,12 +19,14 @@ type LocationService interface {
 	GetAllLocation(name, pageStr, limitStr string) ([]responses.LocationResponse, *responses.PaginationMeta, error)
 	GetLocationByID(id int) (*responses.LocationResponse, error)
 	UpdateLocationByID(ctx context.Context, id, userID int, req requests.LocationRequest) (*responses.LocationResponse, error)
-	DeleteLocationByID(id, userID int) error
+	DeleteLocationByID(ctx context.Context, id, userID int) error
+	GetUpcomingSchedule(id, count int) (*responses.ScheduleResponse, error)
 }
 
 type locationService struct {
-	repo repositories.LocationRepository
-	s3   *S3Service
+	repo    repositories.LocationRepository
+	storage storage.ObjectStorage
+	audit   AuditService
 }
 
 func (l *locationService) CreateLocation(ctx context.Context, req requests.LocationRequest, userID int) (*responses.LocationResponse, error) {
@@ -31,21 +35,23 @@ func (l *locationService) CreateLocation(ctx context.Context, req requests.Locat
 	}
 
 	locationMapping := models.Location{
-		Name:    req.Name,
-		Address: req.Address,
+		Name:         req.Name,
+		Address:      req.Address,
+		ScheduleDay:  req.ScheduleDay,
+		ScheduleTime: req.ScheduleTime,
 	}
 
-	var url string
+	var key string
 	var err error
 	if req.Picture != nil {
-		url, err = l.s3.UploadFile(ctx, req.Picture, "locations")
+		key, err = uploadFile(ctx, l.storage, req.Picture, "locations")
 		if err != nil {
-			return nil, pkg.NewInternalServerError("Gagal upload gambar lokasi")
+			return nil, err
 		}
 	}
 
-	if url != "" {
-		locationMapping.Picture = url
+	if key != "" {
+		locationMapping.Picture = key
 	}
 
 	location, err := l.repo.CreateLocation(&locationMapping)
@@ -54,23 +60,35 @@ func (l *locationService) CreateLocation(ctx context.Context, req requests.Locat
 		return nil, pkg.NewInternalServerError("Gagal membuat lokasi")
 	}
 
+	l.audit.Record(ctx, pkg.AuditActionCreate, pkg.AuditEntityLocation, location.ID, nil, location)
+
 	locationResponse := responses.LocationResponse{
-		ID:        location.ID,
-		Name:      location.Name,
-		Address:   location.Address,
-		Picture:   location.Picture,
-		CreatedAt: location.CreatedAt,
-		UpdatedAt: location.UpdatedAt,
+		ID:              location.ID,
+		Name:            location.Name,
+		Address:         location.Address,
+		Picture:         fileURL(l.storage, location.Picture),
+		PictureVariants: imageVariants(l.storage, location.Picture),
+		ScheduleDay:     location.ScheduleDay,
+		ScheduleTime:    location.ScheduleTime,
+		CreatedAt:       location.CreatedAt,
+		UpdatedAt:       location.UpdatedAt,
 	}
 
 	return &locationResponse, nil
 }
 
-func (l *locationService) DeleteLocationByID(id, userID int) error {
-	err := l.repo.DeleteLocationByID(id, userID)
+func (l *locationService) DeleteLocationByID(ctx context.Context, id, userID int) error {
+	before, err := l.repo.GetLocationByID(id)
+	if err != nil {
+		return pkg.NewNotFoundError("Lokasi tidak ditemukan")
+	}
+
+	err = l.repo.DeleteLocationByID(id, userID)
 	if err != nil {
 		return pkg.NewInternalServerError("Gagal menghapus lokasi")
 	}
+
+	l.audit.Record(ctx, pkg.AuditActionDelete, pkg.AuditEntityLocation, id, before, nil)
 	return nil
 }
 
@@ -100,12 +118,15 @@ func (l *locationService) GetAllLocation(name, pageStr, limitStr string) ([]resp
 
 	for _, v := range locations {
 		locationsResponse = append(locationsResponse, responses.LocationResponse{
-			ID:        v.ID,
-			Name:      v.Name,
-			Address:   v.Address,
-			Picture:   v.Picture,
-			CreatedAt: v.CreatedAt,
-			UpdatedAt: v.UpdatedAt,
+			ID:              v.ID,
+			Name:            v.Name,
+			Address:         v.Address,
+			Picture:         fileURL(l.storage, v.Picture),
+			PictureVariants: imageVariants(l.storage, v.Picture),
+			ScheduleDay:     v.ScheduleDay,
+			ScheduleTime:    v.ScheduleTime,
+			CreatedAt:       v.CreatedAt,
+			UpdatedAt:       v.UpdatedAt,
 		})
 	}
 
@@ -127,12 +148,15 @@ func (l *locationService) GetLocationByID(id int) (*responses.LocationResponse,
 	}
 
 	locationResponse := responses.LocationResponse{
-		ID:        location.ID,
-		Name:      location.Name,
-		Address:   location.Address,
-		Picture:   location.Picture,
-		CreatedAt: location.CreatedAt,
-		UpdatedAt: location.UpdatedAt,
+		ID:              location.ID,
+		Name:            location.Name,
+		Address:         location.Address,
+		Picture:         fileURL(l.storage, location.Picture),
+		PictureVariants: imageVariants(l.storage, location.Picture),
+		ScheduleDay:     location.ScheduleDay,
+		ScheduleTime:    location.ScheduleTime,
+		CreatedAt:       location.CreatedAt,
+		UpdatedAt:       location.UpdatedAt,
 	}
 
 	return &locationResponse, nil
@@ -143,24 +167,31 @@ func (l *locationService) UpdateLocationByID(ctx context.Context, id, userID int
 		return nil, pkg.NewBadRequestError(err.Error())
 	}
 
-	var url string
+	var key string
 	var err error
 	if req.Picture != nil && req.Picture.Filename != "" && req.Picture.Size > 0 {
-		url, err = l.s3.UploadFile(ctx, req.Picture, "locations")
+		key, err = uploadFile(ctx, l.storage, req.Picture, "locations")
 		if err != nil {
-			return nil, pkg.NewInternalServerError("Gagal upload gambar lokasi")
+			return nil, err
 		}
 	}
 
-	log.Println("[DEBUG] Location Picture URL:", url)
+	log.Println("[DEBUG] Location Picture key:", key)
 
 	locationMapping := models.Location{
-		Name:    req.Name,
-		Address: req.Address,
+		Name:         req.Name,
+		Address:      req.Address,
+		ScheduleDay:  req.ScheduleDay,
+		ScheduleTime: req.ScheduleTime,
 	}
 
-	if url != "" {
-		locationMapping.Picture = url
+	if key != "" {
+		locationMapping.Picture = key
+	}
+
+	before, err := l.repo.GetLocationByID(id)
+	if err != nil {
+		return nil, pkg.NewNotFoundError("Lokasi tidak ditemukan")
 	}
 
 	location, err := l.repo.UpdateLocationByID(id, &locationMapping)
@@ -168,18 +199,60 @@ func (l *locationService) UpdateLocationByID(ctx context.Context, id, userID int
 		return nil, pkg.NewInternalServerError("Gagal update data lokasi")
 	}
 
+	l.audit.Record(ctx, pkg.AuditActionUpdate, pkg.AuditEntityLocation, id, before, location)
+
 	locationResponse := responses.LocationResponse{
-		ID:        location.ID,
-		Name:      location.Name,
-		Address:   location.Address,
-		Picture:   location.Picture,
-		CreatedAt: location.CreatedAt,
-		UpdatedAt: location.UpdatedAt,
+		ID:              location.ID,
+		Name:            location.Name,
+		Address:         location.Address,
+		Picture:         fileURL(l.storage, location.Picture),
+		PictureVariants: imageVariants(l.storage, location.Picture),
+		ScheduleDay:     location.ScheduleDay,
+		ScheduleTime:    location.ScheduleTime,
+		CreatedAt:       location.CreatedAt,
+		UpdatedAt:       location.UpdatedAt,
 	}
 
 	return &locationResponse, nil
 }
 
-func NewLocationService(repo repositories.LocationRepository, s3 *S3Service) LocationService {
-	return &locationService{repo: repo, s3: s3}
-}
\ No newline at end of file
+// GetUpcomingSchedule lists the next count posyandu days of the location,
+// starting today. In months shorter than the schedule day the posyandu is
+// held on the last day of the month.
+func (l *locationService) GetUpcomingSchedule(id, count int) (*responses.ScheduleResponse, error) {
+	location, err := l.repo.GetLocationByID(id)
+	if err != nil {
+		return nil, pkg.NewNotFoundError("Lokasi tidak ditemukan")
+	}
+
+	response := responses.ScheduleResponse{
+		LocationID:   location.ID,
+		LocationName: location.Name,
+		Address:      location.Address,
+		ScheduleTime: location.ScheduleTime,
+		Dates:        []time.Time{},
+	}
+
+	if location.ScheduleDay == nil {
+		return &response, nil
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	for month := 0; len(response.Dates) < count; month++ {
+		first := time.Date(today.Year(), today.Month()+time.Month(month), 1, 0, 0, 0, 0, time.Local)
+		day := min(*location.ScheduleDay, first.AddDate(0, 1, -1).Day())
+
+		date := first.AddDate(0, 0, day-1)
+		if date.Before(today) {
+			continue
+		}
+		response.Dates = append(response.Dates, date)
+	}
+
+	return &response, nil
+}
+
+func NewLocationService(repo repositories.LocationRepository, store storage.ObjectStorage, audit AuditService) LocationService {
+	return &locationService{repo: repo, storage: store, audit: audit}
+}
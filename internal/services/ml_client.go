@@ -0,0 +1,290 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"grovia/pkg"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MLClient calls the stunting model served by the ML API.
+type MLClient interface {
+	PredictIndividual(ctx context.Context, req MLPredictRequest) (*MLPredictResponse, error)
+}
+
+// MLPredictRequest is the body of POST /predict-individual.
+type MLPredictRequest struct {
+	Height float64 `json:"height"`
+	Age    int     `json:"age"`
+	Gender string  `json:"gender"`
+}
+
+// MLPredictResponse is the model's height-for-age classification and
+// where it came from. Raw is the response body as received and Latency the
+// duration of the call that returned it.
+type MLPredictResponse struct {
+	Zscore            float64
+	NutritionalStatus string
+	ModelName         string
+	ModelVersion      string
+	Raw               json.RawMessage
+	Latency           time.Duration
+}
+
+const (
+	// mlAttemptTimeout bounds one HTTP call, so a hung model server cannot
+	// hold a request or a group predict job.
+	mlAttemptTimeout = 5 * time.Second
+	mlMaxAttempts    = 3
+	mlBackoff        = 200 * time.Millisecond
+	// After mlBreakerThreshold failed calls in a row the ML API is not
+	// called for mlBreakerCooldown; then a single call probes it.
+	mlBreakerThreshold = 5
+	mlBreakerCooldown  = 30 * time.Second
+
+	mlMaxResponseSize = 1 << 20
+)
+
+type httpMLClient struct {
+	baseURL        string
+	client         *http.Client
+	attemptTimeout time.Duration
+	maxAttempts    int
+	backoff        time.Duration
+	breaker        *circuitBreaker
+}
+
+// mlPredictBody is the wire format of the response. Pointers tell a
+// missing field apart from a zero z-score. The model fields are optional
+// so deployments from before they were added keep working.
+type mlPredictBody struct {
+	Zscore            *float64 `json:"zscore"`
+	NutritionalStatus *string  `json:"nutritionalStatus"`
+	ModelName         string   `json:"modelName"`
+	ModelVersion      string   `json:"modelVersion"`
+}
+
+// mlErrorBody covers the error shapes of the ML API: {"message": ...} and
+// FastAPI's {"detail": ...}.
+type mlErrorBody struct {
+	Message string          `json:"message"`
+	Detail  json.RawMessage `json:"detail"`
+}
+
+// PredictIndividual implements MLClient. Timeouts, connection errors, 429
+// and 5xx responses are retried with exponential backoff; other failures
+// are returned at once. Every error is a *pkg.CustomError.
+func (c *httpMLClient) PredictIndividual(ctx context.Context, req MLPredictRequest) (*MLPredictResponse, error) {
+	if !c.breaker.allow() {
+		return nil, pkg.NewServiceUnavailableError("ML API sedang tidak tersedia, coba lagi nanti")
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		c.breaker.record(outcomeUnknown)
+		return nil, pkg.NewInternalServerError("Gagal membuat request ML API")
+	}
+
+	for attempt := 1; ; attempt++ {
+		result, retry, err := c.do(ctx, body)
+		if err == nil {
+			c.breaker.record(outcomeHealthy)
+			return result, nil
+		}
+
+		// A caller that gave up says nothing about the ML API.
+		if ctx.Err() != nil {
+			c.breaker.record(outcomeUnknown)
+			return nil, err
+		}
+		if !retry {
+			c.breaker.record(outcomeHealthy)
+			return nil, err
+		}
+		if attempt == c.maxAttempts {
+			c.breaker.record(outcomeUnhealthy)
+			return nil, err
+		}
+		if !sleep(ctx, c.delay(attempt)) {
+			c.breaker.record(outcomeUnknown)
+			return nil, err
+		}
+	}
+}
+
+// do makes one call. retry reports whether the failure may be temporary.
+func (c *httpMLClient) do(ctx context.Context, body []byte) (result *MLPredictResponse, retry bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, c.attemptTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/predict-individual", bytes.NewReader(body))
+	if err != nil {
+		return nil, false, pkg.NewInternalServerError("Gagal membuat request ML API")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	start := time.Now()
+	resp, err := c.client.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, true, pkg.NewGatewayTimeoutError("ML API tidak merespons")
+		}
+		return nil, true, pkg.NewServiceUnavailableError("Gagal menghubungi ML API")
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return nil, true, pkg.NewBadGatewayError("ML API mengembalikan status " + resp.Status)
+	case resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnprocessableEntity:
+		return nil, false, pkg.NewUnprocessableEntityError("Data ditolak ML API: " + mlErrorMessage(resp))
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return nil, false, pkg.NewBadGatewayError("ML API mengembalikan status " + resp.Status)
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, mlMaxResponseSize))
+	if err != nil {
+		return nil, true, pkg.NewBadGatewayError("Gagal membaca response ML API")
+	}
+	latency := time.Since(start)
+
+	var decoded mlPredictBody
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, false, pkg.NewBadGatewayError("Response ML API tidak valid")
+	}
+	if decoded.Zscore == nil {
+		return nil, false, pkg.NewBadGatewayError("Response ML API tidak memiliki zscore")
+	}
+	if decoded.NutritionalStatus == nil || *decoded.NutritionalStatus == "" {
+		return nil, false, pkg.NewBadGatewayError("Response ML API tidak memiliki nutritionalStatus")
+	}
+
+	return &MLPredictResponse{
+		Zscore:            *decoded.Zscore,
+		NutritionalStatus: *decoded.NutritionalStatus,
+		ModelName:         decoded.ModelName,
+		ModelVersion:      decoded.ModelVersion,
+		Raw:               raw,
+		Latency:           latency,
+	}, false, nil
+}
+
+// delay is the wait before the next attempt: exponential, with jitter so
+// parallel job workers do not retry in lockstep.
+func (c *httpMLClient) delay(attempt int) time.Duration {
+	d := c.backoff << (attempt - 1)
+	return d/2 + rand.N(d/2+1)
+}
+
+// mlErrorMessage extracts the reason from an error response, falling back
+// to the status line.
+func mlErrorMessage(resp *http.Response) string {
+	var body mlErrorBody
+	if err := json.NewDecoder(io.LimitReader(resp.Body, mlMaxResponseSize)).Decode(&body); err == nil {
+		var detail string
+		if json.Unmarshal(body.Detail, &detail) == nil && detail != "" {
+			return detail
+		}
+		if body.Message != "" {
+			return body.Message
+		}
+	}
+	return resp.Status
+}
+
+// sleep waits for d and reports false when ctx ends first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+type breakerOutcome int
+
+const (
+	outcomeHealthy breakerOutcome = iota
+	outcomeUnhealthy
+	// outcomeUnknown is a call that ended for reasons of its own, such as
+	// a cancelled request.
+	outcomeUnknown
+)
+
+// circuitBreaker stops calls to a failing service for a cooldown, then
+// lets a single probe through to test whether it recovered.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	failures int
+	openedAt time.Time
+	open     bool
+	probing  bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow reports whether a call may be made. Every allowed call must be
+// followed by record.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.open {
+		return true
+	}
+	if b.probing || b.now().Sub(b.openedAt) < b.cooldown {
+		return false
+	}
+
+	b.probing = true
+	return true
+}
+
+func (b *circuitBreaker) record(outcome breakerOutcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	probe := b.probing
+	b.probing = false
+
+	switch outcome {
+	case outcomeHealthy:
+		b.failures = 0
+		b.open = false
+	case outcomeUnhealthy:
+		b.failures++
+		if probe || b.failures >= b.threshold {
+			b.open = true
+			b.openedAt = b.now()
+		}
+	}
+}
+
+// NewMLClient returns a client for the ML API at baseURL.
+func NewMLClient(baseURL string) MLClient {
+	return &httpMLClient{
+		baseURL:        strings.TrimSuffix(baseURL, "/"),
+		client:         &http.Client{},
+		attemptTimeout: mlAttemptTimeout,
+		maxAttempts:    mlMaxAttempts,
+		backoff:        mlBackoff,
+		breaker:        newCircuitBreaker(mlBreakerThreshold, mlBreakerCooldown),
+	}
+}
@@ -0,0 +1,371 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"grovia/pkg"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeMLServer answers /predict-individual with handler and counts calls.
+type fakeMLServer struct {
+	*httptest.Server
+	calls atomic.Int32
+}
+
+func newFakeMLServer(t *testing.T, handler func(w http.ResponseWriter, r *http.Request, call int)) *fakeMLServer {
+	t.Helper()
+
+	f := &fakeMLServer{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/predict-individual" {
+			http.NotFound(w, r)
+			return
+		}
+		handler(w, r, int(f.calls.Add(1)))
+	}))
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+// testMLClient is a client with short timeouts and no real backoff.
+func testMLClient(url string) *httpMLClient {
+	client := NewMLClient(url + "/").(*httpMLClient)
+	client.attemptTimeout = 200 * time.Millisecond
+	client.backoff = time.Millisecond
+	return client
+}
+
+func writeJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write([]byte(body))
+}
+
+var testMLRequest = MLPredictRequest{Height: 80.5, Age: 18, Gender: "M"}
+
+func TestMLClientPredictIndividual(t *testing.T) {
+	const body = `{"zscore": -2.31, "nutritionalStatus": "stunted", "modelName": "stunting-xgb", "modelVersion": "2026.10.1"}`
+	server := newFakeMLServer(t, func(w http.ResponseWriter, r *http.Request, call int) {
+		var req MLPredictRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req != testMLRequest {
+			t.Errorf("request = %+v, %v", req, err)
+		}
+		writeJSON(w, http.StatusOK, body)
+	})
+
+	got, err := testMLClient(server.URL).PredictIndividual(context.Background(), testMLRequest)
+	if err != nil {
+		t.Fatalf("PredictIndividual: %v", err)
+	}
+	if got.Zscore != -2.31 || got.NutritionalStatus != "stunted" {
+		t.Errorf("got %+v", got)
+	}
+	if got.ModelName != "stunting-xgb" || got.ModelVersion != "2026.10.1" {
+		t.Errorf("model = %q %q", got.ModelName, got.ModelVersion)
+	}
+	if string(got.Raw) != body {
+		t.Errorf("raw = %s, want the response body", got.Raw)
+	}
+	if got.Latency <= 0 {
+		t.Errorf("latency = %v", got.Latency)
+	}
+}
+
+func TestMLClientZeroZscoreIsValid(t *testing.T) {
+	server := newFakeMLServer(t, func(w http.ResponseWriter, r *http.Request, call int) {
+		writeJSON(w, http.StatusOK, `{"zscore": 0, "nutritionalStatus": "normal"}`)
+	})
+
+	got, err := testMLClient(server.URL).PredictIndividual(context.Background(), testMLRequest)
+	if err != nil || got.Zscore != 0 {
+		t.Fatalf("PredictIndividual = %+v, %v", got, err)
+	}
+}
+
+func TestMLClientRetriesTemporaryFailures(t *testing.T) {
+	server := newFakeMLServer(t, func(w http.ResponseWriter, r *http.Request, call int) {
+		switch call {
+		case 1:
+			writeJSON(w, http.StatusServiceUnavailable, `{"message": "loading model"}`)
+		case 2:
+			writeJSON(w, http.StatusTooManyRequests, `{}`)
+		default:
+			writeJSON(w, http.StatusOK, `{"zscore": 1.2, "nutritionalStatus": "normal"}`)
+		}
+	})
+
+	got, err := testMLClient(server.URL).PredictIndividual(context.Background(), testMLRequest)
+	if err != nil {
+		t.Fatalf("PredictIndividual: %v", err)
+	}
+	if got.NutritionalStatus != "normal" || server.calls.Load() != 3 {
+		t.Errorf("got %+v after %d calls", got, server.calls.Load())
+	}
+}
+
+func TestMLClientErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		handler   func(w http.ResponseWriter, r *http.Request, call int)
+		wantCode  int
+		wantCalls int32
+	}{
+		{
+			name: "server error after every attempt",
+			handler: func(w http.ResponseWriter, r *http.Request, call int) {
+				writeJSON(w, http.StatusInternalServerError, `{}`)
+			},
+			wantCode:  http.StatusBadGateway,
+			wantCalls: mlMaxAttempts,
+		},
+		{
+			name: "timeout",
+			handler: func(w http.ResponseWriter, r *http.Request, call int) {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(time.Second):
+				}
+			},
+			wantCode:  http.StatusGatewayTimeout,
+			wantCalls: mlMaxAttempts,
+		},
+		{
+			name: "rejected input is not retried",
+			handler: func(w http.ResponseWriter, r *http.Request, call int) {
+				writeJSON(w, http.StatusUnprocessableEntity, `{"detail": "age must be at most 60"}`)
+			},
+			wantCode:  http.StatusUnprocessableEntity,
+			wantCalls: 1,
+		},
+		{
+			name: "not found is a misconfiguration",
+			handler: func(w http.ResponseWriter, r *http.Request, call int) {
+				writeJSON(w, http.StatusNotFound, `{}`)
+			},
+			wantCode:  http.StatusBadGateway,
+			wantCalls: 1,
+		},
+		{
+			name: "missing zscore",
+			handler: func(w http.ResponseWriter, r *http.Request, call int) {
+				writeJSON(w, http.StatusOK, `{"nutritionalStatus": "normal"}`)
+			},
+			wantCode:  http.StatusBadGateway,
+			wantCalls: 1,
+		},
+		{
+			name: "missing status",
+			handler: func(w http.ResponseWriter, r *http.Request, call int) {
+				writeJSON(w, http.StatusOK, `{"zscore": -1}`)
+			},
+			wantCode:  http.StatusBadGateway,
+			wantCalls: 1,
+		},
+		{
+			name: "not json",
+			handler: func(w http.ResponseWriter, r *http.Request, call int) {
+				writeJSON(w, http.StatusOK, `<html>proxy error</html>`)
+			},
+			wantCode:  http.StatusBadGateway,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeMLServer(t, tt.handler)
+
+			_, err := testMLClient(server.URL).PredictIndividual(context.Background(), testMLRequest)
+			if statusCode(err) != tt.wantCode {
+				t.Errorf("error = %v (status %d), want status %d", err, statusCode(err), tt.wantCode)
+			}
+			if calls := server.calls.Load(); calls != tt.wantCalls {
+				t.Errorf("%d calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestMLClientRejectedInputMessage(t *testing.T) {
+	server := newFakeMLServer(t, func(w http.ResponseWriter, r *http.Request, call int) {
+		writeJSON(w, http.StatusBadRequest, `{"message": "gender must be M or F"}`)
+	})
+
+	_, err := testMLClient(server.URL).PredictIndividual(context.Background(), testMLRequest)
+	if err == nil || err.Error() != "Data ditolak ML API: gender must be M or F" {
+		t.Errorf("error = %v", err)
+	}
+}
+
+func TestMLClientUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	_, err := testMLClient(url).PredictIndividual(context.Background(), testMLRequest)
+	if statusCode(err) != http.StatusServiceUnavailable {
+		t.Errorf("error = %v, want a 503", err)
+	}
+}
+
+func TestMLClientStopsWhenCallerGivesUp(t *testing.T) {
+	server := newFakeMLServer(t, func(w http.ResponseWriter, r *http.Request, call int) {
+		writeJSON(w, http.StatusInternalServerError, `{}`)
+	})
+	client := testMLClient(server.URL)
+	client.backoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := client.PredictIndividual(ctx, testMLRequest); err == nil {
+		t.Fatal("PredictIndividual succeeded")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("returned after %v, want right after the deadline", elapsed)
+	}
+	if server.calls.Load() != 1 {
+		t.Errorf("%d calls, want 1", server.calls.Load())
+	}
+	if client.breaker.failures != 0 {
+		t.Errorf("a cancelled call counted as %d breaker failures", client.breaker.failures)
+	}
+}
+
+func TestMLClientCircuitBreaker(t *testing.T) {
+	var healthy atomic.Bool
+	server := newFakeMLServer(t, func(w http.ResponseWriter, r *http.Request, call int) {
+		if healthy.Load() {
+			writeJSON(w, http.StatusOK, `{"zscore": 0.5, "nutritionalStatus": "normal"}`)
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, `{}`)
+	})
+
+	now := time.Now()
+	client := testMLClient(server.URL)
+	client.maxAttempts = 1
+	client.breaker = newCircuitBreaker(2, time.Minute)
+	client.breaker.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := client.PredictIndividual(ctx, testMLRequest); statusCode(err) != http.StatusBadGateway {
+			t.Fatalf("call %d: error = %v, want a 502", i+1, err)
+		}
+	}
+
+	// Open: calls fail fast without reaching the server.
+	if _, err := client.PredictIndividual(ctx, testMLRequest); statusCode(err) != http.StatusServiceUnavailable {
+		t.Fatalf("open breaker: error = %v, want a 503", err)
+	}
+	if server.calls.Load() != 2 {
+		t.Fatalf("%d calls reached the server, want 2", server.calls.Load())
+	}
+
+	// After the cooldown a failing probe opens it again.
+	now = now.Add(time.Minute)
+	if _, err := client.PredictIndividual(ctx, testMLRequest); statusCode(err) != http.StatusBadGateway {
+		t.Fatalf("failed probe: error = %v, want a 502", err)
+	}
+	if _, err := client.PredictIndividual(ctx, testMLRequest); statusCode(err) != http.StatusServiceUnavailable {
+		t.Fatalf("after failed probe: error = %v, want a 503", err)
+	}
+
+	// A successful probe closes it.
+	now = now.Add(time.Minute)
+	healthy.Store(true)
+	for i := 0; i < 2; i++ {
+		if _, err := client.PredictIndividual(ctx, testMLRequest); err != nil {
+			t.Fatalf("call %d after recovery: %v", i+1, err)
+		}
+	}
+}
+
+func TestCircuitBreakerAllowsOneProbe(t *testing.T) {
+	now := time.Now()
+	breaker := newCircuitBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	breaker.allow()
+	breaker.record(outcomeUnhealthy)
+
+	now = now.Add(time.Minute)
+	if !breaker.allow() {
+		t.Fatal("probe refused after the cooldown")
+	}
+	if breaker.allow() {
+		t.Fatal("second call allowed while the probe is running")
+	}
+
+	// A probe that ended on its own, such as a cancelled request, lets the
+	// next call probe instead.
+	breaker.record(outcomeUnknown)
+	if !breaker.allow() {
+		t.Fatal("no probe allowed after an inconclusive one")
+	}
+}
+
+type fakeMLClient struct {
+	response *MLPredictResponse
+	err      error
+}
+
+func (f *fakeMLClient) PredictIndividual(ctx context.Context, req MLPredictRequest) (*MLPredictResponse, error) {
+	return f.response, f.err
+}
+
+func TestPredictHeightForAgeWithMLClient(t *testing.T) {
+	down := &fakeMLClient{err: pkg.NewServiceUnavailableError("ML API sedang tidak tersedia, coba lagi nanti")}
+	up := &fakeMLClient{response: &MLPredictResponse{
+		Zscore:            -1.5,
+		NutritionalStatus: "normal",
+		ModelName:         "stunting-xgb",
+		ModelVersion:      "2026.10.1",
+		Raw:               []byte(`{"zscore": -1.5}`),
+		Latency:           42 * time.Millisecond,
+	}}
+
+	t.Run("ml only passes the error on", func(t *testing.T) {
+		p := &predictService{ml: down, predictor: pkg.PredictorML}
+		if _, err := p.predictHeightForAge(context.Background(), 80, 24, "M"); statusCode(err) != http.StatusServiceUnavailable {
+			t.Errorf("error = %v, want a 503", err)
+		}
+	})
+
+	t.Run("both falls back to WHO", func(t *testing.T) {
+		p := &predictService{ml: down, predictor: pkg.PredictorBoth}
+		got, err := p.predictHeightForAge(context.Background(), 80, 24, "M")
+		if err != nil {
+			t.Fatalf("predictHeightForAge: %v", err)
+		}
+		if got.WhoZscore == nil || got.Zscore != *got.WhoZscore {
+			t.Errorf("outcome = %+v, want the WHO z-score", got)
+		}
+		if got.Source != pkg.PredictSourceWHO {
+			t.Errorf("source = %q, want %q", got.Source, pkg.PredictSourceWHO)
+		}
+	})
+
+	t.Run("both keeps the ML result and the WHO reference", func(t *testing.T) {
+		p := &predictService{ml: up, predictor: pkg.PredictorBoth}
+		got, err := p.predictHeightForAge(context.Background(), 80, 24, "M")
+		if err != nil {
+			t.Fatalf("predictHeightForAge: %v", err)
+		}
+		if got.Zscore != -1.5 || got.NutritionalStatus != "normal" || got.WhoZscore == nil {
+			t.Errorf("outcome = %+v", got)
+		}
+		if got.Source != pkg.PredictSourceML || got.ModelVersion == nil || *got.ModelVersion != "2026.10.1" ||
+			got.MLLatencyMs == nil || *got.MLLatencyMs != 42 || got.MLResponse == nil {
+			t.Errorf("provenance = %+v", got)
+		}
+	})
+}
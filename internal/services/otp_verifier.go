@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"grovia/internal/models"
+	"grovia/internal/repositories"
+	"grovia/pkg"
+	"math/big"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	otpLength         = 6
+	otpTTL            = 5 * time.Minute
+	otpResendCooldown = time.Minute
+	otpMaxAttempts    = 5
+)
+
+type otpVerifier struct {
+	repo   repositories.PhoneOTPRepository
+	sender SMSSender
+}
+
+// SendCode implements PhoneVerifier. A new code replaces any earlier one
+// for the same number and purpose.
+func (o *otpVerifier) SendCode(ctx context.Context, phoneNumber, purpose string) error {
+	latest, err := o.repo.GetLatestOTPByPhoneNumber(phoneNumber, purpose)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return pkg.NewInternalServerError("Gagal mengambil kode OTP")
+	}
+	if latest != nil && time.Since(latest.CreatedAt) < otpResendCooldown {
+		return pkg.NewTooManyRequestsError("Tunggu sebentar sebelum meminta kode baru")
+	}
+
+	code, err := generateOTP()
+	if err != nil {
+		return pkg.NewInternalServerError("Gagal membuat kode OTP")
+	}
+
+	hashed, err := pkg.HashPassword(code)
+	if err != nil {
+		return pkg.NewInternalServerError("Gagal membuat kode OTP")
+	}
+
+	_, err = o.repo.CreateOTP(&models.PhoneOTP{
+		PhoneNumber: phoneNumber,
+		Purpose:     purpose,
+		CodeHash:    hashed,
+		ExpiresAt:   time.Now().Add(otpTTL),
+	})
+	if err != nil {
+		return pkg.NewInternalServerError("Gagal menyimpan kode OTP")
+	}
+
+	message := fmt.Sprintf("Kode verifikasi Grovia: %s. Berlaku %d menit. Jangan berikan kode ini kepada siapa pun.", code, int(otpTTL.Minutes()))
+	if err := o.sender.Send(ctx, phoneNumber, message); err != nil {
+		return pkg.NewInternalServerError("Gagal mengirim kode OTP")
+	}
+
+	return nil
+}
+
+// Verify implements PhoneVerifier. Only a code sent for the same purpose
+// is considered. Every try counts as an attempt and is claimed before the
+// code is compared; a correct code can only be used once.
+func (o *otpVerifier) Verify(ctx context.Context, phoneNumber, purpose, proof string) error {
+	otp, err := o.repo.GetLatestOTPByPhoneNumber(phoneNumber, purpose)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewUnauthorizedError("Kode OTP tidak valid atau sudah kadaluarsa")
+		}
+		return pkg.NewInternalServerError("Gagal mengambil kode OTP")
+	}
+
+	if otp.Purpose != purpose || otp.ConsumedAt != nil || time.Now().After(otp.ExpiresAt) {
+		return pkg.NewUnauthorizedError("Kode OTP tidak valid atau sudah kadaluarsa")
+	}
+
+	if err := o.repo.ClaimOTPAttempt(otp.ID, otpMaxAttempts); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewTooManyRequestsError("Terlalu banyak percobaan, silakan minta kode baru")
+		}
+		return pkg.NewInternalServerError("Gagal memverifikasi kode OTP")
+	}
+
+	if !pkg.CheckPassword(proof, otp.CodeHash) {
+		return pkg.NewUnauthorizedError("Kode OTP salah")
+	}
+
+	if err := o.repo.ConsumeOTP(otp.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkg.NewUnauthorizedError("Kode OTP tidak valid atau sudah kadaluarsa")
+		}
+		return pkg.NewInternalServerError("Gagal memverifikasi kode OTP")
+	}
+
+	return nil
+}
+
+func generateOTP() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < otpLength; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", otpLength, n), nil
+}
+
+func NewOTPVerifier(repo repositories.PhoneOTPRepository, sender SMSSender) PhoneVerifier {
+	return &otpVerifier{repo: repo, sender: sender}
+}
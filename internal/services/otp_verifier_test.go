@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"grovia/internal/models"
+	"grovia/pkg"
+	"regexp"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type fakePhoneOTPRepository struct {
+	otps []*models.PhoneOTP
+}
+
+func (f *fakePhoneOTPRepository) CreateOTP(otp *models.PhoneOTP) (*models.PhoneOTP, error) {
+	now := time.Now()
+	for _, o := range f.otps {
+		if o.PhoneNumber == otp.PhoneNumber && o.Purpose == otp.Purpose && o.ConsumedAt == nil {
+			o.ConsumedAt = &now
+		}
+	}
+	otp.ID = len(f.otps) + 1
+	otp.CreatedAt = now
+	f.otps = append(f.otps, otp)
+	return otp, nil
+}
+
+func (f *fakePhoneOTPRepository) GetLatestOTPByPhoneNumber(phoneNumber, purpose string) (*models.PhoneOTP, error) {
+	for i := len(f.otps) - 1; i >= 0; i-- {
+		if f.otps[i].PhoneNumber == phoneNumber && f.otps[i].Purpose == purpose {
+			return f.otps[i], nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakePhoneOTPRepository) ClaimOTPAttempt(id, maxAttempts int) error {
+	if f.otps[id-1].Attempts >= maxAttempts {
+		return gorm.ErrRecordNotFound
+	}
+	f.otps[id-1].Attempts++
+	return nil
+}
+
+func (f *fakePhoneOTPRepository) ConsumeOTP(id int) error {
+	if f.otps[id-1].ConsumedAt != nil {
+		return gorm.ErrRecordNotFound
+	}
+	now := time.Now()
+	f.otps[id-1].ConsumedAt = &now
+	return nil
+}
+
+type recordingSender struct {
+	messages []string
+}
+
+func (r *recordingSender) Send(ctx context.Context, phoneNumber, message string) error {
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+var otpCodePattern = regexp.MustCompile(`\d{6}`)
+
+func (r *recordingSender) lastCode(t *testing.T) string {
+	t.Helper()
+	if len(r.messages) == 0 {
+		t.Fatal("no message sent")
+	}
+	return otpCodePattern.FindString(r.messages[len(r.messages)-1])
+}
+
+func statusCode(err error) int {
+	var custom *pkg.CustomError
+	if errors.As(err, &custom) {
+		return custom.StatusCode
+	}
+	return 0
+}
+
+func TestOTPVerifierRejectsCodeForOtherPurpose(t *testing.T) {
+	ctx := context.Background()
+	sender := &recordingSender{}
+	verifier := NewOTPVerifier(&fakePhoneOTPRepository{}, sender)
+
+	if err := verifier.SendCode(ctx, "081234567890", pkg.OTPPurposePasswordReset); err != nil {
+		t.Fatalf("SendCode: %v", err)
+	}
+	code := sender.lastCode(t)
+
+	err := verifier.Verify(ctx, "081234567890", pkg.OTPPurposeParentLogin, code)
+	if statusCode(err) != 401 {
+		t.Fatalf("parent login with a reset code: got %v, want 401", err)
+	}
+
+	if err := verifier.Verify(ctx, "081234567890", pkg.OTPPurposePasswordReset, code); err != nil {
+		t.Fatalf("password reset with its own code: %v", err)
+	}
+	if err := verifier.Verify(ctx, "081234567890", pkg.OTPPurposePasswordReset, code); statusCode(err) != 401 {
+		t.Fatalf("reusing a consumed code: got %v, want 401", err)
+	}
+}
+
+func TestOTPVerifierKeepsPurposesApart(t *testing.T) {
+	ctx := context.Background()
+	sender := &recordingSender{}
+	verifier := NewOTPVerifier(&fakePhoneOTPRepository{}, sender)
+
+	if err := verifier.SendCode(ctx, "081234567890", pkg.OTPPurposePasswordReset); err != nil {
+		t.Fatalf("SendCode reset: %v", err)
+	}
+	resetCode := sender.lastCode(t)
+
+	// A login code neither hits the reset cooldown nor replaces the reset code.
+	if err := verifier.SendCode(ctx, "081234567890", pkg.OTPPurposeParentLogin); err != nil {
+		t.Fatalf("SendCode login: %v", err)
+	}
+	loginCode := sender.lastCode(t)
+
+	if err := verifier.Verify(ctx, "081234567890", pkg.OTPPurposeParentLogin, loginCode); err != nil {
+		t.Fatalf("parent login: %v", err)
+	}
+	if err := verifier.Verify(ctx, "081234567890", pkg.OTPPurposePasswordReset, resetCode); err != nil {
+		t.Fatalf("password reset: %v", err)
+	}
+}
+
+func TestOTPVerifierStopsAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	sender := &recordingSender{}
+	verifier := NewOTPVerifier(&fakePhoneOTPRepository{}, sender)
+
+	if err := verifier.SendCode(ctx, "081234567890", pkg.OTPPurposeParentLogin); err != nil {
+		t.Fatalf("SendCode: %v", err)
+	}
+	code := sender.lastCode(t)
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+
+	for i := 0; i < otpMaxAttempts; i++ {
+		if err := verifier.Verify(ctx, "081234567890", pkg.OTPPurposeParentLogin, wrong); statusCode(err) != 401 {
+			t.Fatalf("wrong code %d: got %v, want 401", i+1, err)
+		}
+	}
+
+	// With no attempts left even the right code is refused.
+	if err := verifier.Verify(ctx, "081234567890", pkg.OTPPurposeParentLogin, code); statusCode(err) != 429 {
+		t.Fatalf("right code after %d wrong ones: got %v, want 429", otpMaxAttempts, err)
+	}
+}
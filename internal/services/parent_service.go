@@ -1,10 +1,12 @@
 package services
 
 import (
+	"context"
 	"grovia/internal/dto/requests"
 	"grovia/internal/dto/responses"
 	"grovia/internal/models"
 	"grovia/internal/repositories"
+	"grovia/internal/storage"
 	"grovia/pkg"
 	"math"
 	"strconv"
@@ -12,20 +14,22 @@ import (
 )
 
 type ParentService interface {
-	CreateParent(req requests.CreateParentRequest, userID int) (*responses.ParentResponse, error)
-	GetAllParent(locationID int, name, pageStr, limitStr string) ([]responses.ParentResponse, *responses.PaginationMeta, error)
-	GetParentByID(id, locationID int) (*responses.ParentResponse, error)
-	UpdateParentByID(id, locationID, userID int, req requests.UpdateParentRequest) (*responses.ParentResponse, error)
-	DeleteParentByID(id, locationID, userID int) error
+	CreateParent(ctx context.Context, req requests.CreateParentRequest, userID int) (*responses.ParentResponse, error)
+	GetAllParent(scope pkg.AccessScope, name, pageStr, limitStr string) ([]responses.ParentResponse, *responses.PaginationMeta, error)
+	GetParentByID(id int, scope pkg.AccessScope) (*responses.ParentResponse, error)
+	UpdateParentByID(ctx context.Context, id int, scope pkg.AccessScope, userID int, req requests.UpdateParentRequest) (*responses.ParentResponse, error)
+	DeleteParentByID(ctx context.Context, id int, scope pkg.AccessScope, userID int) error
 	CheckPhoneExists(phoneNumber string) (*models.Parent, error)
 	GetAllParentAllLocation(name, pageStr, limitStr string) ([]responses.ParentResponse, *responses.PaginationMeta, error)
 }
 
 type parentService struct {
-	repo repositories.ParentRepository
+	repo    repositories.ParentRepository
+	storage storage.ObjectStorage
+	audit   AuditService
 }
 
-func (p *parentService) CreateParent(req requests.CreateParentRequest, userID int) (*responses.ParentResponse, error) {
+func (p *parentService) CreateParent(ctx context.Context, req requests.CreateParentRequest, userID int) (*responses.ParentResponse, error) {
 	if err := pkg.ValidateStruct(req); err != nil {
 		return nil, pkg.NewBadRequestError(err.Error())
 	}
@@ -48,6 +52,8 @@ func (p *parentService) CreateParent(req requests.CreateParentRequest, userID in
 		return nil, pkg.NewInternalServerError("Gagal membuat parent")
 	}
 
+	p.audit.Record(ctx, pkg.AuditActionCreate, pkg.AuditEntityParent, parent.ID, nil, parent)
+
 	parentResp := responses.ParentResponse{
 		ID:          parent.ID,
 		LocationID:  parent.LocationID,
@@ -122,15 +128,22 @@ func (p *parentService) CheckPhoneExists(phoneNumber string) (*models.Parent, er
 	return parent, nil
 }
 
-func (p *parentService) DeleteParentByID(id int, locationID, userID int) error {
-	err := p.repo.DeleteParentByID(id, locationID, userID)
+func (p *parentService) DeleteParentByID(ctx context.Context, id int, scope pkg.AccessScope, userID int) error {
+	before, err := p.repo.GetParentByID(id, scope)
+	if err != nil {
+		return pkg.NewNotFoundError("Parent tidak ditemukan")
+	}
+
+	err = p.repo.DeleteParentByID(id, scope, userID)
 	if err != nil {
 		return pkg.NewInternalServerError("Gagal menghapus parent")
 	}
+
+	p.audit.Record(ctx, pkg.AuditActionDelete, pkg.AuditEntityParent, id, before, nil)
 	return nil
 }
 
-func (p *parentService) GetAllParent(locationID int, name, pageStr, limitStr string) ([]responses.ParentResponse, *responses.PaginationMeta, error) {
+func (p *parentService) GetAllParent(scope pkg.AccessScope, name, pageStr, limitStr string) ([]responses.ParentResponse, *responses.PaginationMeta, error) {
 	page, _ := strconv.Atoi(pageStr)
 	limit, _ := strconv.Atoi(limitStr)
 
@@ -144,7 +157,7 @@ func (p *parentService) GetAllParent(locationID int, name, pageStr, limitStr str
 
 	offset := (page - 1) * limit
 
-	parents, total, err := p.repo.GetAllParent(locationID, limit, offset, name)
+	parents, total, err := p.repo.GetAllParent(scope, limit, offset, name)
 
 	if err != nil {
 		return nil, nil, pkg.NewInternalServerError("Gagal mengambil data parent")
@@ -180,8 +193,8 @@ func (p *parentService) GetAllParent(locationID int, name, pageStr, limitStr str
 	return parentResponse, &meta, nil
 }
 
-func (p *parentService) GetParentByID(id int, locationID int) (*responses.ParentResponse, error) {
-	parent, err := p.repo.GetParentByID(id, locationID)
+func (p *parentService) GetParentByID(id int, scope pkg.AccessScope) (*responses.ParentResponse, error) {
+	parent, err := p.repo.GetParentByID(id, scope)
 
 	if err != nil {
 		return nil, pkg.NewNotFoundError("Parent tidak ditemukan")
@@ -189,21 +202,7 @@ func (p *parentService) GetParentByID(id int, locationID int) (*responses.Parent
 
 	var toddlerResponses []responses.ToddlerResponse
 	for _, t := range parent.Toddlers {
-		toddlerResponses = append(toddlerResponses, responses.ToddlerResponse{
-			ID:                t.ID,
-			ParentID:          t.ParentID,
-			LocationID:        t.LocationID,
-			CreatedByID:       t.CreatedByID,
-			UpdatedByID:       t.UpdatedByID,
-			Name:              t.Name,
-			Birthdate:         t.Birthdate,
-			Sex:               t.Sex,
-			Height:            t.Height,
-			ProfilePicture:    t.ProfilePicture,
-			NutritionalStatus: t.NutritionalStatus,
-			CreatedAt:         t.CreatedAt,
-			UpdatedAt:         t.UpdatedAt,
-		})
+		toddlerResponses = append(toddlerResponses, toToddlerResponse(p.storage, &t))
 	}
 
 	parentResponses := responses.ParentResponse{
@@ -224,7 +223,7 @@ func (p *parentService) GetParentByID(id int, locationID int) (*responses.Parent
 	return &parentResponses, nil
 }
 
-func (p *parentService) UpdateParentByID(id int, locationID, userID int, req requests.UpdateParentRequest) (*responses.ParentResponse, error) {
+func (p *parentService) UpdateParentByID(ctx context.Context, id int, scope pkg.AccessScope, userID int, req requests.UpdateParentRequest) (*responses.ParentResponse, error) {
 	if err := pkg.ValidateStruct(req); err != nil {
 		return nil, pkg.NewBadRequestError(err.Error())
 	}
@@ -262,11 +261,18 @@ func (p *parentService) UpdateParentByID(id int, locationID, userID int, req req
 		parentMapping.LocationID = *req.LocationID
 	}
 
-	parent, err := p.repo.UpdateParentByID(id, locationID, &parentMapping)
+	before, err := p.repo.GetParentByID(id, scope)
+	if err != nil {
+		return nil, pkg.NewNotFoundError("Parent tidak ditemukan")
+	}
+
+	parent, err := p.repo.UpdateParentByID(id, scope, &parentMapping)
 	if err != nil {
 		return nil, pkg.NewInternalServerError("Gagal update data parent")
 	}
 
+	p.audit.Record(ctx, pkg.AuditActionUpdate, pkg.AuditEntityParent, id, before, parent)
+
 	parentResponse := responses.ParentResponse{
 		ID:          parent.ID,
 		LocationID:  parent.LocationID,
@@ -284,6 +290,6 @@ func (p *parentService) UpdateParentByID(id int, locationID, userID int, req req
 	return &parentResponse, nil
 }
 
-func NewParentService(repo repositories.ParentRepository) ParentService {
-	return &parentService{repo: repo}
+func NewParentService(repo repositories.ParentRepository, store storage.ObjectStorage, audit AuditService) ParentService {
+	return &parentService{repo: repo, storage: store, audit: audit}
 }
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"grovia/internal/dto/responses"
+	"grovia/pkg"
+)
+
+type PermissionService interface {
+	GetPermissionMatrix() responses.PermissionMatrixResponse
+	GetRolePermissions(role string) (*responses.RolePermissionResponse, error)
+}
+
+type permissionService struct{}
+
+func (p *permissionService) GetPermissionMatrix() responses.PermissionMatrixResponse {
+	matrix := responses.PermissionMatrixResponse{
+		Permissions: pkg.Permissions,
+		Roles:       make([]responses.RolePermissionResponse, 0, len(pkg.Roles)),
+	}
+
+	for _, role := range pkg.Roles {
+		matrix.Roles = append(matrix.Roles, responses.RolePermissionResponse{
+			Role:        role,
+			Permissions: pkg.RolePermissions[role],
+		})
+	}
+
+	return matrix
+}
+
+func (p *permissionService) GetRolePermissions(role string) (*responses.RolePermissionResponse, error) {
+	permissions, ok := pkg.RolePermissions[role]
+	if !ok {
+		return nil, pkg.NewNotFoundError("Role tidak ditemukan")
+	}
+
+	return &responses.RolePermissionResponse{
+		Role:        role,
+		Permissions: permissions,
+	}, nil
+}
+
+func NewPermissionService() PermissionService {
+	return &permissionService{}
+}
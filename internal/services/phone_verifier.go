@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"grovia/configs"
+	"grovia/internal/firebase"
+	"grovia/internal/repositories"
+	"grovia/pkg"
+)
+
+// PhoneVerifier proves that the caller controls a phone number. SendCode
+// starts a verification and Verify checks the proof the caller got back:
+// an OTP code, or a Firebase ID token when Firebase sends the SMS itself.
+// The purpose (pkg.OTPPurposePasswordReset, pkg.OTPPurposeParentLogin)
+// must match between the two calls.
+type PhoneVerifier interface {
+	SendCode(ctx context.Context, phoneNumber, purpose string) error
+	Verify(ctx context.Context, phoneNumber, purpose, proof string) error
+}
+
+// NewPhoneVerifier builds the verifier selected in the config. There is no
+// default: a missing phone_verifier is an error, so a deployment never
+// falls back to OTP codes it cannot deliver.
+func NewPhoneVerifier(cfg configs.VerificationConfig, otpRepo repositories.PhoneOTPRepository) (PhoneVerifier, error) {
+	switch cfg.PhoneVerifier {
+	case pkg.PhoneVerifierFirebase:
+		if err := firebase.InitFirebase(cfg.FirebaseCredentials); err != nil {
+			return nil, fmt.Errorf("initializing firebase: %w", err)
+		}
+		return NewFirebaseVerifier(), nil
+	case pkg.PhoneVerifierOTP:
+		sender, err := NewSMSSender(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return NewOTPVerifier(otpRepo, sender), nil
+	case "":
+		return nil, errors.New("phone_verifier is not set (use firebase or otp)")
+	default:
+		return nil, fmt.Errorf("unknown phone verifier %q", cfg.PhoneVerifier)
+	}
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"grovia/configs"
+	"grovia/pkg"
+	"testing"
+)
+
+func TestNewPhoneVerifierRequiresExplicitDriver(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     configs.VerificationConfig
+		wantErr bool
+	}{
+		{"no verifier", configs.VerificationConfig{}, true},
+		{"otp without gateway", configs.VerificationConfig{PhoneVerifier: pkg.PhoneVerifierOTP}, true},
+		{"otp with unknown gateway", configs.VerificationConfig{PhoneVerifier: pkg.PhoneVerifierOTP, SMSGateway: "pigeon"}, true},
+		{"otp with http gateway without url", configs.VerificationConfig{PhoneVerifier: pkg.PhoneVerifierOTP, SMSGateway: pkg.SMSGatewayHTTP}, true},
+		{"unknown verifier", configs.VerificationConfig{PhoneVerifier: "email"}, true},
+		{"otp with console gateway", configs.VerificationConfig{PhoneVerifier: pkg.PhoneVerifierOTP, SMSGateway: pkg.SMSGatewayConsole}, false},
+		{"otp with http gateway", configs.VerificationConfig{PhoneVerifier: pkg.PhoneVerifierOTP, SMSGateway: pkg.SMSGatewayHTTP, SMSGatewayURL: "http://sms.local/send"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier, err := NewPhoneVerifier(tt.cfg, nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %T, want an error", verifier)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewPhoneVerifier: %v", err)
+			}
+		})
+	}
+}
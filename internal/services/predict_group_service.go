@@ -0,0 +1,425 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"grovia/internal/dto/responses"
+	"grovia/internal/growth"
+	"grovia/internal/jobs"
+	"grovia/internal/models"
+	"grovia/internal/spreadsheet"
+	"grovia/pkg"
+	"io"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	groupPredictFileName = "hasil_prediksi.xlsx"
+	groupPreviewTTL      = 30 * time.Minute
+
+	// WHO flags z-scores beyond ±6 as biologically implausible, which in
+	// practice means a typo in the height or the birthdate.
+	implausibleZscore = 6
+)
+
+// groupRowPlan is a validated spreadsheet row together with the parent
+// and, when already registered, the toddler it belongs to.
+type groupRowPlan struct {
+	row       spreadsheet.GroupRow
+	sex       growth.Sex
+	age       int
+	birthdate time.Time
+	parent    *models.Parent
+	toddler   *models.Toddler
+	errors    []string
+}
+
+func (p *predictService) PreviewGroupPredict(file io.Reader, fileName string, scope pkg.AccessScope) (*responses.GroupPredictPreviewResponse, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, pkg.NewBadRequestError("File tidak dapat dibaca")
+	}
+
+	sheet, err := spreadsheet.ReadGroupSheet(bytes.NewReader(data))
+	if err != nil {
+		return nil, pkg.NewBadRequestError("File tidak dapat dibaca: " + err.Error())
+	}
+	defer sheet.Close()
+
+	plans := p.planGroupRows(sheet.Rows, scope, time.Now())
+
+	preview, err := p.previewRepo.CreatePreview(&models.GroupPredictPreview{
+		Token:       uuid.New().String(),
+		LocationID:  scope.LocationID,
+		CreatedByID: scope.UserID,
+		FileName:    fileName,
+		File:        data,
+		ExpiresAt:   time.Now().Add(groupPreviewTTL),
+	})
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal menyimpan preview")
+	}
+
+	response := &responses.GroupPredictPreviewResponse{
+		PreviewToken: preview.Token,
+		ExpiresAt:    preview.ExpiresAt,
+		Rows:         []responses.GroupPredictPreviewRowResponse{},
+	}
+
+	for _, plan := range plans {
+		row := responses.GroupPredictPreviewRowResponse{
+			Row:               plan.row.Row,
+			Name:              plan.row.Name,
+			Sex:               plan.row.Sex,
+			Height:            plan.row.Height,
+			Weight:            plan.row.Weight,
+			HeadCircumference: plan.row.HeadCircumference,
+			PhoneNumber:       plan.row.PhoneNumber,
+			Errors:            plan.errors,
+		}
+		if plan.parent != nil {
+			row.ParentName = plan.parent.Name
+		}
+
+		response.Summary.Total++
+		if len(plan.errors) > 0 {
+			response.Summary.Invalid++
+			response.Rows = append(response.Rows, row)
+			continue
+		}
+
+		age := plan.age
+		row.Age = &age
+		response.Summary.Valid++
+		if plan.toddler != nil {
+			row.ToddlerID = &plan.toddler.ID
+			row.Action = responses.GroupRowActionMatch
+			response.Summary.Matched++
+		} else {
+			row.Action = responses.GroupRowActionCreate
+			response.Summary.NewToddlers++
+		}
+		response.Rows = append(response.Rows, row)
+	}
+
+	return response, nil
+}
+
+func (p *predictService) ConfirmGroupPredict(token string, scope pkg.AccessScope) (*responses.JobResponse, error) {
+	preview, err := p.previewRepo.GetPreviewByToken(token)
+	if err != nil {
+		return nil, pkg.NewNotFoundError("Preview tidak ditemukan")
+	}
+
+	if preview.CreatedByID != scope.UserID {
+		return nil, pkg.NewForbiddenError("Preview ini dibuat oleh pengguna lain")
+	}
+	if time.Now().After(preview.ExpiresAt) {
+		return nil, pkg.NewBadRequestError("Preview sudah kedaluwarsa, silakan unggah ulang file")
+	}
+
+	if err := p.previewRepo.ConfirmPreview(preview.ID); err != nil {
+		return nil, pkg.NewConflictError("Preview sudah dikonfirmasi")
+	}
+
+	return p.enqueueGroupPredict(preview.File, preview.FileName, scope)
+}
+
+// groupPredictJobPayload carries the uploader's access scope, so the
+// worker checks parent locations the same way the request would have.
+type groupPredictJobPayload struct {
+	Scope    pkg.AccessScope `json:"scope"`
+	FileName string          `json:"fileName"`
+}
+
+func (p *predictService) enqueueGroupPredict(data []byte, fileName string, scope pkg.AccessScope) (*responses.JobResponse, error) {
+	payload := groupPredictJobPayload{Scope: scope, FileName: fileName}
+	return p.jobService.Enqueue(pkg.JobGroupPredict, payload, data, scope.LocationID, scope.UserID)
+}
+
+func (p *predictService) RunGroupPredictJob(ctx context.Context, job *models.Job, progress jobs.Progress) (*jobs.Result, error) {
+	var payload groupPredictJobPayload
+	if job.Payload == nil || json.Unmarshal([]byte(*job.Payload), &payload) != nil {
+		return nil, errors.New("payload job tidak valid")
+	}
+
+	// Records written by the job are audited as the uploader's changes.
+	ctx = pkg.WithAccessScope(ctx, payload.Scope)
+
+	response, err := p.runGroupPredict(ctx, job.ID, bytes.NewReader(job.Input), payload.Scope, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	file := response.File
+	response.File = nil
+
+	return &jobs.Result{Data: response, File: file, FileName: response.FileName}, nil
+}
+
+func (p *predictService) runGroupPredict(ctx context.Context, jobID int, file io.Reader, scope pkg.AccessScope, progress jobs.Progress) (*responses.GroupPredictResponse, error) {
+	sheet, err := spreadsheet.ReadGroupSheet(file)
+	if err != nil {
+		return nil, pkg.NewBadRequestError("File tidak dapat dibaca: " + err.Error())
+	}
+	defer sheet.Close()
+
+	response := &responses.GroupPredictResponse{
+		Rows:     []responses.GroupPredictRowResponse{},
+		FileName: groupPredictFileName,
+	}
+
+	plans := p.planGroupRows(sheet.Rows, scope, time.Now())
+	progress(0, len(plans))
+
+	for i, plan := range plans {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result := p.predictGroupRow(ctx, jobID, plan, scope.UserID)
+		response.Rows = append(response.Rows, result)
+
+		response.Summary.Total++
+		switch result.Status {
+		case responses.GroupRowFailed:
+			response.Summary.Failed++
+			err = sheet.Annotate(plan.row.Row, nil, "", strings.Join(result.Errors, "; "))
+		case responses.GroupRowCreated:
+			response.Summary.Succeeded++
+			response.Summary.NewToddlers++
+			err = sheet.Annotate(plan.row.Row, &result.Predict.Zscore, result.Predict.NutritionalStatus, "Balita baru")
+		default:
+			response.Summary.Succeeded++
+			err = sheet.Annotate(plan.row.Row, &result.Predict.Zscore, result.Predict.NutritionalStatus, "")
+		}
+		if err != nil {
+			return nil, pkg.NewInternalServerError("Gagal menulis hasil prediksi ke file")
+		}
+
+		progress(i+1, len(plans))
+	}
+
+	response.File, err = sheet.Bytes()
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal membuat file hasil prediksi")
+	}
+
+	return response, nil
+}
+
+// planGroupRows validates every row against the data already stored:
+// plausible measurements, a known parent in the uploader's location and no
+// child listed twice in the same file.
+func (p *predictService) planGroupRows(rows []spreadsheet.GroupRow, scope pkg.AccessScope, now time.Time) []groupRowPlan {
+	plans := make([]groupRowPlan, 0, len(rows))
+	parents := map[string]*models.Parent{}
+	seen := map[string]int{}
+
+	for _, row := range rows {
+		plan := groupRowPlan{row: row, errors: append([]string{}, row.Errors...)}
+		p.checkGroupRow(&plan, scope, now, parents)
+
+		if row.Name != "" && row.PhoneNumber != "" {
+			key := row.PhoneNumber + "|" + strings.ToLower(strings.ReplaceAll(row.Name, " ", ""))
+			if first, ok := seen[key]; ok {
+				plan.errors = append(plan.errors, fmt.Sprintf("Balita yang sama sudah ada di baris %d", first))
+			} else {
+				seen[key] = row.Row
+			}
+		}
+
+		plans = append(plans, plan)
+	}
+
+	return plans
+}
+
+func (p *predictService) checkGroupRow(plan *groupRowPlan, scope pkg.AccessScope, now time.Time, parents map[string]*models.Parent) {
+	row := plan.row
+	fail := func(format string, args ...any) {
+		plan.errors = append(plan.errors, fmt.Sprintf(format, args...))
+	}
+
+	sexKnown := false
+	if row.Sex != "" {
+		sex, err := growth.ParseSex(row.Sex)
+		if err != nil {
+			fail("Jenis kelamin tidak dikenali: %s (gunakan laki-laki atau perempuan)", row.Sex)
+		} else {
+			plan.sex = sex
+			sexKnown = true
+		}
+	}
+
+	ageKnown := false
+	switch {
+	case row.Birthdate != nil:
+		plan.birthdate = *row.Birthdate
+		plan.age = ageInMonths(plan.birthdate, now)
+		if plan.birthdate.After(now) {
+			fail("Tanggal lahir tidak boleh di masa depan")
+			break
+		}
+		if row.AgeMonths != nil && math.Abs(float64(*row.AgeMonths-plan.age)) > 1 {
+			fail("Umur %d bulan tidak sesuai dengan tanggal lahir (%d bulan)", *row.AgeMonths, plan.age)
+			break
+		}
+		ageKnown = true
+	case row.AgeMonths != nil:
+		plan.age = *row.AgeMonths
+		plan.birthdate = now.AddDate(0, -plan.age, 0)
+		ageKnown = true
+	}
+	if ageKnown && (plan.age < 0 || plan.age > growth.MaxAgeMonths) {
+		fail("Umur harus dalam rentang 0 - %d bulan", growth.MaxAgeMonths)
+		ageKnown = false
+	}
+
+	if row.Height > 0 {
+		if row.Height < 30 || row.Height > 120 {
+			fail("Tinggi badan harus dalam rentang 30cm - 120cm")
+		} else if sexKnown && ageKnown {
+			if hfa, err := growth.HeightForAge(plan.sex, plan.age, row.Height); err == nil && math.Abs(hfa.Zscore) > implausibleZscore {
+				fail("Tinggi badan %.1f cm tidak wajar untuk umur %d bulan (z-score %.1f)", row.Height, plan.age, hfa.Zscore)
+			}
+		}
+	}
+	if row.Weight != nil && (*row.Weight < 1 || *row.Weight > 40) {
+		fail("Berat badan harus dalam rentang 1kg - 40kg")
+	}
+	if row.HeadCircumference != nil && (*row.HeadCircumference < 25 || *row.HeadCircumference > 60) {
+		fail("Lingkar kepala harus dalam rentang 25cm - 60cm")
+	}
+
+	if row.PhoneNumber == "" {
+		return
+	}
+
+	parent, cached := parents[row.PhoneNumber]
+	if !cached {
+		parent, _ = p.parentRepo.FindParentByPhoneNumber(row.PhoneNumber)
+		parents[row.PhoneNumber] = parent
+	}
+	if parent == nil {
+		fail("Orang tua dengan nomor HP %s tidak ditemukan", row.PhoneNumber)
+		return
+	}
+	if !scope.CanAccessLocation(parent.LocationID) {
+		fail("Orang tua dengan nomor HP %s terdaftar di lokasi lain", row.PhoneNumber)
+		return
+	}
+	plan.parent = parent
+
+	if row.Name == "" {
+		return
+	}
+
+	found, toddler, err := p.toddlerRepo.FindToddlerByName(parent.ID, row.Name)
+	if err != nil {
+		fail("Gagal mencari data balita")
+		return
+	}
+	if found {
+		plan.toddler = toddler
+	}
+}
+
+// predictGroupRow predicts one validated row and stores it under the
+// matched toddler, registering the toddler first when it is not known yet.
+// Problems are reported on the row instead of failing the whole upload.
+//
+// Each predict is keyed by its job and row, so a job that is requeued
+// after a restart reuses the rows it already stored instead of inserting
+// them again. A toddler registered by the interrupted run is matched by
+// name when the rows are planned again.
+func (p *predictService) predictGroupRow(ctx context.Context, jobID int, plan groupRowPlan, userID int) responses.GroupPredictRowResponse {
+	result := responses.GroupPredictRowResponse{Row: plan.row.Row, Name: plan.row.Name, Status: responses.GroupRowFailed}
+	if len(plan.errors) > 0 {
+		result.Errors = plan.errors
+		return result
+	}
+
+	if saved, err := p.repo.GetPredictByJobRow(jobID, plan.row.Row); err == nil {
+		predictResponse := toPredictResponse(saved)
+		result.Status = responses.GroupRowMatched
+		result.ToddlerID = &saved.ToddlerID
+		result.Predict = &predictResponse
+		return result
+	}
+
+	fail := func(message string) responses.GroupPredictRowResponse {
+		result.Status = responses.GroupRowFailed
+		result.Errors = append(result.Errors, message)
+		return result
+	}
+
+	row := plan.row
+	outcome, err := p.predictHeightForAge(ctx, row.Height, plan.age, string(plan.sex))
+	if err != nil {
+		return fail(err.Error())
+	}
+
+	toddler := plan.toddler
+	if toddler != nil {
+		result.Status = responses.GroupRowMatched
+	} else {
+		toddler, err = p.toddlerRepo.CreateToddler(&models.Toddler{
+			ParentID:          plan.parent.ID,
+			LocationID:        plan.parent.LocationID,
+			CreatedByID:       userID,
+			UpdatedByID:       userID,
+			Name:              row.Name,
+			Birthdate:         plan.birthdate,
+			Sex:               string(plan.sex),
+			Height:            row.Height,
+			Weight:            row.Weight,
+			HeadCircumference: row.HeadCircumference,
+			NutritionalStatus: outcome.NutritionalStatus,
+		})
+		if err != nil {
+			return fail("Gagal menyimpan data balita")
+		}
+		p.audit.Record(ctx, pkg.AuditActionCreate, pkg.AuditEntityToddler, toddler.ID, nil, toddler)
+		result.Status = responses.GroupRowCreated
+	}
+
+	predictModel := newPredict(row.Name, string(plan.sex), plan.age, row.Height, row.Weight, row.HeadCircumference, outcome, toddler.LocationID, userID)
+	predictModel.JobID = &jobID
+	predictModel.JobRow = &row.Row
+
+	saved, err := p.repo.CreateIndividualPredict(predictModel, toddler.LocationID, toddler.ID)
+	if err != nil {
+		return fail("Gagal menyimpan prediksi")
+	}
+	p.audit.Record(ctx, pkg.AuditActionCreate, pkg.AuditEntityPredict, saved.ID, nil, saved)
+	p.submitShadow(saved)
+
+	if plan.toddler != nil {
+		updated, err := p.toddlerRepo.UpdateToddlerByID(toddler.ID, pkg.LocationScope(toddler.LocationID), &models.Toddler{
+			UpdatedByID:       userID,
+			Height:            row.Height,
+			Weight:            row.Weight,
+			HeadCircumference: row.HeadCircumference,
+			NutritionalStatus: outcome.NutritionalStatus,
+		})
+		if err != nil {
+			log.Printf("group predict: failed to update toddler %d: %v", toddler.ID, err)
+		} else {
+			p.audit.Record(ctx, pkg.AuditActionUpdate, pkg.AuditEntityToddler, toddler.ID, plan.toddler, updated)
+		}
+	}
+
+	predictResponse := toPredictResponse(saved)
+	result.ToddlerID = &toddler.ID
+	result.Predict = &predictResponse
+	return result
+}
@@ -1,40 +1,158 @@
 package services
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"grovia/internal/dto/requests"
 	"grovia/internal/dto/responses"
+	"grovia/internal/growth"
+	"grovia/internal/jobs"
 	"grovia/internal/models"
 	"grovia/internal/repositories"
 	"grovia/pkg"
 	"io"
+	"log"
 	"math"
-	"mime/multipart"
-	"net/http"
-	"os"
-	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type PredictService interface {
-	CreateIndividualPredict(req requests.CreateToddlerRequest, locationID, toddlerID, userID int) (*responses.PredictResponse, error)
-	CreateGroupPredict(filePath string) ([]byte, error)
-	GetAllPredict(locationID int, pageStr, limitStr string) ([]responses.PredictResponse, *responses.PaginationMeta, error)
-	GetAllPredictByToddlerID(locationID, toddlerID int) ([]responses.PredictResponse, error)
-	GetPredictByID(id int) (*responses.PredictResponse, error)
-	UpdatePredictByID(id int, req *requests.UpdatePredictRequest) (*responses.PredictResponse, error)
-	DeletePredictByID(id, locationID, userID int) error
-	GetAllPredictAllLocation(pageStr, limitStr string) ([]responses.PredictResponse, *responses.PaginationMeta, error)
+	CreateIndividualPredict(ctx context.Context, req requests.CreateToddlerRequest, locationID, toddlerID, userID int) (*responses.PredictResponse, error)
+	PreviewGroupPredict(file io.Reader, fileName string, scope pkg.AccessScope) (*responses.GroupPredictPreviewResponse, error)
+	ConfirmGroupPredict(token string, scope pkg.AccessScope) (*responses.JobResponse, error)
+	RunGroupPredictJob(ctx context.Context, job *models.Job, progress jobs.Progress) (*jobs.Result, error)
+	GetAllPredict(scope pkg.AccessScope, query requests.PredictQuery, pageStr, limitStr string) ([]responses.PredictResponse, *responses.PaginationMeta, error)
+	GetAllPredictByToddlerID(scope pkg.AccessScope, toddlerID int) ([]responses.PredictResponse, error)
+	GetPredictByID(id int, scope pkg.AccessScope) (*responses.PredictResponse, error)
+	UpdatePredictByID(ctx context.Context, id int, scope pkg.AccessScope, userID int, req *requests.UpdatePredictRequest) (*responses.PredictResponse, error)
+	GetPredictRevisions(id int, scope pkg.AccessScope) ([]responses.PredictRevisionResponse, error)
+	DeletePredictByID(ctx context.Context, id int, scope pkg.AccessScope, userID int) error
+	GetAllPredictAllLocation(query requests.PredictQuery, pageStr, limitStr string) ([]responses.PredictResponse, *responses.PaginationMeta, error)
 }
 
 type predictService struct {
-	repo     repositories.PredictRepository
-	mlAPIURL string
+	repo        repositories.PredictRepository
+	toddlerRepo repositories.ToddlerRepository
+	parentRepo  repositories.ParentRepository
+	previewRepo repositories.GroupPredictPreviewRepository
+	jobService  JobService
+	audit       AuditService
+	ml          MLClient
+	predictor   string
+	// shadow, when set, evaluates every saved predict with a candidate
+	// model in the background.
+	shadow ShadowEvaluator
+}
+
+// heightForAgeOrder lists the height-for-age statuses from the worst up.
+var heightForAgeOrder = []string{growth.StatusSeverelyStunted, growth.StatusStunted, growth.StatusNormal, growth.StatusTall}
+
+type heightForAgeOutcome struct {
+	Zscore               float64
+	NutritionalStatus    string
+	WhoZscore            *float64
+	WhoNutritionalStatus *string
+	Source               string
+	ModelName            *string
+	ModelVersion         *string
+	MLResponse           *string
+	MLLatencyMs          *int
+}
+
+// predictHeightForAge determines the height-for-age status with the
+// configured predictor. In "both" mode the WHO result is kept as a
+// reference value and used instead of the ML API when it is unreachable.
+func (p *predictService) predictHeightForAge(ctx context.Context, height float64, age int, sex string) (*heightForAgeOutcome, error) {
+	var who *growth.Result
+	var whoErr error
+
+	if p.predictor == pkg.PredictorWHO || p.predictor == pkg.PredictorBoth {
+		who, whoErr = p.predictWithWHO(height, age, sex)
+	}
+
+	if p.predictor == pkg.PredictorWHO {
+		if whoErr != nil {
+			return nil, pkg.NewBadRequestError("Gagal menghitung z-score WHO: " + whoErr.Error())
+		}
+		return &heightForAgeOutcome{
+			Zscore:               who.Zscore,
+			NutritionalStatus:    who.Status,
+			WhoZscore:            &who.Zscore,
+			WhoNutritionalStatus: &who.Status,
+			Source:               pkg.PredictSourceWHO,
+		}, nil
+	}
+
+	outcome, mlErr := p.predictWithML(ctx, height, age, sex)
+	if mlErr != nil {
+		if p.predictor != pkg.PredictorBoth || whoErr != nil {
+			return nil, mlErr
+		}
+		log.Printf("ML API unavailable, using WHO growth standard: %v", mlErr)
+		outcome = &heightForAgeOutcome{Zscore: who.Zscore, NutritionalStatus: who.Status, Source: pkg.PredictSourceWHO}
+	}
+
+	if who != nil {
+		outcome.WhoZscore = &who.Zscore
+		outcome.WhoNutritionalStatus = &who.Status
+	}
+
+	return outcome, nil
+}
+
+func (p *predictService) predictWithWHO(height float64, age int, sex string) (*growth.Result, error) {
+	parsedSex, err := growth.ParseSex(sex)
+	if err != nil {
+		return nil, err
+	}
+	return growth.HeightForAge(parsedSex, age, height)
 }
 
-func (p *predictService) GetAllPredictAllLocation(pageStr, limitStr string) ([]responses.PredictResponse, *responses.PaginationMeta, error) {
+func (p *predictService) predictWithML(ctx context.Context, height float64, age int, sex string) (*heightForAgeOutcome, error) {
+	result, err := p.ml.PredictIndividual(ctx, MLPredictRequest{Height: height, Age: age, Gender: sex})
+	if err != nil {
+		return nil, err
+	}
+
+	latency := int(result.Latency.Milliseconds())
+	outcome := &heightForAgeOutcome{
+		Zscore:            result.Zscore,
+		NutritionalStatus: result.NutritionalStatus,
+		Source:            pkg.PredictSourceML,
+		MLLatencyMs:       &latency,
+	}
+	if result.ModelName != "" {
+		outcome.ModelName = &result.ModelName
+	}
+	if result.ModelVersion != "" {
+		outcome.ModelVersion = &result.ModelVersion
+	}
+	if len(result.Raw) > 0 {
+		raw := string(result.Raw)
+		outcome.MLResponse = &raw
+	}
+
+	return outcome, nil
+}
+
+func (p *predictService) submitShadow(predict *models.Predict) {
+	if p.shadow != nil {
+		p.shadow.Submit(predict)
+	}
+}
+
+func (p *predictService) GetAllPredictAllLocation(query requests.PredictQuery, pageStr, limitStr string) ([]responses.PredictResponse, *responses.PaginationMeta, error) {
+	if err := pkg.ValidateStruct(query); err != nil {
+		return nil, nil, pkg.NewBadRequestError(err.Error())
+	}
+
 	page, _ := strconv.Atoi(pageStr)
 	limit, _ := strconv.Atoi(limitStr)
 
@@ -48,7 +166,7 @@ func (p *predictService) GetAllPredictAllLocation(pageStr, limitStr string) ([]r
 
 	offset := (page - 1) * limit
 
-	predicts, total, err := p.repo.GetAllPredictAllLocation(limit, offset)
+	predicts, total, err := p.repo.GetAllPredictAllLocation(toPredictFilter(query), limit, offset)
 
 	if err != nil {
 		return nil, nil, pkg.NewInternalServerError("Gagal mengambil data prediksi")
@@ -58,19 +176,7 @@ func (p *predictService) GetAllPredictAllLocation(pageStr, limitStr string) ([]r
 
 	var predictResponses []responses.PredictResponse
 	for _, v := range predicts {
-		predictResponses = append(predictResponses, responses.PredictResponse{
-			ID:                v.ID,
-			ToddlerID:         v.ToddlerID,
-			CreatedByID:       v.CreatedByID,
-			Name:              v.Name,
-			Height:            v.Height,
-			Age:               v.Age,
-			Sex:               v.Sex,
-			Zscore:            v.Zscore,
-			NutritionalStatus: v.NutritionalStatus,
-			CreatedAt:         v.CreatedAt,
-			UpdatedAt:         v.UpdatedAt,
-		})
+		predictResponses = append(predictResponses, toPredictResponse(&v))
 	}
 
 	meta := responses.PaginationMeta{
@@ -83,123 +189,59 @@ func (p *predictService) GetAllPredictAllLocation(pageStr, limitStr string) ([]r
 	return predictResponses, &meta, nil
 }
 
-func (p *predictService) CreateGroupPredict(filePath string) ([]byte, error) {
-	payload := &bytes.Buffer{}
-	writer := multipart.NewWriter(payload)
-
-	fileWriter, err := writer.CreateFormFile("file", filepath.Base(filePath))
-	if err != nil {
-		return nil, pkg.NewInternalServerError("Gagal membuat form file")
+func (p *predictService) CreateIndividualPredict(ctx context.Context, req requests.CreateToddlerRequest, locationID, toddlerID, userID int) (*responses.PredictResponse, error) {
+	if err := pkg.ValidateStruct(req); err != nil {
+		return nil, pkg.NewBadRequestError(err.Error())
 	}
+	age := ageInMonths(req.Birthdate, time.Now())
 
-	file, err := os.Open(filePath)
+	outcome, err := p.predictHeightForAge(ctx, req.Height, age, req.Sex)
 	if err != nil {
-		return nil, pkg.NewInternalServerError("Gagal membuka file")
+		return nil, err
 	}
-	defer file.Close()
 
-	_, err = io.Copy(fileWriter, file)
-	if err != nil {
-		return nil, pkg.NewInternalServerError("Gagal menyalin file")
+	var weight, headCircumference *float64
+	if req.Weight > 0 {
+		weight = &req.Weight
 	}
-	writer.Close()
-
-	req, err := http.NewRequest("POST", p.mlAPIURL+"/predict-group", payload)
-	if err != nil {
-		return nil, pkg.NewInternalServerError("Gagal membuat request")
+	if req.HeadCircumference > 0 {
+		headCircumference = &req.HeadCircumference
 	}
-	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, pkg.NewInternalServerError("Gagal menghubungi ML API")
-	}
-	defer resp.Body.Close()
+	predictModel := newPredict(req.Name, req.Sex, age, req.Height, weight, headCircumference, outcome, locationID, userID)
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, pkg.NewInternalServerError("ML API error: " + resp.Status)
-	}
-
-	data, err := io.ReadAll(resp.Body)
+	saved, err := p.repo.CreateIndividualPredict(predictModel, locationID, toddlerID)
 	if err != nil {
-		return nil, pkg.NewInternalServerError("Gagal membaca response")
-	}
-
-	return data, nil
-}
-
-func (p *predictService) CreateIndividualPredict(req requests.CreateToddlerRequest, locationID, toddlerID, userID int) (*responses.PredictResponse, error) {
-	if err := pkg.ValidateStruct(req); err != nil {
-		return nil, pkg.NewBadRequestError(err.Error())
+		return nil, pkg.NewInternalServerError("Gagal menyimpan prediksi")
 	}
-	today := time.Now()
 
-	age := (today.Year()-req.Birthdate.Year())*12 + int(today.Month()) - int(req.Birthdate.Month())
+	p.audit.Record(ctx, pkg.AuditActionCreate, pkg.AuditEntityPredict, saved.ID, nil, saved)
+	p.submitShadow(saved)
 
-	if today.Day() < req.Birthdate.Day() {
-		age--
-	}
-	payload, _ := json.Marshal(map[string]any{
-		"height": req.Height,
-		"age":    age,
-		"gender": req.Sex,
-	})
+	response := toPredictResponse(saved)
+	return &response, nil
+}
 
-	resp, err := http.Post(p.mlAPIURL+"/predict-individual", "application/json", bytes.NewBuffer(payload))
+func (p *predictService) DeletePredictByID(ctx context.Context, id int, scope pkg.AccessScope, userID int) error {
+	before, err := p.repo.GetPredictByID(id, scope)
 	if err != nil {
-		return nil, pkg.NewInternalServerError("Gagal menghubungi ML API")
-	}
-	defer resp.Body.Close()
-
-	var mlResult map[string]any
-	if err := json.NewDecoder(resp.Body).Decode(&mlResult); err != nil {
-		return nil, pkg.NewInternalServerError("Gagal decode response ML API")
-	}
-
-	predictModel := &models.Predict{
-		CreatedByID:       userID,
-		DeletedByID:       nil,
-		Name:              req.Name,
-		Height:            req.Height,
-		Age:               age,
-		Sex:               req.Sex,
-		Zscore:            mlResult["zscore"].(float64),
-		NutritionalStatus: mlResult["nutritionalStatus"].(string),
-		LocationID:        locationID,
-		CreatedAt:         time.Now(),
-		UpdatedAt:         time.Now(),
+		return pkg.NewNotFoundError("Prediksi tidak ditemukan")
 	}
 
-	saved, err := p.repo.CreateIndividualPredict(predictModel, locationID, toddlerID)
+	err = p.repo.DeletePredictByID(id, scope, userID)
 	if err != nil {
-		return nil, pkg.NewInternalServerError("Gagal menyimpan prediksi")
+		return pkg.NewInternalServerError("Gagal menghapus prediksi")
 	}
 
-	return &responses.PredictResponse{
-		ID:                saved.ID,
-		ToddlerID:         saved.ToddlerID,
-		CreatedByID:       saved.CreatedByID,
-		Name:              saved.Name,
-		Height:            saved.Height,
-		Age:               saved.Age,
-		Sex:               saved.Sex,
-		Zscore:            saved.Zscore,
-		NutritionalStatus: saved.NutritionalStatus,
-		CreatedAt:         saved.CreatedAt,
-		UpdatedAt:         saved.UpdatedAt,
-	}, nil
+	p.audit.Record(ctx, pkg.AuditActionDelete, pkg.AuditEntityPredict, id, before, nil)
+	return nil
 }
 
-func (p *predictService) DeletePredictByID(id int, locationID, userID int) error {
-	err := p.repo.DeletePredictByID(id, locationID, userID)
-	if err != nil {
-		return pkg.NewInternalServerError("Gagal menghapus prediksi")
+func (p *predictService) GetAllPredict(scope pkg.AccessScope, query requests.PredictQuery, pageStr, limitStr string) ([]responses.PredictResponse, *responses.PaginationMeta, error) {
+	if err := pkg.ValidateStruct(query); err != nil {
+		return nil, nil, pkg.NewBadRequestError(err.Error())
 	}
-	return nil
-}
 
-func (p *predictService) GetAllPredict(locationID int, pageStr, limitStr string) ([]responses.PredictResponse, *responses.PaginationMeta, error) {
 	page, _ := strconv.Atoi(pageStr)
 	limit, _ := strconv.Atoi(limitStr)
 
@@ -213,7 +255,7 @@ func (p *predictService) GetAllPredict(locationID int, pageStr, limitStr string)
 
 	offset := (page - 1) * limit
 
-	predicts, total, err := p.repo.GetAllPredict(locationID, limit, offset)
+	predicts, total, err := p.repo.GetAllPredict(scope, toPredictFilter(query), limit, offset)
 
 	if err != nil {
 		return nil, nil, pkg.NewInternalServerError("Gagal mengambil data prediksi")
@@ -223,19 +265,7 @@ func (p *predictService) GetAllPredict(locationID int, pageStr, limitStr string)
 
 	var responsesList []responses.PredictResponse
 	for _, pred := range predicts {
-		responsesList = append(responsesList, responses.PredictResponse{
-			ID:                pred.ID,
-			ToddlerID:         pred.ToddlerID,
-			CreatedByID:       pred.CreatedByID,
-			Name:              pred.Name,
-			Height:            pred.Height,
-			Age:               pred.Age,
-			Sex:               pred.Sex,
-			Zscore:            pred.Zscore,
-			NutritionalStatus: pred.NutritionalStatus,
-			CreatedAt:         pred.CreatedAt,
-			UpdatedAt:         pred.UpdatedAt,
-		})
+		responsesList = append(responsesList, toPredictResponse(&pred))
 	}
 
 	meta := responses.PaginationMeta{
@@ -248,85 +278,295 @@ func (p *predictService) GetAllPredict(locationID int, pageStr, limitStr string)
 	return responsesList, &meta, nil
 }
 
-func (p *predictService) GetAllPredictByToddlerID(locationID int, toddlerID int) ([]responses.PredictResponse, error) {
-	predicts, err := p.repo.GetAllPredictByToddlerID(locationID, toddlerID)
+func (p *predictService) GetAllPredictByToddlerID(scope pkg.AccessScope, toddlerID int) ([]responses.PredictResponse, error) {
+	predicts, err := p.repo.GetAllPredictByToddlerID(scope, toddlerID)
 	if err != nil {
 		return nil, pkg.NewInternalServerError("Gagal mengambil data prediksi")
 	}
 
 	var responsesList []responses.PredictResponse
 	for _, pred := range predicts {
-		responsesList = append(responsesList, responses.PredictResponse{
-			ID:                pred.ID,
-			ToddlerID:         pred.ToddlerID,
-			CreatedByID:       pred.CreatedByID,
-			Name:              pred.Name,
-			Height:            pred.Height,
-			Age:               pred.Age,
-			Sex:               pred.Sex,
-			Zscore:            pred.Zscore,
-			NutritionalStatus: pred.NutritionalStatus,
-			CreatedAt:         pred.CreatedAt,
-			UpdatedAt:         pred.UpdatedAt,
-		})
+		responsesList = append(responsesList, toPredictResponse(&pred))
 	}
 
 	return responsesList, nil
 }
 
-func (p *predictService) GetPredictByID(id int) (*responses.PredictResponse, error) {
-	predict, err := p.repo.GetPredictByID(id)
+func (p *predictService) GetPredictByID(id int, scope pkg.AccessScope) (*responses.PredictResponse, error) {
+	predict, err := p.repo.GetPredictByID(id, scope)
 	if err != nil {
 		return nil, pkg.NewNotFoundError("Prediksi tidak ditemukan")
 	}
 
-	return &responses.PredictResponse{
-		ID:                predict.ID,
-		ToddlerID:         predict.ToddlerID,
-		CreatedByID:       predict.CreatedByID,
-		Name:              predict.Name,
-		Height:            predict.Height,
-		Age:               predict.Age,
-		Sex:               predict.Sex,
-		Zscore:            predict.Zscore,
-		NutritionalStatus: predict.NutritionalStatus,
-		CreatedAt:         predict.CreatedAt,
-		UpdatedAt:         predict.UpdatedAt,
-	}, nil
+	response := toPredictResponse(predict)
+	return &response, nil
 }
 
-func (p *predictService) UpdatePredictByID(id int, req *requests.UpdatePredictRequest) (*responses.PredictResponse, error) {
-	predictModel := &models.Predict{
-		ID:                id,
-		DeletedByID:       nil,
-		Height:            *req.Height,
-		Age:               *req.Age,
-		Sex:               *req.Sex,
-		Zscore:            *req.Zscore,
-		NutritionalStatus: *req.NutritionalStatus,
-		UpdatedAt:         time.Now(),
+// UpdatePredictByID corrects a predict. A changed measurement is merged
+// with the recorded ones and the status predicted again; a status or
+// z-score from the request then overrides the result by hand, which needs
+// a reason. The replaced values are kept as a revision.
+func (p *predictService) UpdatePredictByID(ctx context.Context, id int, scope pkg.AccessScope, userID int, req *requests.UpdatePredictRequest) (*responses.PredictResponse, error) {
+	if err := pkg.ValidateStruct(req); err != nil {
+		return nil, pkg.NewBadRequestError(err.Error())
+	}
+
+	measurementChanged := req.Height != nil || req.Weight != nil || req.HeadCircumference != nil || req.Age != nil || req.Sex != nil
+	statusOverridden := req.Zscore != nil || req.NutritionalStatus != nil
+	if !measurementChanged && !statusOverridden {
+		return nil, pkg.NewBadRequestError("Tidak ada data prediksi yang diubah")
+	}
+
+	var reason *string
+	if req.Reason != nil && strings.TrimSpace(*req.Reason) != "" {
+		trimmed := strings.TrimSpace(*req.Reason)
+		reason = &trimmed
+	}
+	if statusOverridden && reason == nil {
+		return nil, pkg.NewBadRequestError("Alasan wajib diisi untuk mengubah status gizi secara manual")
+	}
+	if req.NutritionalStatus != nil && !slices.Contains(heightForAgeOrder, *req.NutritionalStatus) {
+		return nil, pkg.NewBadRequestError("Status gizi harus salah satu dari: " + strings.Join(heightForAgeOrder, ", "))
+	}
+
+	before, err := p.repo.GetPredictByID(id, scope)
+	if err != nil {
+		return nil, pkg.NewNotFoundError("Prediksi tidak ditemukan")
+	}
+
+	corrected := *before
+	if measurementChanged {
+		height, weight, headCircumference, age, sex := before.Height, before.Weight, before.HeadCircumference, before.Age, before.Sex
+		if req.Height != nil {
+			height = *req.Height
+		}
+		if req.Weight != nil {
+			weight = req.Weight
+		}
+		if req.HeadCircumference != nil {
+			headCircumference = req.HeadCircumference
+		}
+		if req.Age != nil {
+			age = *req.Age
+		}
+		if req.Sex != nil {
+			sex = *req.Sex
+		}
+
+		outcome, err := p.predictHeightForAge(ctx, height, age, sex)
+		if err != nil {
+			return nil, err
+		}
+		corrected = *newPredict(before.Name, sex, age, height, weight, headCircumference, outcome, before.LocationID, before.CreatedByID)
 	}
 
-	updated, err := p.repo.UpdatePredictByID(id, predictModel)
+	if statusOverridden {
+		corrected.Source = pkg.PredictSourceManual
+		if req.Zscore != nil {
+			corrected.Zscore = *req.Zscore
+		}
+		if req.NutritionalStatus != nil {
+			corrected.NutritionalStatus = *req.NutritionalStatus
+		}
+	}
+	corrected.UpdatedAt = time.Now()
+
+	revision := &models.PredictRevision{
+		EditedByID:         userID,
+		MeasurementChanged: measurementChanged,
+		StatusOverridden:   statusOverridden,
+		Reason:             reason,
+	}
+
+	updated, err := p.repo.UpdatePredictByID(id, scope, &corrected, revision)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, pkg.NewNotFoundError("Prediksi tidak ditemukan")
+	}
 	if err != nil {
 		return nil, pkg.NewInternalServerError("Gagal update prediksi")
 	}
 
-	return &responses.PredictResponse{
-		ID:                updated.ID,
-		ToddlerID:         updated.ToddlerID,
-		CreatedByID:       updated.CreatedByID,
-		Name:              updated.Name,
-		Height:            updated.Height,
-		Age:               updated.Age,
-		Sex:               updated.Sex,
-		Zscore:            updated.Zscore,
-		NutritionalStatus: updated.NutritionalStatus,
-		CreatedAt:         updated.CreatedAt,
-		UpdatedAt:         updated.UpdatedAt,
-	}, nil
+	p.audit.Record(ctx, pkg.AuditActionUpdate, pkg.AuditEntityPredict, id, before, updated)
+	if measurementChanged && !statusOverridden {
+		p.submitShadow(updated)
+	}
+
+	response := toPredictResponse(updated)
+	return &response, nil
 }
 
-func NewPredictService(repo repositories.PredictRepository, mlAPIURL string) PredictService {
-	return &predictService{repo: repo, mlAPIURL: mlAPIURL}
-}
\ No newline at end of file
+func (p *predictService) GetPredictRevisions(id int, scope pkg.AccessScope) ([]responses.PredictRevisionResponse, error) {
+	if _, err := p.repo.GetPredictByID(id, scope); err != nil {
+		return nil, pkg.NewNotFoundError("Prediksi tidak ditemukan")
+	}
+
+	revisions, err := p.repo.GetPredictRevisions(id)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal mengambil riwayat koreksi prediksi")
+	}
+
+	result := []responses.PredictRevisionResponse{}
+	for _, r := range revisions {
+		result = append(result, responses.PredictRevisionResponse{
+			ID:                 r.ID,
+			PredictID:          r.PredictID,
+			EditedByID:         r.EditedByID,
+			MeasurementChanged: r.MeasurementChanged,
+			StatusOverridden:   r.StatusOverridden,
+			Reason:             r.Reason,
+			Height:             r.Height,
+			Weight:             r.Weight,
+			HeadCircumference:  r.HeadCircumference,
+			Age:                r.Age,
+			Sex:                r.Sex,
+			Zscore:             r.Zscore,
+			NutritionalStatus:  r.NutritionalStatus,
+			Source:             r.Source,
+			ModelName:          r.ModelName,
+			ModelVersion:       r.ModelVersion,
+			CreatedAt:          r.CreatedAt,
+		})
+	}
+
+	return result, nil
+}
+
+// newPredict builds the predict record for one measurement, including the
+// WHO weight and head circumference indicators when the sex is known.
+func newPredict(name, sex string, age int, height float64, weight, headCircumference *float64, outcome *heightForAgeOutcome, locationID, userID int) *models.Predict {
+	predict := &models.Predict{
+		CreatedByID:          userID,
+		DeletedByID:          nil,
+		Name:                 name,
+		Height:               height,
+		Weight:               weight,
+		HeadCircumference:    headCircumference,
+		Age:                  age,
+		Sex:                  sex,
+		Zscore:               outcome.Zscore,
+		NutritionalStatus:    outcome.NutritionalStatus,
+		WhoZscore:            outcome.WhoZscore,
+		WhoNutritionalStatus: outcome.WhoNutritionalStatus,
+		Source:               outcome.Source,
+		ModelName:            outcome.ModelName,
+		ModelVersion:         outcome.ModelVersion,
+		MLResponse:           outcome.MLResponse,
+		MLLatencyMs:          outcome.MLLatencyMs,
+		LocationID:           locationID,
+		CreatedAt:            time.Now(),
+		UpdatedAt:            time.Now(),
+	}
+
+	if parsedSex, err := growth.ParseSex(sex); err == nil {
+		m := growth.Measurement{Sex: parsedSex, AgeMonths: age, HeightCm: height}
+		if weight != nil {
+			m.WeightKg = *weight
+		}
+		if headCircumference != nil {
+			m.HeadCircumferenceCm = *headCircumference
+		}
+		applyAssessment(predict, growth.Assess(m))
+	}
+
+	return predict
+}
+
+// ageInMonths returns the completed months between birthdate and today.
+func ageInMonths(birthdate, today time.Time) int {
+	age := (today.Year()-birthdate.Year())*12 + int(today.Month()) - int(birthdate.Month())
+	if today.Day() < birthdate.Day() {
+		age--
+	}
+	return age
+}
+
+// applyAssessment stores the weight and head circumference indicators of
+// the WHO assessment on the predict. Height-for-age is left to the
+// configured predictor.
+func applyAssessment(predict *models.Predict, a growth.Assessment) {
+	if a.WeightForAge != nil {
+		predict.WfaZscore = &a.WeightForAge.Zscore
+		predict.WfaStatus = &a.WeightForAge.Status
+	}
+	if a.WeightForHeight != nil {
+		predict.WfhZscore = &a.WeightForHeight.Zscore
+		predict.WfhStatus = &a.WeightForHeight.Status
+	}
+	if a.BMIForAge != nil {
+		predict.BfaZscore = &a.BMIForAge.Zscore
+		predict.BfaStatus = &a.BMIForAge.Status
+	}
+	if a.HeadCircumferenceForAge != nil {
+		predict.HcfaZscore = &a.HeadCircumferenceForAge.Zscore
+		predict.HcfaStatus = &a.HeadCircumferenceForAge.Status
+	}
+}
+
+func toPredictFilter(query requests.PredictQuery) repositories.PredictFilter {
+	return repositories.PredictFilter{
+		Source:       query.Source,
+		ModelName:    query.ModelName,
+		ModelVersion: query.ModelVersion,
+	}
+}
+
+func toPredictResponse(predict *models.Predict) responses.PredictResponse {
+	var mlResponse json.RawMessage
+	if predict.MLResponse != nil {
+		mlResponse = json.RawMessage(*predict.MLResponse)
+	}
+
+	return responses.PredictResponse{
+		ID:                   predict.ID,
+		ToddlerID:            predict.ToddlerID,
+		CreatedByID:          predict.CreatedByID,
+		Name:                 predict.Name,
+		Height:               predict.Height,
+		Weight:               predict.Weight,
+		HeadCircumference:    predict.HeadCircumference,
+		Age:                  predict.Age,
+		Sex:                  predict.Sex,
+		Zscore:               predict.Zscore,
+		NutritionalStatus:    predict.NutritionalStatus,
+		WhoZscore:            predict.WhoZscore,
+		WhoNutritionalStatus: predict.WhoNutritionalStatus,
+		Source:               predict.Source,
+		ModelName:            predict.ModelName,
+		ModelVersion:         predict.ModelVersion,
+		MLResponse:           mlResponse,
+		MLLatencyMs:          predict.MLLatencyMs,
+		WfaZscore:            predict.WfaZscore,
+		WfaStatus:            predict.WfaStatus,
+		WfhZscore:            predict.WfhZscore,
+		WfhStatus:            predict.WfhStatus,
+		BfaZscore:            predict.BfaZscore,
+		BfaStatus:            predict.BfaStatus,
+		HcfaZscore:           predict.HcfaZscore,
+		HcfaStatus:           predict.HcfaStatus,
+		SessionID:            predict.SessionID,
+		CreatedAt:            predict.CreatedAt,
+		UpdatedAt:            predict.UpdatedAt,
+	}
+}
+
+func NewPredictService(repo repositories.PredictRepository, toddlerRepo repositories.ToddlerRepository, parentRepo repositories.ParentRepository, previewRepo repositories.GroupPredictPreviewRepository, jobService JobService, audit AuditService, ml MLClient, predictor string, shadow ShadowEvaluator) (PredictService, error) {
+	switch predictor {
+	case "":
+		predictor = pkg.PredictorML
+	case pkg.PredictorML, pkg.PredictorWHO, pkg.PredictorBoth:
+	default:
+		return nil, fmt.Errorf("unknown predictor %q", predictor)
+	}
+
+	return &predictService{
+		repo:        repo,
+		toddlerRepo: toddlerRepo,
+		parentRepo:  parentRepo,
+		previewRepo: previewRepo,
+		jobService:  jobService,
+		audit:       audit,
+		ml:          ml,
+		predictor:   predictor,
+		shadow:      shadow,
+	}, nil
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"grovia/internal/dto/requests"
+	"grovia/internal/growth"
+	"grovia/internal/models"
+	"grovia/internal/repositories"
+	"grovia/pkg"
+	"net/http"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+type fakePredictRepository struct {
+	repositories.PredictRepository
+	predict *models.Predict
+
+	updated  *models.Predict
+	revision *models.PredictRevision
+}
+
+func (f *fakePredictRepository) GetPredictByID(id int, scope pkg.AccessScope) (*models.Predict, error) {
+	if f.predict == nil || f.predict.ID != id {
+		return nil, gorm.ErrRecordNotFound
+	}
+	predict := *f.predict
+	return &predict, nil
+}
+
+func (f *fakePredictRepository) UpdatePredictByID(id int, scope pkg.AccessScope, predict *models.Predict, revision *models.PredictRevision) (*models.Predict, error) {
+	f.updated, f.revision = predict, revision
+	saved := *predict
+	saved.ID = id
+	return &saved, nil
+}
+
+type fakeAuditService struct {
+	AuditService
+}
+
+func (fakeAuditService) Record(ctx context.Context, action, entity string, entityID int, before, after any) {
+}
+
+func recordedPredict() *models.Predict {
+	return &models.Predict{
+		ID:                9,
+		Name:              "Budi",
+		Height:            70,
+		Age:               24,
+		Sex:               "male",
+		Zscore:            -4.5,
+		NutritionalStatus: growth.StatusSeverelyStunted,
+		Source:            pkg.PredictSourceWHO,
+		LocationID:        2,
+	}
+}
+
+func newTestPredictService(t *testing.T, repo *fakePredictRepository, ml MLClient, predictor string) *predictService {
+	t.Helper()
+
+	p, err := NewPredictService(repo, nil, nil, nil, nil, fakeAuditService{}, ml, predictor, nil)
+	if err != nil {
+		t.Fatalf("NewPredictService: %v", err)
+	}
+	return p.(*predictService)
+}
+
+func TestUpdatePredictRejects(t *testing.T) {
+	height := 180.0
+	status := growth.StatusNormal
+	blank := "  "
+	unknown := "gizi baik"
+	reason := "Salah ketik saat pencatatan"
+
+	tests := []struct {
+		name string
+		id   int
+		req  requests.UpdatePredictRequest
+		want int
+	}{
+		{"nothing to change", 9, requests.UpdatePredictRequest{}, http.StatusBadRequest},
+		{"reason only", 9, requests.UpdatePredictRequest{Reason: &reason}, http.StatusBadRequest},
+		{"override without reason", 9, requests.UpdatePredictRequest{NutritionalStatus: &status}, http.StatusBadRequest},
+		{"override with a blank reason", 9, requests.UpdatePredictRequest{NutritionalStatus: &status, Reason: &blank}, http.StatusBadRequest},
+		{"unknown status", 9, requests.UpdatePredictRequest{NutritionalStatus: &unknown, Reason: &reason}, http.StatusBadRequest},
+		{"height out of range", 9, requests.UpdatePredictRequest{Height: &height}, http.StatusBadRequest},
+		{"missing predict", 10, requests.UpdatePredictRequest{NutritionalStatus: &status, Reason: &reason}, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakePredictRepository{predict: recordedPredict()}
+			p := newTestPredictService(t, repo, nil, pkg.PredictorWHO)
+
+			_, err := p.UpdatePredictByID(context.Background(), tt.id, pkg.AccessScope{AllLocations: true}, 1, &tt.req)
+			if statusCode(err) != tt.want {
+				t.Errorf("error = %v, want status %d", err, tt.want)
+			}
+			if repo.updated != nil {
+				t.Error("predict was updated")
+			}
+		})
+	}
+}
+
+func TestUpdatePredictMeasurementPredictsAgain(t *testing.T) {
+	repo := &fakePredictRepository{predict: recordedPredict()}
+	p := newTestPredictService(t, repo, nil, pkg.PredictorWHO)
+
+	// 70 cm was a typo for 87 cm, a normal height at two years.
+	height, weight := 87.0, 12.0
+	got, err := p.UpdatePredictByID(context.Background(), 9, pkg.AccessScope{AllLocations: true}, 4, &requests.UpdatePredictRequest{Height: &height, Weight: &weight})
+	if err != nil {
+		t.Fatalf("UpdatePredictByID: %v", err)
+	}
+
+	if got.Height != 87 || got.NutritionalStatus != growth.StatusNormal || got.Source != pkg.PredictSourceWHO {
+		t.Errorf("corrected predict = %+v", got)
+	}
+	if got.Age != 24 || got.Sex != "male" {
+		t.Errorf("unchanged measurements were not kept: age %d, sex %q", got.Age, got.Sex)
+	}
+	if got.WfaZscore == nil || got.WhoZscore == nil {
+		t.Errorf("WHO indicators not recomputed: %+v", got)
+	}
+
+	revision := repo.revision
+	if revision == nil || !revision.MeasurementChanged || revision.StatusOverridden || revision.EditedByID != 4 || revision.Reason != nil {
+		t.Errorf("revision = %+v", revision)
+	}
+}
+
+func TestUpdatePredictOverride(t *testing.T) {
+	repo := &fakePredictRepository{predict: recordedPredict()}
+	p := newTestPredictService(t, repo, nil, pkg.PredictorWHO)
+
+	status, reason := growth.StatusStunted, "  Diukur ulang oleh bidan  "
+	got, err := p.UpdatePredictByID(context.Background(), 9, pkg.AccessScope{AllLocations: true}, 4, &requests.UpdatePredictRequest{NutritionalStatus: &status, Reason: &reason})
+	if err != nil {
+		t.Fatalf("UpdatePredictByID: %v", err)
+	}
+
+	if got.NutritionalStatus != growth.StatusStunted || got.Source != pkg.PredictSourceManual {
+		t.Errorf("overridden predict = %+v", got)
+	}
+	if got.Height != 70 || got.Zscore != -4.5 {
+		t.Errorf("override changed the measurement: %+v", got)
+	}
+	if revision := repo.revision; !revision.StatusOverridden || revision.MeasurementChanged || revision.Reason == nil || *revision.Reason != "Diukur ulang oleh bidan" {
+		t.Errorf("revision = %+v", revision)
+	}
+}
+
+func TestUpdatePredictOverridesTheNewPrediction(t *testing.T) {
+	repo := &fakePredictRepository{predict: recordedPredict()}
+	p := newTestPredictService(t, repo, nil, pkg.PredictorWHO)
+
+	height, zscore, reason := 87.0, -2.1, "Koreksi dari buku KIA"
+	got, err := p.UpdatePredictByID(context.Background(), 9, pkg.AccessScope{AllLocations: true}, 4, &requests.UpdatePredictRequest{Height: &height, Zscore: &zscore, Reason: &reason})
+	if err != nil {
+		t.Fatalf("UpdatePredictByID: %v", err)
+	}
+
+	if got.Height != 87 || got.Zscore != -2.1 || got.Source != pkg.PredictSourceManual {
+		t.Errorf("corrected predict = %+v", got)
+	}
+	if !repo.revision.MeasurementChanged || !repo.revision.StatusOverridden {
+		t.Errorf("revision = %+v", repo.revision)
+	}
+}
+
+func TestUpdatePredictKeepsThePredictWhenTheMLAPIFails(t *testing.T) {
+	repo := &fakePredictRepository{predict: recordedPredict()}
+	ml := &fakeMLClient{err: pkg.NewServiceUnavailableError("ML API sedang tidak tersedia, coba lagi nanti")}
+	p := newTestPredictService(t, repo, ml, pkg.PredictorML)
+
+	age := 30
+	_, err := p.UpdatePredictByID(context.Background(), 9, pkg.AccessScope{AllLocations: true}, 4, &requests.UpdatePredictRequest{Age: &age})
+	if statusCode(err) != http.StatusServiceUnavailable {
+		t.Errorf("error = %v, want a 503", err)
+	}
+	if repo.updated != nil {
+		t.Error("predict was updated without a new prediction")
+	}
+}
+
+func TestNewPredictServiceRejectsAnUnknownPredictor(t *testing.T) {
+	for _, predictor := range []string{"WHO", "hybrid"} {
+		if _, err := NewPredictService(nil, nil, nil, nil, nil, nil, nil, predictor, nil); err == nil {
+			t.Errorf("predictor %q accepted", predictor)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"grovia/internal/dto/responses"
+	"grovia/pkg"
+)
+
+// GetImmunizationReport reports imunisasi dasar lengkap (IDL) for the
+// toddlers who turned one during the period: how many got every basic
+// dose before their first birthday, and the coverage of each dose.
+func (r *reportService) GetImmunizationReport(scope pkg.AccessScope, locationIDStr, fromStr, toStr string) (*responses.ImmunizationReportResponse, error) {
+	locationID, err := reportLocation(scope, locationIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := parseReportRange(fromStr, toStr)
+	if err != nil {
+		return nil, err
+	}
+
+	idl, err := r.immunizationRepo.GetIDL(locationID, start, end)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal menghitung imunisasi dasar lengkap")
+	}
+
+	coverage, err := r.immunizationRepo.GetVaccineCoverage(locationID, start, end)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal menghitung cakupan imunisasi")
+	}
+
+	report := &responses.ImmunizationReportResponse{
+		From:      start.Format(reportMonthLayout),
+		To:        end.AddDate(0, -1, 0).Format(reportMonthLayout),
+		Locations: []responses.IDLResponse{},
+		Total:     responses.IDLResponse{LocationName: "Total"},
+		Vaccines:  []responses.VaccineCoverageResponse{},
+	}
+
+	for _, row := range idl {
+		report.Locations = append(report.Locations, responses.IDLResponse{
+			LocationID:   row.LocationID,
+			LocationName: row.LocationName,
+			Cohort:       row.Cohort,
+			Complete:     row.Complete,
+			Percentage:   percentage(row.Complete, row.Cohort),
+		})
+		report.Total.Cohort += row.Cohort
+		report.Total.Complete += row.Complete
+	}
+	report.Total.Percentage = percentage(report.Total.Complete, report.Total.Cohort)
+
+	for _, row := range coverage {
+		report.Vaccines = append(report.Vaccines, responses.VaccineCoverageResponse{
+			Code:       row.Code,
+			Name:       row.Name,
+			Basic:      row.Basic,
+			Given:      row.Given,
+			Percentage: percentage(row.Given, report.Total.Cohort),
+		})
+	}
+
+	return report, nil
+}
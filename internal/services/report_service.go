@@ -0,0 +1,273 @@
+package services
+
+import (
+	"fmt"
+	"grovia/internal/dto/responses"
+	"grovia/internal/growth"
+	"grovia/internal/repositories"
+	"grovia/internal/spreadsheet"
+	"grovia/pkg"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const reportMonthLayout = "2006-01"
+
+type ReportService interface {
+	GetSKDN(scope pkg.AccessScope, locationIDStr, monthStr string) (*responses.SKDNReportResponse, error)
+	ExportSKDN(report *responses.SKDNReportResponse) ([]byte, string, error)
+	GetPrevalence(scope pkg.AccessScope, locationIDStr, groupByStr, fromStr, toStr string) (*responses.PrevalenceResponse, error)
+	GetShadowReport(scope pkg.AccessScope, locationIDStr, modelVersion, fromStr, toStr string) (*responses.ShadowReportResponse, error)
+	GetImmunizationReport(scope pkg.AccessScope, locationIDStr, fromStr, toStr string) (*responses.ImmunizationReportResponse, error)
+}
+
+type reportService struct {
+	repo             repositories.ReportRepository
+	shadowRepo       repositories.ShadowPredictionRepository
+	immunizationRepo repositories.ImmunizationRepository
+}
+
+func (r *reportService) GetSKDN(scope pkg.AccessScope, locationIDStr, monthStr string) (*responses.SKDNReportResponse, error) {
+	start, end, err := parseReportMonth(monthStr)
+	if err != nil {
+		return nil, err
+	}
+
+	locationID, err := reportLocation(scope, locationIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.repo.GetSKDN(locationID, start, end)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal menghitung SKDN")
+	}
+
+	report := &responses.SKDNReportResponse{
+		Month:     start.Format(reportMonthLayout),
+		Locations: []responses.SKDNResponse{},
+		Total:     responses.SKDNResponse{LocationName: "Total"},
+	}
+
+	for _, row := range rows {
+		report.Locations = append(report.Locations, toSKDNResponse(row))
+		report.Total.S += row.S
+		report.Total.K += row.K
+		report.Total.D += row.D
+		report.Total.N += row.N
+		report.Total.T += row.T
+		report.Total.O += row.O
+	}
+	setSKDNRatios(&report.Total)
+
+	return report, nil
+}
+
+func (r *reportService) ExportSKDN(report *responses.SKDNReportResponse) ([]byte, string, error) {
+	table := spreadsheet.Table{
+		Sheet:   "SKDN",
+		Title:   "Laporan SKDN " + report.Month,
+		Headers: []string{"Posyandu", "S", "K", "D", "N", "T", "O", "K/S (%)", "D/S (%)", "N/D (%)"},
+	}
+
+	for _, l := range append(report.Locations, report.Total) {
+		table.Rows = append(table.Rows, []any{l.LocationName, l.S, l.K, l.D, l.N, l.T, l.O, l.KPerS, l.DPerS, l.NPerD})
+	}
+
+	data, err := spreadsheet.WriteTable(table)
+	if err != nil {
+		return nil, "", pkg.NewInternalServerError("Gagal membuat file laporan")
+	}
+
+	return data, fmt.Sprintf("skdn_%s.xlsx", report.Month), nil
+}
+
+func (r *reportService) GetPrevalence(scope pkg.AccessScope, locationIDStr, groupByStr, fromStr, toStr string) (*responses.PrevalenceResponse, error) {
+	locationID, err := reportLocation(scope, locationIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := parseReportRange(fromStr, toStr)
+	if err != nil {
+		return nil, err
+	}
+
+	groupBy := []string{}
+	for _, dim := range strings.Split(groupByStr, ",") {
+		dim = strings.TrimSpace(dim)
+		if dim == "" || slices.Contains(groupBy, dim) {
+			continue
+		}
+		switch dim {
+		case repositories.PrevalenceByLocation, repositories.PrevalenceByMonth, repositories.PrevalenceBySex, repositories.PrevalenceByAgeBand:
+			groupBy = append(groupBy, dim)
+		default:
+			return nil, pkg.NewBadRequestError("groupBy harus salah satu dari: location month sex ageBand")
+		}
+	}
+
+	rows, err := r.repo.GetPrevalence(repositories.PrevalenceFilter{
+		LocationID: locationID,
+		From:       start,
+		To:         end,
+		GroupBy:    groupBy,
+	})
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal menghitung prevalensi")
+	}
+
+	response := &responses.PrevalenceResponse{
+		From:    start.Format(reportMonthLayout),
+		To:      end.AddDate(0, -1, 0).Format(reportMonthLayout),
+		GroupBy: groupBy,
+		Groups:  []responses.PrevalenceGroupResponse{},
+	}
+
+	// Rows arrive ordered by the group columns, so one group's statuses
+	// are consecutive.
+	var current *responses.PrevalenceGroupResponse
+	for _, row := range rows {
+		if current == nil || !samePrevalenceGroup(current, row) {
+			response.Groups = append(response.Groups, responses.PrevalenceGroupResponse{
+				LocationID:   row.LocationID,
+				LocationName: row.LocationName,
+				Month:        row.Month,
+				Sex:          row.Sex,
+				AgeBand:      row.AgeBand,
+				Statuses:     []responses.PrevalenceStatusResponse{},
+			})
+			current = &response.Groups[len(response.Groups)-1]
+		}
+
+		current.Total += row.Total
+		current.Statuses = append(current.Statuses, responses.PrevalenceStatusResponse{
+			NutritionalStatus: row.NutritionalStatus,
+			Count:             row.Total,
+		})
+	}
+
+	for i := range response.Groups {
+		group := &response.Groups[i]
+		stunted := 0
+		for j := range group.Statuses {
+			status := &group.Statuses[j]
+			status.Percentage = percentage(status.Count, group.Total)
+			if status.NutritionalStatus == growth.StatusStunted || status.NutritionalStatus == growth.StatusSeverelyStunted {
+				stunted += status.Count
+			}
+		}
+		group.StuntingPrevalence = percentage(stunted, group.Total)
+	}
+
+	return response, nil
+}
+
+func samePrevalenceGroup(group *responses.PrevalenceGroupResponse, row repositories.PrevalenceRow) bool {
+	return equalPtr(group.LocationID, row.LocationID) &&
+		equalPtr(group.Month, row.Month) &&
+		equalPtr(group.Sex, row.Sex) &&
+		equalPtr(group.AgeBand, row.AgeBand)
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// parseReportMonth returns the first instant of the month and of the next
+// one. An empty value means the current month.
+func parseReportMonth(monthStr string) (time.Time, time.Time, error) {
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+
+	if monthStr != "" {
+		month, err := time.ParseInLocation(reportMonthLayout, monthStr, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, pkg.NewBadRequestError("month harus berformat YYYY-MM")
+		}
+		start = month
+	}
+
+	return start, start.AddDate(0, 1, 0), nil
+}
+
+// parseReportRange returns the months from and to span, to included. to
+// defaults to the current month and from to twelve months before it.
+func parseReportRange(fromStr, toStr string) (time.Time, time.Time, error) {
+	_, end, err := parseReportMonth(toStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	start := end.AddDate(-1, 0, 0)
+	if fromStr != "" {
+		if start, _, err = parseReportMonth(fromStr); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, pkg.NewBadRequestError("from harus sebelum to")
+	}
+
+	return start, end, nil
+}
+
+// reportLocation resolves which location a report covers. A scope over
+// all locations may pick any location or, by leaving it empty, all of
+// them (0); everyone else only gets their own location.
+func reportLocation(scope pkg.AccessScope, locationIDStr string) (int, error) {
+	if locationIDStr == "" {
+		if scope.AllLocations {
+			return 0, nil
+		}
+		return scope.LocationID, nil
+	}
+
+	locationID, err := strconv.Atoi(locationIDStr)
+	if err != nil || locationID < 1 {
+		return 0, pkg.NewBadRequestError("locationId tidak valid")
+	}
+
+	if !scope.CanAccessLocation(locationID) {
+		return 0, pkg.NewForbiddenError("Tidak memiliki akses ke laporan lokasi ini")
+	}
+
+	return locationID, nil
+}
+
+func toSKDNResponse(row repositories.SKDNRow) responses.SKDNResponse {
+	response := responses.SKDNResponse{
+		LocationID:   row.LocationID,
+		LocationName: row.LocationName,
+		S:            row.S,
+		K:            row.K,
+		D:            row.D,
+		N:            row.N,
+		T:            row.T,
+		O:            row.O,
+	}
+	setSKDNRatios(&response)
+	return response
+}
+
+func setSKDNRatios(r *responses.SKDNResponse) {
+	r.KPerS = percentage(r.K, r.S)
+	r.DPerS = percentage(r.D, r.S)
+	r.NPerD = percentage(r.N, r.D)
+}
+
+func percentage(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(whole)*1000) / 10
+}
+
+func NewReportService(repo repositories.ReportRepository, shadowRepo repositories.ShadowPredictionRepository, immunizationRepo repositories.ImmunizationRepository) ReportService {
+	return &reportService{repo: repo, shadowRepo: shadowRepo, immunizationRepo: immunizationRepo}
+}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"grovia/internal/dto/responses"
+	"grovia/internal/growth"
+	"grovia/internal/repositories"
+	"grovia/pkg"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type fakeReportRepository struct {
+	skdn       []repositories.SKDNRow
+	prevalence []repositories.PrevalenceRow
+
+	locationID int
+	start, end time.Time
+}
+
+func (f *fakeReportRepository) GetSKDN(locationID int, start, end time.Time) ([]repositories.SKDNRow, error) {
+	f.locationID, f.start, f.end = locationID, start, end
+	return f.skdn, nil
+}
+
+func (f *fakeReportRepository) GetPrevalence(filter repositories.PrevalenceFilter) ([]repositories.PrevalenceRow, error) {
+	f.locationID, f.start, f.end = filter.LocationID, filter.From, filter.To
+	return f.prevalence, nil
+}
+
+func TestPercentage(t *testing.T) {
+	tests := []struct {
+		part, whole int
+		want        float64
+	}{
+		{0, 0, 0},
+		{5, 0, 0},
+		{0, 7, 0},
+		{7, 7, 100},
+		{1, 3, 33.3},
+		{2, 3, 66.7},
+		{1, 8, 12.5},
+		{1, 6, 16.7},
+		{5, 4, 125},
+	}
+
+	for _, tt := range tests {
+		if got := percentage(tt.part, tt.whole); got != tt.want {
+			t.Errorf("percentage(%d, %d) = %v, want %v", tt.part, tt.whole, got, tt.want)
+		}
+	}
+}
+
+func TestParseReportMonth(t *testing.T) {
+	start, end, err := parseReportMonth("2026-02")
+	if err != nil {
+		t.Fatalf("parseReportMonth: %v", err)
+	}
+	if want := time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local); !start.Equal(want) {
+		t.Errorf("start = %v, want %v", start, want)
+	}
+	if want := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local); !end.Equal(want) {
+		t.Errorf("end = %v, want %v", end, want)
+	}
+
+	start, end, err = parseReportMonth("2025-12")
+	if err != nil {
+		t.Fatalf("parseReportMonth: %v", err)
+	}
+	if end.Year() != 2026 || end.Month() != time.January || !start.Before(end) {
+		t.Errorf("December rolls over to %v", end)
+	}
+
+	start, _, err = parseReportMonth("")
+	if err != nil {
+		t.Fatalf("parseReportMonth: %v", err)
+	}
+	now := time.Now()
+	if start.Year() != now.Year() || start.Month() != now.Month() || start.Day() != 1 || start.Hour() != 0 {
+		t.Errorf("empty month = %v, want the start of the current month", start)
+	}
+
+	for _, invalid := range []string{"2026-13", "2026/02", "02-2026", "2026-2", "bulan"} {
+		_, _, err := parseReportMonth(invalid)
+		var customErr *pkg.CustomError
+		if !errors.As(err, &customErr) || customErr.StatusCode != 400 {
+			t.Errorf("parseReportMonth(%q) error = %v, want a bad request", invalid, err)
+		}
+	}
+}
+
+func TestGetSKDN(t *testing.T) {
+	repo := &fakeReportRepository{skdn: []repositories.SKDNRow{
+		{LocationID: 1, LocationName: "Melati", S: 40, K: 36, D: 30, N: 21, T: 6, O: 3},
+		{LocationID: 2, LocationName: "Mawar", S: 20, K: 20, D: 12, N: 11, T: 1, O: 0},
+	}}
+	service := NewReportService(repo, nil, nil)
+
+	report, err := service.GetSKDN(pkg.AccessScope{AllLocations: true}, "", "2026-09")
+	if err != nil {
+		t.Fatalf("GetSKDN: %v", err)
+	}
+
+	if report.Month != "2026-09" {
+		t.Errorf("month = %q", report.Month)
+	}
+	if repo.locationID != 0 {
+		t.Errorf("all-location scope queried location %d", repo.locationID)
+	}
+	if len(report.Locations) != 2 {
+		t.Fatalf("%d locations, want 2", len(report.Locations))
+	}
+
+	melati := report.Locations[0]
+	if melati.KPerS != 90 || melati.DPerS != 75 || melati.NPerD != 70 {
+		t.Errorf("Melati ratios = %v/%v/%v, want 90/75/70", melati.KPerS, melati.DPerS, melati.NPerD)
+	}
+
+	total := report.Total
+	if total.LocationName != "Total" || total.S != 60 || total.K != 56 || total.D != 42 || total.N != 32 || total.T != 7 || total.O != 3 {
+		t.Errorf("total = %+v", total)
+	}
+	if total.KPerS != 93.3 || total.DPerS != 70 || total.NPerD != 76.2 {
+		t.Errorf("total ratios = %v/%v/%v, want 93.3/70/76.2", total.KPerS, total.DPerS, total.NPerD)
+	}
+}
+
+func TestGetSKDNEmptyMonth(t *testing.T) {
+	service := NewReportService(&fakeReportRepository{}, nil, nil)
+
+	report, err := service.GetSKDN(pkg.AccessScope{LocationID: 3}, "", "2026-09")
+	if err != nil {
+		t.Fatalf("GetSKDN: %v", err)
+	}
+	if report.Locations == nil || len(report.Locations) != 0 {
+		t.Errorf("locations = %v, want an empty list", report.Locations)
+	}
+	if report.Total.KPerS != 0 || report.Total.NPerD != 0 {
+		t.Errorf("ratios of an empty month = %+v", report.Total)
+	}
+}
+
+func TestGetSKDNLocationScope(t *testing.T) {
+	repo := &fakeReportRepository{}
+	service := NewReportService(repo, nil, nil)
+
+	if _, err := service.GetSKDN(pkg.AccessScope{LocationID: 3}, "", ""); err != nil {
+		t.Fatalf("GetSKDN: %v", err)
+	}
+	if repo.locationID != 3 {
+		t.Errorf("location-bound scope queried location %d, want 3", repo.locationID)
+	}
+
+	_, err := service.GetSKDN(pkg.AccessScope{LocationID: 3}, "4", "")
+	var customErr *pkg.CustomError
+	if !errors.As(err, &customErr) || customErr.StatusCode != 403 {
+		t.Errorf("other location error = %v, want forbidden", err)
+	}
+
+	for _, invalid := range []string{"0", "-1", "abc"} {
+		_, err := service.GetSKDN(pkg.AccessScope{AllLocations: true}, invalid, "")
+		if !errors.As(err, &customErr) || customErr.StatusCode != 400 {
+			t.Errorf("locationId %q error = %v, want a bad request", invalid, err)
+		}
+	}
+}
+
+func TestExportSKDN(t *testing.T) {
+	service := NewReportService(&fakeReportRepository{}, nil, nil)
+	report := &responses.SKDNReportResponse{
+		Month: "2026-09",
+		Locations: []responses.SKDNResponse{
+			{LocationID: 1, LocationName: "Melati", S: 40, K: 36, D: 30, N: 21, T: 6, O: 3, KPerS: 90, DPerS: 75, NPerD: 70},
+		},
+		Total: responses.SKDNResponse{LocationName: "Total", S: 40, K: 36, D: 30, N: 21, T: 6, O: 3, KPerS: 90, DPerS: 75, NPerD: 70},
+	}
+
+	data, fileName, err := service.ExportSKDN(report)
+	if err != nil {
+		t.Fatalf("ExportSKDN: %v", err)
+	}
+	if fileName != "skdn_2026-09.xlsx" {
+		t.Errorf("file name = %q", fileName)
+	}
+
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("open workbook: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows("SKDN")
+	if err != nil {
+		t.Fatalf("GetRows: %v", err)
+	}
+	want := [][]string{
+		{"Laporan SKDN 2026-09"},
+		nil,
+		{"Posyandu", "S", "K", "D", "N", "T", "O", "K/S (%)", "D/S (%)", "N/D (%)"},
+		{"Melati", "40", "36", "30", "21", "6", "3", "90", "75", "70"},
+		{"Total", "40", "36", "30", "21", "6", "3", "90", "75", "70"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %q, want %q", rows, want)
+	}
+}
+
+func TestGetPrevalence(t *testing.T) {
+	melati, mawar := "Melati", "Mawar"
+	one, two := 1, 2
+	repo := &fakeReportRepository{prevalence: []repositories.PrevalenceRow{
+		{LocationID: &one, LocationName: &melati, NutritionalStatus: growth.StatusNormal, Total: 6},
+		{LocationID: &one, LocationName: &melati, NutritionalStatus: growth.StatusStunted, Total: 3},
+		{LocationID: &one, LocationName: &melati, NutritionalStatus: growth.StatusSeverelyStunted, Total: 1},
+		{LocationID: &two, LocationName: &mawar, NutritionalStatus: growth.StatusNormal, Total: 2},
+	}}
+	service := NewReportService(repo, nil, nil)
+
+	response, err := service.GetPrevalence(pkg.AccessScope{AllLocations: true}, "", "location, location", "2026-01", "2026-06")
+	if err != nil {
+		t.Fatalf("GetPrevalence: %v", err)
+	}
+
+	if !reflect.DeepEqual(response.GroupBy, []string{repositories.PrevalenceByLocation}) {
+		t.Errorf("groupBy = %v", response.GroupBy)
+	}
+	if response.From != "2026-01" || response.To != "2026-06" {
+		t.Errorf("period = %s..%s", response.From, response.To)
+	}
+	if want := time.Date(2026, 7, 1, 0, 0, 0, 0, time.Local); !repo.end.Equal(want) {
+		t.Errorf("queried until %v, want %v", repo.end, want)
+	}
+	if len(response.Groups) != 2 {
+		t.Fatalf("%d groups, want 2", len(response.Groups))
+	}
+
+	first := response.Groups[0]
+	if first.Total != 10 || first.StuntingPrevalence != 40 {
+		t.Errorf("Melati total %d prevalence %v, want 10 and 40", first.Total, first.StuntingPrevalence)
+	}
+	if first.Statuses[1].Percentage != 30 {
+		t.Errorf("stunted share = %v, want 30", first.Statuses[1].Percentage)
+	}
+	if second := response.Groups[1]; second.Total != 2 || second.StuntingPrevalence != 0 {
+		t.Errorf("Mawar = %+v", second)
+	}
+
+	for _, tt := range []struct{ groupBy, from, to string }{
+		{"ward", "", ""},
+		{"", "2026-06", "2026-01"},
+	} {
+		_, err := service.GetPrevalence(pkg.AccessScope{AllLocations: true}, "", tt.groupBy, tt.from, tt.to)
+		var customErr *pkg.CustomError
+		if !errors.As(err, &customErr) || customErr.StatusCode != 400 {
+			t.Errorf("%+v: error = %v, want a bad request", tt, err)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"grovia/internal/dto/responses"
+	"grovia/internal/repositories"
+	"grovia/pkg"
+	"slices"
+)
+
+// shadowDisagreementLimit caps the disagreeing predicts listed in the
+// shadow report; the matrices still count all of them.
+const shadowDisagreementLimit = 100
+
+func (r *reportService) GetShadowReport(scope pkg.AccessScope, locationIDStr, modelVersion, fromStr, toStr string) (*responses.ShadowReportResponse, error) {
+	locationID, err := reportLocation(scope, locationIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := parseReportRange(fromStr, toStr)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repositories.ShadowFilter{
+		LocationID:   locationID,
+		ModelVersion: modelVersion,
+		From:         start,
+		To:           end,
+	}
+
+	summary, err := r.shadowRepo.GetShadowSummary(filter)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal menghitung evaluasi model")
+	}
+
+	againstPrimary, err := r.shadowRepo.GetShadowConfusion(filter, repositories.ShadowAgainstPrimary)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal menghitung evaluasi model")
+	}
+
+	againstWHO, err := r.shadowRepo.GetShadowConfusion(filter, repositories.ShadowAgainstWHO)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal menghitung evaluasi model")
+	}
+
+	disagreements, err := r.shadowRepo.GetShadowDisagreements(filter, shadowDisagreementLimit)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal mengambil perbedaan klasifikasi")
+	}
+
+	report := &responses.ShadowReportResponse{
+		From:           start.Format(reportMonthLayout),
+		To:             end.AddDate(0, -1, 0).Format(reportMonthLayout),
+		ModelVersion:   modelVersion,
+		Total:          summary.Total,
+		Failed:         summary.Failed,
+		AgainstPrimary: toShadowConfusion(againstPrimary),
+		AgainstWHO:     toShadowConfusion(againstWHO),
+		Disagreements:  []responses.ShadowDisagreementResponse{},
+	}
+
+	for _, row := range disagreements {
+		report.Disagreements = append(report.Disagreements, responses.ShadowDisagreementResponse{
+			PredictID:                row.PredictID,
+			ToddlerID:                row.ToddlerID,
+			LocationID:               row.LocationID,
+			Name:                     row.Name,
+			Age:                      row.Age,
+			Sex:                      row.Sex,
+			Height:                   row.Height,
+			ModelVersion:             row.ModelVersion,
+			Zscore:                   row.Zscore,
+			NutritionalStatus:        row.NutritionalStatus,
+			PrimarySource:            row.PrimarySource,
+			PrimaryZscore:            row.PrimaryZscore,
+			PrimaryNutritionalStatus: row.PrimaryNutritionalStatus,
+			WhoZscore:                row.WhoZscore,
+			WhoNutritionalStatus:     row.WhoNutritionalStatus,
+			CreatedAt:                row.CreatedAt,
+		})
+	}
+
+	return report, nil
+}
+
+// toShadowConfusion builds the confusion matrix over every status either
+// side used.
+func toShadowConfusion(rows []repositories.ShadowConfusionRow) responses.ShadowConfusionResponse {
+	var labels []string
+	for _, row := range rows {
+		for _, label := range []string{row.Reference, row.Shadow} {
+			if !slices.Contains(labels, label) {
+				labels = append(labels, label)
+			}
+		}
+	}
+	// Statuses outside heightForAgeOrder follow it alphabetically.
+	slices.SortFunc(labels, compareStatus)
+
+	confusion := responses.ShadowConfusionResponse{
+		Labels: labels,
+		Matrix: make([][]int, len(labels)),
+	}
+	for i := range confusion.Matrix {
+		confusion.Matrix[i] = make([]int, len(labels))
+	}
+	if confusion.Labels == nil {
+		confusion.Labels = []string{}
+	}
+
+	for _, row := range rows {
+		i := slices.Index(labels, row.Reference)
+		j := slices.Index(labels, row.Shadow)
+		confusion.Matrix[i][j] += row.Total
+		confusion.Compared += row.Total
+		if i == j {
+			confusion.Agreed += row.Total
+		}
+	}
+	confusion.AgreementRate = percentage(confusion.Agreed, confusion.Compared)
+
+	return confusion
+}
+
+func compareStatus(a, b string) int {
+	i, j := slices.Index(heightForAgeOrder, a), slices.Index(heightForAgeOrder, b)
+	switch {
+	case i >= 0 && j >= 0:
+		return i - j
+	case i >= 0:
+		return -1
+	case j >= 0:
+		return 1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
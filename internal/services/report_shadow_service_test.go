@@ -0,0 +1,100 @@
+package services
+
+import (
+	"grovia/internal/growth"
+	"grovia/internal/repositories"
+	"grovia/pkg"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestToShadowConfusion(t *testing.T) {
+	got := toShadowConfusion([]repositories.ShadowConfusionRow{
+		{Reference: growth.StatusNormal, Shadow: growth.StatusNormal, Total: 70},
+		{Reference: growth.StatusNormal, Shadow: growth.StatusStunted, Total: 5},
+		{Reference: growth.StatusStunted, Shadow: growth.StatusStunted, Total: 18},
+		{Reference: growth.StatusStunted, Shadow: "unknown", Total: 1},
+		{Reference: growth.StatusSeverelyStunted, Shadow: growth.StatusStunted, Total: 6},
+	})
+
+	wantLabels := []string{growth.StatusSeverelyStunted, growth.StatusStunted, growth.StatusNormal, "unknown"}
+	if !reflect.DeepEqual(got.Labels, wantLabels) {
+		t.Errorf("labels = %q, want %q", got.Labels, wantLabels)
+	}
+
+	wantMatrix := [][]int{
+		{0, 6, 0, 0},
+		{0, 18, 0, 1},
+		{0, 5, 70, 0},
+		{0, 0, 0, 0},
+	}
+	if !reflect.DeepEqual(got.Matrix, wantMatrix) {
+		t.Errorf("matrix = %v, want %v", got.Matrix, wantMatrix)
+	}
+
+	if got.Compared != 100 || got.Agreed != 88 || got.AgreementRate != 88 {
+		t.Errorf("compared %d, agreed %d, rate %v, want 100, 88, 88", got.Compared, got.Agreed, got.AgreementRate)
+	}
+}
+
+func TestToShadowConfusionEmpty(t *testing.T) {
+	got := toShadowConfusion(nil)
+	if got.Labels == nil || got.Matrix == nil || len(got.Labels) != 0 || got.AgreementRate != 0 {
+		t.Errorf("empty confusion = %+v, want empty lists", got)
+	}
+}
+
+func TestGetShadowReport(t *testing.T) {
+	whoStatus := growth.StatusStunted
+	repo := &fakeShadowRepository{
+		summary: repositories.ShadowSummaryRow{Total: 12, Failed: 2},
+		confusion: map[string][]repositories.ShadowConfusionRow{
+			repositories.ShadowAgainstPrimary: {
+				{Reference: growth.StatusNormal, Shadow: growth.StatusNormal, Total: 6},
+				{Reference: growth.StatusNormal, Shadow: growth.StatusStunted, Total: 2},
+			},
+			repositories.ShadowAgainstWHO: {
+				{Reference: growth.StatusStunted, Shadow: growth.StatusStunted, Total: 10},
+			},
+		},
+		disagreements: []repositories.ShadowDisagreementRow{{
+			PredictID:                5,
+			NutritionalStatus:        growth.StatusStunted,
+			PrimarySource:            pkg.PredictSourceML,
+			PrimaryNutritionalStatus: growth.StatusNormal,
+			WhoNutritionalStatus:     &whoStatus,
+		}},
+	}
+	service := NewReportService(&fakeReportRepository{}, repo, nil)
+
+	report, err := service.GetShadowReport(pkg.AccessScope{AllLocations: true}, "4", "2026.11.0-rc1", "2026-01", "2026-03")
+	if err != nil {
+		t.Fatalf("GetShadowReport: %v", err)
+	}
+
+	if repo.filter.LocationID != 4 || repo.filter.ModelVersion != "2026.11.0-rc1" {
+		t.Errorf("filter = %+v", repo.filter)
+	}
+	if want := time.Date(2026, 4, 1, 0, 0, 0, 0, time.Local); !repo.filter.To.Equal(want) {
+		t.Errorf("to = %v, want %v", repo.filter.To, want)
+	}
+	if report.From != "2026-01" || report.To != "2026-03" || report.Total != 12 || report.Failed != 2 {
+		t.Errorf("report = %+v", report)
+	}
+	if report.AgainstPrimary.AgreementRate != 75 || report.AgainstWHO.AgreementRate != 100 {
+		t.Errorf("agreement = %v / %v, want 75 / 100", report.AgainstPrimary.AgreementRate, report.AgainstWHO.AgreementRate)
+	}
+	if len(report.Disagreements) != 1 || report.Disagreements[0].PredictID != 5 {
+		t.Errorf("disagreements = %+v", report.Disagreements)
+	}
+}
+
+func TestGetShadowReportChecksTheLocation(t *testing.T) {
+	service := NewReportService(&fakeReportRepository{}, &fakeShadowRepository{}, nil)
+
+	_, err := service.GetShadowReport(pkg.AccessScope{LocationID: 3}, "4", "", "", "")
+	if statusCode(err) != 403 {
+		t.Errorf("error = %v, want a 403", err)
+	}
+}
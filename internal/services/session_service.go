@@ -0,0 +1,281 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"grovia/internal/dto/requests"
+	"grovia/internal/dto/responses"
+	"grovia/internal/models"
+	"grovia/internal/repositories"
+	"grovia/pkg"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SessionService interface {
+	OpenSession(ctx context.Context, scope pkg.AccessScope, userID int, req requests.OpenSessionRequest) (*responses.SessionResponse, error)
+	CloseSession(ctx context.Context, id int, scope pkg.AccessScope, userID int) (*responses.SessionResponse, error)
+	GetAllSession(scope pkg.AccessScope, locationIDStr, pageStr, limitStr string) ([]responses.SessionResponse, *responses.PaginationMeta, error)
+	GetSessionByID(id int, scope pkg.AccessScope) (*responses.SessionResponse, error)
+	GetMeasured(id int, scope pkg.AccessScope) ([]responses.PredictResponse, error)
+	GetAbsent(id int, scope pkg.AccessScope) ([]responses.SessionAbsentResponse, error)
+	RecordAttendance(id int, scope pkg.AccessScope, userID int, req requests.RecordAttendanceRequest) (*responses.SessionResponse, error)
+}
+
+type sessionService struct {
+	repo        repositories.SessionRepository
+	toddlerRepo repositories.ToddlerRepository
+	audit       AuditService
+	now         func() time.Time
+}
+
+func (s *sessionService) OpenSession(ctx context.Context, scope pkg.AccessScope, userID int, req requests.OpenSessionRequest) (*responses.SessionResponse, error) {
+	if err := pkg.ValidateStruct(req); err != nil {
+		return nil, pkg.NewBadRequestError(err.Error())
+	}
+
+	locationID := scope.LocationID
+	if req.LocationID != nil {
+		locationID = *req.LocationID
+	}
+	if locationID == 0 {
+		return nil, pkg.NewBadRequestError("locationID wajib diisi")
+	}
+	if !scope.CanAccessLocation(locationID) {
+		return nil, pkg.NewForbiddenError("Tidak punya akses ke lokasi ini")
+	}
+
+	now := s.now()
+	date := dateOf(now)
+	if req.Date != nil {
+		date, _ = time.Parse(time.DateOnly, *req.Date)
+	}
+
+	if _, err := s.repo.GetOpenSession(locationID); err == nil {
+		return nil, pkg.NewConflictError("Masih ada sesi yang terbuka di lokasi ini")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, pkg.NewInternalServerError("Gagal membuka sesi")
+	}
+
+	if _, err := s.repo.GetSessionByDate(locationID, date); err == nil {
+		return nil, pkg.NewConflictError("Sesi untuk tanggal ini sudah ada")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, pkg.NewInternalServerError("Gagal membuka sesi")
+	}
+
+	session := &models.Session{
+		LocationID: locationID,
+		Date:       date,
+		Status:     models.SessionStatusOpen,
+		OpenedByID: userID,
+		OpenedAt:   now,
+	}
+	if req.Notes != nil {
+		session.Notes = strings.TrimSpace(*req.Notes)
+	}
+
+	session, err := s.repo.CreateSession(session)
+	if errors.Is(err, repositories.ErrSessionAlreadyOpen) {
+		return nil, pkg.NewConflictError("Masih ada sesi yang terbuka di lokasi ini")
+	}
+	if errors.Is(err, repositories.ErrSessionDateTaken) {
+		return nil, pkg.NewConflictError("Sesi untuk tanggal ini sudah ada")
+	}
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal membuka sesi")
+	}
+
+	s.audit.Record(ctx, pkg.AuditActionCreate, pkg.AuditEntitySession, session.ID, nil, session)
+
+	response := toSessionResponse(session)
+	return &response, nil
+}
+
+func (s *sessionService) CloseSession(ctx context.Context, id int, scope pkg.AccessScope, userID int) (*responses.SessionResponse, error) {
+	before, err := s.repo.GetSessionByID(id, scope)
+	if err != nil {
+		return nil, pkg.NewNotFoundError("Sesi tidak ditemukan")
+	}
+	if before.Status != models.SessionStatusOpen {
+		return nil, pkg.NewConflictError("Sesi sudah ditutup")
+	}
+
+	session, err := s.repo.CloseSession(id, scope, userID, s.now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, pkg.NewConflictError("Sesi sudah ditutup")
+	}
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal menutup sesi")
+	}
+
+	s.audit.Record(ctx, pkg.AuditActionUpdate, pkg.AuditEntitySession, id, before, session)
+
+	return s.withCounts(session)
+}
+
+func (s *sessionService) GetAllSession(scope pkg.AccessScope, locationIDStr, pageStr, limitStr string) ([]responses.SessionResponse, *responses.PaginationMeta, error) {
+	page, _ := strconv.Atoi(pageStr)
+	limit, _ := strconv.Atoi(limitStr)
+
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 {
+		limit = 10
+	}
+
+	offset := (page - 1) * limit
+
+	var locationID int
+	if locationIDStr != "" {
+		id, err := strconv.Atoi(locationIDStr)
+		if err != nil || id < 1 {
+			return nil, nil, pkg.NewBadRequestError("locationId tidak valid")
+		}
+		locationID = id
+	}
+
+	sessions, total, err := s.repo.GetAllSession(scope, locationID, limit, offset)
+	if err != nil {
+		return nil, nil, pkg.NewInternalServerError("Gagal mengambil data sesi")
+	}
+
+	sessionResponses := []responses.SessionResponse{}
+	for _, session := range sessions {
+		sessionResponses = append(sessionResponses, toSessionResponse(&session))
+	}
+
+	meta := responses.PaginationMeta{
+		Page:      page,
+		Limit:     limit,
+		TotalData: total,
+		TotalPage: int(math.Ceil(float64(total) / float64(limit))),
+	}
+
+	return sessionResponses, &meta, nil
+}
+
+func (s *sessionService) GetSessionByID(id int, scope pkg.AccessScope) (*responses.SessionResponse, error) {
+	session, err := s.repo.GetSessionByID(id, scope)
+	if err != nil {
+		return nil, pkg.NewNotFoundError("Sesi tidak ditemukan")
+	}
+
+	return s.withCounts(session)
+}
+
+func (s *sessionService) GetMeasured(id int, scope pkg.AccessScope) ([]responses.PredictResponse, error) {
+	session, err := s.repo.GetSessionByID(id, scope)
+	if err != nil {
+		return nil, pkg.NewNotFoundError("Sesi tidak ditemukan")
+	}
+
+	predicts, err := s.repo.GetMeasuredPredicts(session.ID)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal mengambil data pengukuran sesi")
+	}
+
+	predictResponses := []responses.PredictResponse{}
+	for _, predict := range predicts {
+		predictResponses = append(predictResponses, toPredictResponse(&predict))
+	}
+
+	return predictResponses, nil
+}
+
+func (s *sessionService) GetAbsent(id int, scope pkg.AccessScope) ([]responses.SessionAbsentResponse, error) {
+	session, err := s.repo.GetSessionByID(id, scope)
+	if err != nil {
+		return nil, pkg.NewNotFoundError("Sesi tidak ditemukan")
+	}
+
+	toddlers, err := s.repo.GetAbsentToddlers(session)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal mengambil data balita yang tidak hadir")
+	}
+
+	absentResponses := []responses.SessionAbsentResponse{}
+	for _, toddler := range toddlers {
+		absentResponses = append(absentResponses, responses.SessionAbsentResponse{
+			ToddlerID:         toddler.ID,
+			Name:              toddler.Name,
+			Birthdate:         toddler.Birthdate,
+			Sex:               toddler.Sex,
+			AgeMonths:         ageInMonths(toddler.Birthdate, session.Date),
+			NutritionalStatus: toddler.NutritionalStatus,
+			ParentID:          toddler.ParentID,
+			ParentName:        toddler.Parent.Name,
+			ParentPhoneNumber: toddler.Parent.PhoneNumber,
+		})
+	}
+
+	return absentResponses, nil
+}
+
+// RecordAttendance marks a toddler who came but was not measured. Measured
+// toddlers are recorded when their predict is saved.
+func (s *sessionService) RecordAttendance(id int, scope pkg.AccessScope, userID int, req requests.RecordAttendanceRequest) (*responses.SessionResponse, error) {
+	if err := pkg.ValidateStruct(req); err != nil {
+		return nil, pkg.NewBadRequestError(err.Error())
+	}
+
+	session, err := s.repo.GetSessionByID(id, scope)
+	if err != nil {
+		return nil, pkg.NewNotFoundError("Sesi tidak ditemukan")
+	}
+	if session.Status != models.SessionStatusOpen {
+		return nil, pkg.NewConflictError("Sesi sudah ditutup")
+	}
+
+	if _, err := s.toddlerRepo.GetToddlerByID(req.ToddlerID, pkg.LocationScope(session.LocationID)); err != nil {
+		return nil, pkg.NewNotFoundError("Toddler tidak ditemukan di lokasi sesi ini")
+	}
+
+	if err := s.repo.RecordAttendance(session.ID, req.ToddlerID, &userID); err != nil {
+		return nil, pkg.NewInternalServerError("Gagal mencatat kehadiran")
+	}
+
+	return s.withCounts(session)
+}
+
+func (s *sessionService) withCounts(session *models.Session) (*responses.SessionResponse, error) {
+	counts, err := s.repo.GetSessionCounts(session)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal menghitung kehadiran sesi")
+	}
+
+	response := toSessionResponse(session)
+	response.Counts = &responses.SessionCountsResponse{
+		Registered: counts.Registered,
+		Attended:   counts.Attended,
+		Measured:   counts.Measured,
+		Absent:     max(counts.Registered-counts.Attended, 0),
+	}
+	return &response, nil
+}
+
+func toSessionResponse(session *models.Session) responses.SessionResponse {
+	return responses.SessionResponse{
+		ID:           session.ID,
+		LocationID:   session.LocationID,
+		LocationName: session.Location.Name,
+		Date:         session.Date.Format(time.DateOnly),
+		Status:       session.Status,
+		OpenedByID:   session.OpenedByID,
+		ClosedByID:   session.ClosedByID,
+		OpenedAt:     session.OpenedAt,
+		ClosedAt:     session.ClosedAt,
+		Notes:        session.Notes,
+		CreatedAt:    session.CreatedAt,
+		UpdatedAt:    session.UpdatedAt,
+	}
+}
+
+func NewSessionService(repo repositories.SessionRepository, toddlerRepo repositories.ToddlerRepository, audit AuditService) SessionService {
+	return &sessionService{repo: repo, toddlerRepo: toddlerRepo, audit: audit, now: time.Now}
+}
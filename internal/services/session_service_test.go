@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"grovia/internal/dto/requests"
+	"grovia/internal/models"
+	"grovia/internal/repositories"
+	"grovia/pkg"
+	"net/http"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type fakeSessionRepository struct {
+	repositories.SessionRepository
+	sessions []models.Session
+
+	created    *models.Session
+	attendance []int
+	createErr  error
+}
+
+func (f *fakeSessionRepository) find(match func(models.Session) bool) (*models.Session, error) {
+	for _, session := range f.sessions {
+		if match(session) {
+			return &session, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeSessionRepository) CreateSession(session *models.Session) (*models.Session, error) {
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
+	f.created = session
+	session.ID = len(f.sessions) + 1
+	return session, nil
+}
+
+func (f *fakeSessionRepository) GetSessionByID(id int, scope pkg.AccessScope) (*models.Session, error) {
+	return f.find(func(s models.Session) bool { return s.ID == id && scope.CanAccessLocation(s.LocationID) })
+}
+
+func (f *fakeSessionRepository) GetOpenSession(locationID int) (*models.Session, error) {
+	return f.find(func(s models.Session) bool { return s.LocationID == locationID && s.Status == models.SessionStatusOpen })
+}
+
+func (f *fakeSessionRepository) GetSessionByDate(locationID int, date time.Time) (*models.Session, error) {
+	return f.find(func(s models.Session) bool { return s.LocationID == locationID && s.Date.Equal(date) })
+}
+
+func (f *fakeSessionRepository) RecordAttendance(sessionID, toddlerID int, recordedByID *int) error {
+	f.attendance = append(f.attendance, toddlerID)
+	return nil
+}
+
+func (f *fakeSessionRepository) GetSessionCounts(session *models.Session) (*repositories.SessionCounts, error) {
+	return &repositories.SessionCounts{Registered: 10, Attended: len(f.attendance)}, nil
+}
+
+type fakeToddlerRepository struct {
+	repositories.ToddlerRepository
+	toddlers []models.Toddler
+}
+
+func (f *fakeToddlerRepository) GetToddlerByID(id int, scope pkg.AccessScope) (*models.Toddler, error) {
+	for _, toddler := range f.toddlers {
+		if toddler.ID == id && scope.CanAccessLocation(toddler.LocationID) {
+			return &toddler, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+var sessionTestDay = time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+func newTestSessionService(repo *fakeSessionRepository, toddlers ...models.Toddler) *sessionService {
+	s := NewSessionService(repo, &fakeToddlerRepository{toddlers: toddlers}, fakeAuditService{}).(*sessionService)
+	s.now = func() time.Time { return sessionTestDay.Add(8 * time.Hour) }
+	return s
+}
+
+func TestOpenSession(t *testing.T) {
+	repo := &fakeSessionRepository{}
+	s := newTestSessionService(repo)
+
+	got, err := s.OpenSession(context.Background(), pkg.AccessScope{LocationID: 3}, 5, requests.OpenSessionRequest{})
+	if err != nil {
+		t.Fatalf("OpenSession: %v", err)
+	}
+
+	if got.LocationID != 3 || got.Date != "2026-10-18" || got.Status != models.SessionStatusOpen || got.OpenedByID != 5 {
+		t.Errorf("opened session = %+v", got)
+	}
+}
+
+func TestOpenSessionRejects(t *testing.T) {
+	other, date := 4, "2026-09-20"
+	open := models.Session{ID: 1, LocationID: 3, Date: sessionTestDay.AddDate(0, -1, 0), Status: models.SessionStatusOpen}
+	closed := models.Session{ID: 2, LocationID: 3, Date: sessionTestDay, Status: models.SessionStatusClosed}
+
+	tests := []struct {
+		name     string
+		sessions []models.Session
+		scope    pkg.AccessScope
+		req      requests.OpenSessionRequest
+		want     int
+	}{
+		{"another location", nil, pkg.AccessScope{LocationID: 3}, requests.OpenSessionRequest{LocationID: &other}, http.StatusForbidden},
+		{"no location", nil, pkg.AccessScope{AllLocations: true}, requests.OpenSessionRequest{}, http.StatusBadRequest},
+		{"malformed date", nil, pkg.AccessScope{LocationID: 3}, requests.OpenSessionRequest{Date: new(string)}, http.StatusBadRequest},
+		{"a session is still open", []models.Session{open}, pkg.AccessScope{LocationID: 3}, requests.OpenSessionRequest{Date: &date}, http.StatusConflict},
+		{"the day already had a session", []models.Session{closed}, pkg.AccessScope{LocationID: 3}, requests.OpenSessionRequest{}, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeSessionRepository{sessions: tt.sessions}
+			s := newTestSessionService(repo)
+
+			_, err := s.OpenSession(context.Background(), tt.scope, 5, tt.req)
+			if statusCode(err) != tt.want {
+				t.Errorf("error = %v, want status %d", err, tt.want)
+			}
+			if repo.created != nil {
+				t.Error("session was opened")
+			}
+		})
+	}
+}
+
+// A session created by a concurrent request between the checks and the
+// insert is reported like one found by the checks.
+func TestOpenSessionConflictOnInsert(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"open session", repositories.ErrSessionAlreadyOpen, "Masih ada sesi yang terbuka di lokasi ini"},
+		{"same date", repositories.ErrSessionDateTaken, "Sesi untuk tanggal ini sudah ada"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSessionService(&fakeSessionRepository{createErr: tt.err})
+
+			_, err := s.OpenSession(context.Background(), pkg.AccessScope{LocationID: 3}, 5, requests.OpenSessionRequest{})
+			if statusCode(err) != http.StatusConflict || err.Error() != tt.want {
+				t.Errorf("error = %v, want a 409 %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCloseClosedSession(t *testing.T) {
+	repo := &fakeSessionRepository{sessions: []models.Session{{ID: 2, LocationID: 3, Status: models.SessionStatusClosed}}}
+	s := newTestSessionService(repo)
+
+	_, err := s.CloseSession(context.Background(), 2, pkg.AccessScope{LocationID: 3}, 5)
+	if statusCode(err) != http.StatusConflict {
+		t.Errorf("error = %v, want a 409", err)
+	}
+}
+
+func TestRecordAttendance(t *testing.T) {
+	sessions := []models.Session{
+		{ID: 1, LocationID: 3, Status: models.SessionStatusOpen},
+		{ID: 2, LocationID: 3, Status: models.SessionStatusClosed},
+	}
+	toddlers := []models.Toddler{{ID: 8, LocationID: 3}, {ID: 9, LocationID: 4}}
+
+	tests := []struct {
+		name      string
+		sessionID int
+		toddlerID int
+		want      int
+	}{
+		{"present", 1, 8, http.StatusOK},
+		{"toddler of another location", 1, 9, http.StatusNotFound},
+		{"closed session", 2, 8, http.StatusConflict},
+		{"missing session", 3, 8, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeSessionRepository{sessions: sessions}
+			s := newTestSessionService(repo, toddlers...)
+
+			got, err := s.RecordAttendance(tt.sessionID, pkg.AccessScope{LocationID: 3}, 5, requests.RecordAttendanceRequest{ToddlerID: tt.toddlerID})
+			if tt.want == http.StatusOK {
+				if err != nil {
+					t.Fatalf("RecordAttendance: %v", err)
+				}
+				if got.Counts == nil || got.Counts.Attended != 1 || got.Counts.Absent != 9 {
+					t.Errorf("counts = %+v", got.Counts)
+				}
+				return
+			}
+			if statusCode(err) != tt.want {
+				t.Errorf("error = %v, want status %d", err, tt.want)
+			}
+			if len(repo.attendance) != 0 {
+				t.Error("attendance was recorded")
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"grovia/internal/growth"
+	"grovia/internal/models"
+	"grovia/internal/repositories"
+	"log"
+	"time"
+)
+
+const (
+	// shadowQueueSize bounds the predicts waiting for the candidate model;
+	// beyond it predicts are not evaluated rather than slowing requests.
+	shadowQueueSize = 256
+	shadowWorkers   = 2
+	shadowTimeout   = 30 * time.Second
+)
+
+// ShadowEvaluator sends saved predicts to a candidate ML model in the
+// background and stores its answer for comparison. It never changes a
+// predict, and a failing candidate does not affect the request.
+type ShadowEvaluator interface {
+	Submit(predict *models.Predict)
+	Start(ctx context.Context)
+}
+
+type shadowEvaluator struct {
+	ml    MLClient
+	repo  repositories.ShadowPredictionRepository
+	queue chan models.Predict
+}
+
+// Submit implements ShadowEvaluator. It does not block.
+func (s *shadowEvaluator) Submit(predict *models.Predict) {
+	select {
+	case s.queue <- *predict:
+	default:
+		log.Printf("shadow: queue full, predict %d not evaluated", predict.ID)
+	}
+}
+
+// Start implements ShadowEvaluator. The workers stop when ctx is cancelled.
+func (s *shadowEvaluator) Start(ctx context.Context) {
+	for i := 0; i < shadowWorkers; i++ {
+		go s.work(ctx)
+	}
+}
+
+func (s *shadowEvaluator) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case predict := <-s.queue:
+			s.evaluate(ctx, &predict)
+		}
+	}
+}
+
+// evaluate asks the candidate model about one predict and stores the
+// answer, or the failure, with the primary and WHO statuses of the
+// predict. WHO is computed here when the predictor did not record it.
+func (s *shadowEvaluator) evaluate(ctx context.Context, predict *models.Predict) {
+	shadow := &models.ShadowPrediction{
+		PredictID:                predict.ID,
+		PrimarySource:            predict.Source,
+		PrimaryZscore:            predict.Zscore,
+		PrimaryNutritionalStatus: predict.NutritionalStatus,
+		WhoZscore:                predict.WhoZscore,
+		WhoNutritionalStatus:     predict.WhoNutritionalStatus,
+	}
+
+	if shadow.WhoNutritionalStatus == nil {
+		if sex, err := growth.ParseSex(predict.Sex); err == nil {
+			if who, err := growth.HeightForAge(sex, predict.Age, predict.Height); err == nil {
+				shadow.WhoZscore = &who.Zscore
+				shadow.WhoNutritionalStatus = &who.Status
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, shadowTimeout)
+	defer cancel()
+
+	result, err := s.ml.PredictIndividual(ctx, MLPredictRequest{Height: predict.Height, Age: predict.Age, Gender: predict.Sex})
+	if err != nil {
+		message := err.Error()
+		shadow.Error = &message
+	} else {
+		latency := int(result.Latency.Milliseconds())
+		shadow.Zscore = &result.Zscore
+		shadow.NutritionalStatus = &result.NutritionalStatus
+		shadow.MLLatencyMs = &latency
+		if result.ModelName != "" {
+			shadow.ModelName = &result.ModelName
+		}
+		if result.ModelVersion != "" {
+			shadow.ModelVersion = &result.ModelVersion
+		}
+		if len(result.Raw) > 0 {
+			raw := string(result.Raw)
+			shadow.MLResponse = &raw
+		}
+	}
+
+	if err := s.repo.CreateShadowPrediction(shadow); err != nil {
+		log.Printf("shadow: failed to store the evaluation of predict %d: %v", predict.ID, err)
+	}
+}
+
+func NewShadowEvaluator(ml MLClient, repo repositories.ShadowPredictionRepository) ShadowEvaluator {
+	return &shadowEvaluator{ml: ml, repo: repo, queue: make(chan models.Predict, shadowQueueSize)}
+}
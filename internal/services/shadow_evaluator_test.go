@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"grovia/internal/growth"
+	"grovia/internal/models"
+	"grovia/internal/repositories"
+	"grovia/pkg"
+	"testing"
+	"time"
+)
+
+type fakeShadowRepository struct {
+	created       []*models.ShadowPrediction
+	summary       repositories.ShadowSummaryRow
+	confusion     map[string][]repositories.ShadowConfusionRow
+	disagreements []repositories.ShadowDisagreementRow
+
+	filter repositories.ShadowFilter
+}
+
+func (f *fakeShadowRepository) CreateShadowPrediction(shadow *models.ShadowPrediction) error {
+	f.created = append(f.created, shadow)
+	return nil
+}
+
+func (f *fakeShadowRepository) GetShadowSummary(filter repositories.ShadowFilter) (*repositories.ShadowSummaryRow, error) {
+	f.filter = filter
+	return &f.summary, nil
+}
+
+func (f *fakeShadowRepository) GetShadowConfusion(filter repositories.ShadowFilter, against string) ([]repositories.ShadowConfusionRow, error) {
+	return f.confusion[against], nil
+}
+
+func (f *fakeShadowRepository) GetShadowDisagreements(filter repositories.ShadowFilter, limit int) ([]repositories.ShadowDisagreementRow, error) {
+	return f.disagreements, nil
+}
+
+func shadowTestPredict() *models.Predict {
+	return &models.Predict{
+		ID:                7,
+		Height:            80,
+		Age:               24,
+		Sex:               "M",
+		Zscore:            -1.2,
+		NutritionalStatus: growth.StatusNormal,
+		Source:            pkg.PredictSourceML,
+	}
+}
+
+func TestShadowEvaluatorStoresTheCandidateAnswer(t *testing.T) {
+	repo := &fakeShadowRepository{}
+	ml := &fakeMLClient{response: &MLPredictResponse{
+		Zscore:            -2.4,
+		NutritionalStatus: growth.StatusStunted,
+		ModelVersion:      "2026.11.0-rc1",
+		Raw:               []byte(`{"zscore": -2.4}`),
+		Latency:           30 * time.Millisecond,
+	}}
+	evaluator := NewShadowEvaluator(ml, repo).(*shadowEvaluator)
+
+	evaluator.evaluate(context.Background(), shadowTestPredict())
+
+	if len(repo.created) != 1 {
+		t.Fatalf("%d shadow predictions stored, want 1", len(repo.created))
+	}
+	got := repo.created[0]
+	if got.PredictID != 7 || got.PrimarySource != pkg.PredictSourceML || got.PrimaryNutritionalStatus != growth.StatusNormal {
+		t.Errorf("primary snapshot = %+v", got)
+	}
+	if got.NutritionalStatus == nil || *got.NutritionalStatus != growth.StatusStunted || *got.ModelVersion != "2026.11.0-rc1" || *got.MLLatencyMs != 30 {
+		t.Errorf("candidate answer = %+v", got)
+	}
+	if got.ModelName != nil || got.Error != nil {
+		t.Errorf("model name %v, error %v, want neither", got.ModelName, got.Error)
+	}
+	// The predict had no WHO reference, so the evaluator computed one.
+	if got.WhoNutritionalStatus == nil || got.WhoZscore == nil {
+		t.Errorf("WHO reference missing: %+v", got)
+	}
+}
+
+func TestShadowEvaluatorStoresFailures(t *testing.T) {
+	repo := &fakeShadowRepository{}
+	ml := &fakeMLClient{err: pkg.NewBadGatewayError("ML API mengembalikan status 500 Internal Server Error")}
+	evaluator := NewShadowEvaluator(ml, repo).(*shadowEvaluator)
+
+	predict := shadowTestPredict()
+	whoZscore, whoStatus := -1.1, growth.StatusNormal
+	predict.WhoZscore, predict.WhoNutritionalStatus = &whoZscore, &whoStatus
+	evaluator.evaluate(context.Background(), predict)
+
+	if len(repo.created) != 1 {
+		t.Fatalf("%d shadow predictions stored, want 1", len(repo.created))
+	}
+	got := repo.created[0]
+	if got.Error == nil || got.NutritionalStatus != nil || got.Zscore != nil {
+		t.Errorf("failure stored as %+v", got)
+	}
+	if got.WhoZscore != predict.WhoZscore {
+		t.Errorf("recorded WHO reference not kept")
+	}
+}
+
+func TestShadowEvaluatorSubmitDoesNotBlock(t *testing.T) {
+	evaluator := NewShadowEvaluator(&fakeMLClient{}, &fakeShadowRepository{}).(*shadowEvaluator)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < shadowQueueSize+10; i++ {
+			evaluator.Submit(shadowTestPredict())
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Submit blocked on a full queue")
+	}
+	if len(evaluator.queue) != shadowQueueSize {
+		t.Errorf("queue holds %d predicts, want %d", len(evaluator.queue), shadowQueueSize)
+	}
+}
+
+func TestShadowEvaluatorWorkers(t *testing.T) {
+	repo := &fakeShadowRepository{}
+	ml := &fakeMLClient{response: &MLPredictResponse{Zscore: 0.1, NutritionalStatus: growth.StatusNormal}}
+	evaluator := NewShadowEvaluator(ml, repo).(*shadowEvaluator)
+
+	// One worker, so the fake repository is not written concurrently.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stopped := make(chan struct{})
+	go func() {
+		evaluator.work(ctx)
+		close(stopped)
+	}()
+
+	evaluator.Submit(shadowTestPredict())
+	deadline := time.After(time.Second)
+	for len(evaluator.queue) > 0 {
+		select {
+		case <-deadline:
+			t.Fatal("submitted predict was not evaluated")
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	cancel()
+	<-stopped
+	if len(repo.created) != 1 {
+		t.Errorf("%d shadow predictions stored, want 1", len(repo.created))
+	}
+}
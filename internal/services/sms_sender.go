@@ -0,0 +1,91 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"grovia/configs"
+	"grovia/pkg"
+	"log"
+	"net/http"
+	"time"
+)
+
+// SMSSender delivers a text message through an SMS gateway.
+type SMSSender interface {
+	Send(ctx context.Context, phoneNumber, message string) error
+}
+
+type consoleSender struct{}
+
+// Send implements SMSSender by writing the message to the log.
+func (c *consoleSender) Send(ctx context.Context, phoneNumber, message string) error {
+	log.Printf("sms to %s: %s", phoneNumber, message)
+	return nil
+}
+
+type httpSender struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+type httpSMSRequest struct {
+	To      string `json:"to"`
+	Message string `json:"message"`
+}
+
+// Send implements SMSSender by posting the message as JSON to the gateway.
+func (h *httpSender) Send(ctx context.Context, phoneNumber, message string) error {
+	body, err := json.Marshal(httpSMSRequest{To: phoneNumber, Message: message})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+h.apiKey)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sms gateway returned %s", resp.Status)
+	}
+
+	return nil
+}
+
+// NewSMSSender builds the gateway selected in the config. The console
+// gateway logs codes in plain text, so it is only used when sms_gateway
+// names it explicitly.
+func NewSMSSender(cfg configs.VerificationConfig) (SMSSender, error) {
+	switch cfg.SMSGateway {
+	case pkg.SMSGatewayConsole:
+		log.Printf("sms gateway is console: OTP codes are written to the log")
+		return &consoleSender{}, nil
+	case "":
+		return nil, errors.New("sms_gateway is not set (use http, or console for development)")
+	case pkg.SMSGatewayHTTP:
+		if cfg.SMSGatewayURL == "" {
+			return nil, errors.New("SMS_GATEWAY_URL is not set")
+		}
+		return &httpSender{
+			url:    cfg.SMSGatewayURL,
+			apiKey: cfg.SMSGatewayAPIKey,
+			client: &http.Client{Timeout: 10 * time.Second},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown sms gateway %q", cfg.SMSGateway)
+	}
+}
@@ -2,45 +2,124 @@ package services
 
 import (
 	"context"
+	"errors"
+	"grovia/internal/charts"
 	"grovia/internal/dto/requests"
 	"grovia/internal/dto/responses"
+	"grovia/internal/growth"
 	"grovia/internal/models"
 	"grovia/internal/repositories"
+	"grovia/internal/storage"
 	"grovia/pkg"
 	"math"
 	"strconv"
+
+	"gorm.io/gorm"
 )
 
 type ToddlerService interface {
-	CreateToddler(req requests.CreateToddlerRequest, userID int) (*responses.ToddlerResponse, *responses.PredictResponse, error)
-	CreateToddlerWithParent(toddlerReq requests.CreateToddlerRequest, parentReq requests.CreateParentRequest, userID int) (*responses.ToddlerResponse, *responses.ParentResponse, *responses.PredictResponse, error)
-	GetAllToddler(locationID int, name, pageStr, limitStr string) ([]responses.ToddlerResponse, *responses.PaginationMeta, error)
-	GetToddlerByID(id, locationID int) (*responses.ToddlerResponse, error)
-	UpdateToddlerByID(ctx context.Context, id, locationID, userID int, req requests.UpdateToddlerRequest) (*responses.ToddlerResponse, *responses.PredictResponse, error)
-	DeleteToddlerByID(id, locationID, userID int) error
+	CreateToddler(ctx context.Context, req requests.CreateToddlerRequest, userID int) (*responses.ToddlerResponse, *responses.PredictResponse, error)
+	CreateToddlerWithParent(ctx context.Context, toddlerReq requests.CreateToddlerRequest, parentReq requests.CreateParentRequest, userID int) (*responses.ToddlerResponse, *responses.ParentResponse, *responses.PredictResponse, error)
+	GetAllToddler(scope pkg.AccessScope, name, pageStr, limitStr string) ([]responses.ToddlerResponse, *responses.PaginationMeta, error)
+	GetToddlerByID(id int, scope pkg.AccessScope) (*responses.ToddlerResponse, error)
+	UpdateToddlerByID(ctx context.Context, id int, scope pkg.AccessScope, userID int, req requests.UpdateToddlerRequest) (*responses.ToddlerResponse, *responses.PredictResponse, error)
+	DeleteToddlerByID(ctx context.Context, id int, scope pkg.AccessScope, userID int) error
 	CheckToddlerExists(phoneNumber, name string) (bool, *models.Toddler, error)
 	GetAllToddlerAllLocation(name, pageStr, limitStr string) ([]responses.ToddlerResponse, *responses.PaginationMeta, error)
-	UpdateToddlerByIDWithoutPredict(ctx context.Context, id, locationID, userID int, req requests.UpdateToddlerRequest) (*responses.ToddlerResponse, error)
+	UpdateToddlerByIDWithoutPredict(ctx context.Context, id int, scope pkg.AccessScope, userID int, req requests.UpdateToddlerRequest) (*responses.ToddlerResponse, error)
+	GetFalteringToddlers(scope pkg.AccessScope) ([]responses.GrowthFalteringResponse, error)
+	GetGrowthChart(id int, scope pkg.AccessScope, indicator, format string) ([]byte, string, error)
 }
 
 type toddlerService struct {
 	repo       repositories.ToddlerRepository
 	parentRepo repositories.ParentRepository
-	s3         *S3Service
+	storage    storage.ObjectStorage
 	predict    PredictService
+	growth     GrowthService
+	audit      AuditService
+}
+
+func (t *toddlerService) GetGrowthChart(id int, scope pkg.AccessScope, indicator, format string) ([]byte, string, error) {
+	chartIndicator := growth.Indicator(indicator)
+	if indicator == "" {
+		chartIndicator = growth.IndicatorWeightForAge
+	}
+	if chartIndicator != growth.IndicatorWeightForAge && chartIndicator != growth.IndicatorHeightForAge {
+		return nil, "", pkg.NewBadRequestError("indicator harus salah satu dari: wfa hfa")
+	}
+	if format == "" {
+		format = "svg"
+	}
+	if format != "svg" && format != "png" {
+		return nil, "", pkg.NewBadRequestError("format harus salah satu dari: svg png")
+	}
+
+	toddler, err := t.repo.GetToddlerByID(id, scope)
+	if err != nil {
+		return nil, "", pkg.NewNotFoundError("Toddler tidak ditemukan")
+	}
+
+	sex, err := growth.ParseSex(toddler.Sex)
+	if err != nil {
+		return nil, "", pkg.NewUnprocessableEntityError("Jenis kelamin toddler tidak dikenali")
+	}
+
+	predicts, err := t.predict.GetAllPredictByToddlerID(scope, toddler.ID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	title := "KMS Berat Badan menurut Umur - " + toddler.Name
+	if chartIndicator == growth.IndicatorHeightForAge {
+		title = "KMS Tinggi Badan menurut Umur - " + toddler.Name
+	}
+
+	chart := charts.Chart{Indicator: chartIndicator, Sex: sex, Title: title}
+	for _, p := range predicts {
+		if p.Age < 0 || p.Age > growth.MaxAgeMonths {
+			continue
+		}
+		value := p.Height
+		if chartIndicator == growth.IndicatorWeightForAge {
+			if p.Weight == nil {
+				continue
+			}
+			value = *p.Weight
+		}
+		chart.Points = append(chart.Points, charts.Point{AgeMonths: float64(p.Age), Value: value})
+	}
+
+	if format == "png" {
+		data, err := chart.PNG()
+		if err != nil {
+			return nil, "", pkg.NewInternalServerError("Gagal membuat grafik pertumbuhan")
+		}
+		return data, "image/png", nil
+	}
+
+	data, err := chart.SVG()
+	if err != nil {
+		return nil, "", pkg.NewInternalServerError("Gagal membuat grafik pertumbuhan")
+	}
+	return data, "image/svg+xml", nil
 }
 
-func (t *toddlerService) UpdateToddlerByIDWithoutPredict(ctx context.Context, id int, locationID, userID int, req requests.UpdateToddlerRequest) (*responses.ToddlerResponse, error) {
+func (t *toddlerService) GetFalteringToddlers(scope pkg.AccessScope) ([]responses.GrowthFalteringResponse, error) {
+	return t.growth.GetFaltering(scope)
+}
+
+func (t *toddlerService) UpdateToddlerByIDWithoutPredict(ctx context.Context, id int, scope pkg.AccessScope, userID int, req requests.UpdateToddlerRequest) (*responses.ToddlerResponse, error) {
 	if err := pkg.ValidateStruct(req); err != nil {
 		return nil, pkg.NewBadRequestError(err.Error())
 	}
 
-	var url string
+	var key string
 	var err error
 	if req.ProfilePicture != nil && req.ProfilePicture.Filename != "" && req.ProfilePicture.Size > 0 {
-		url, err = t.s3.UploadFile(ctx, req.ProfilePicture, "toddlers")
+		key, err = uploadFile(ctx, t.storage, req.ProfilePicture, "toddlers")
 		if err != nil {
-			return nil, pkg.NewInternalServerError("Gagal upload foto")
+			return nil, err
 		}
 	}
 
@@ -62,33 +141,27 @@ func (t *toddlerService) UpdateToddlerByIDWithoutPredict(ctx context.Context, id
 	if req.PhoneNumber != nil {
 		toddlerMapping.ParentID = parentID
 	}
-	if url != "" {
-		toddlerMapping.ProfilePicture = url
+	if key != "" {
+		toddlerMapping.ProfilePicture = key
 	}
 	if req.LocationID != nil {
 		toddlerMapping.LocationID = *req.LocationID
 	}
-	toddler, err := t.repo.UpdateToddlerByID(id, locationID, &toddlerMapping)
+
+	before, err := t.repo.GetToddlerByID(id, scope)
 	if err != nil {
-		return nil, pkg.NewInternalServerError("Gagal update data toddler")
+		return nil, pkg.NewNotFoundError("Toddler tidak ditemukan")
 	}
 
-	toddlerResponse := responses.ToddlerResponse{
-		ID:                toddler.ID,
-		ParentID:          toddler.ParentID,
-		LocationID:        toddler.LocationID,
-		CreatedByID:       toddler.CreatedByID,
-		UpdatedByID:       toddler.UpdatedByID,
-		Name:              toddler.Name,
-		Birthdate:         toddler.Birthdate,
-		Sex:               toddler.Sex,
-		Height:            toddler.Height,
-		NutritionalStatus: toddler.NutritionalStatus,
-		ProfilePicture:    toddler.ProfilePicture,
-		CreatedAt:         toddler.CreatedAt,
-		UpdatedAt:         toddler.UpdatedAt,
+	toddler, err := t.repo.UpdateToddlerByID(id, scope, &toddlerMapping)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("Gagal update data toddler")
 	}
 
+	t.audit.Record(ctx, pkg.AuditActionUpdate, pkg.AuditEntityToddler, id, before, toddler)
+
+	toddlerResponse := toToddlerResponse(t.storage, toddler)
+
 	return &toddlerResponse, nil
 }
 
@@ -116,21 +189,7 @@ func (t *toddlerService) GetAllToddlerAllLocation(name, pageStr, limitStr string
 
 	var toddlerResponses []responses.ToddlerResponse
 	for _, v := range toddlers {
-		toddlerResponses = append(toddlerResponses, responses.ToddlerResponse{
-			ID:                v.ID,
-			ParentID:          v.ParentID,
-			LocationID:        v.LocationID,
-			CreatedByID:       v.CreatedByID,
-			UpdatedByID:       v.UpdatedByID,
-			Name:              v.Name,
-			Birthdate:         v.Birthdate,
-			Sex:               v.Sex,
-			Height:            v.Height,
-			ProfilePicture:    v.ProfilePicture,
-			NutritionalStatus: v.NutritionalStatus,
-			CreatedAt:         v.CreatedAt,
-			UpdatedAt:         v.UpdatedAt,
-		})
+		toddlerResponses = append(toddlerResponses, toToddlerResponse(t.storage, &v))
 	}
 
 	meta := responses.PaginationMeta{
@@ -151,12 +210,14 @@ func (t *toddlerService) CheckToddlerExists(phoneNumber, name string) (bool, *mo
 	return t.repo.FindToddlerByName(parent.ID, name)
 }
 
-func (t *toddlerService) CreateToddler(req requests.CreateToddlerRequest, userID int) (*responses.ToddlerResponse, *responses.PredictResponse, error) {
+func (t *toddlerService) CreateToddler(ctx context.Context, req requests.CreateToddlerRequest, userID int) (*responses.ToddlerResponse, *responses.PredictResponse, error) {
 	parent, err := t.parentRepo.FindParentByPhoneNumber(req.PhoneNumber)
-
-	if parent == nil {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil, pkg.NewNotFoundError("Parent dengan nomor telepon " + req.PhoneNumber + " tidak ditemukan")
 	}
+	if err != nil {
+		return nil, nil, pkg.NewInternalServerError("Gagal memproses data parent")
+	}
 
 	toddlerMapping := models.Toddler{
 		ParentID:    parent.ID,
@@ -170,8 +231,11 @@ func (t *toddlerService) CreateToddler(req requests.CreateToddlerRequest, userID
 		LocationID:  parent.LocationID,
 	}
 
-	if err != nil {
-		return nil, nil, pkg.NewInternalServerError("Gagal memproses data parent")
+	if req.Weight > 0 {
+		toddlerMapping.Weight = &req.Weight
+	}
+	if req.HeadCircumference > 0 {
+		toddlerMapping.HeadCircumference = &req.HeadCircumference
 	}
 
 	toddler, err := t.repo.CreateToddler(&toddlerMapping)
@@ -180,10 +244,10 @@ func (t *toddlerService) CreateToddler(req requests.CreateToddlerRequest, userID
 		return nil, nil, pkg.NewInternalServerError("Gagal membuat toddler")
 	}
 
-	predict, err := t.predict.CreateIndividualPredict(req, toddler.LocationID, toddler.ID, userID)
+	predict, err := t.predict.CreateIndividualPredict(ctx, req, toddler.LocationID, toddler.ID, userID)
 
 	if err != nil {
-		return nil, nil, pkg.NewInternalServerError("Gagal membuat prediksi")
+		return nil, nil, err
 	}
 
 	toddlerModel := models.Toddler{
@@ -197,44 +261,23 @@ func (t *toddlerService) CreateToddler(req requests.CreateToddlerRequest, userID
 		NutritionalStatus: predict.NutritionalStatus,
 	}
 
-	_, err = t.repo.UpdateToddlerByID(toddler.ID, parent.LocationID, &toddlerModel)
+	_, err = t.repo.UpdateToddlerByID(toddler.ID, pkg.LocationScope(parent.LocationID), &toddlerModel)
 
 	if err != nil {
 		return nil, nil, pkg.NewInternalServerError("Gagal update nutritional status")
 	}
 
-	toddlerResponse := responses.ToddlerResponse{
-		ID:                toddler.ID,
-		ParentID:          toddler.ParentID,
-		LocationID:        toddler.LocationID,
-		CreatedByID:       toddler.CreatedByID,
-		UpdatedByID:       toddler.UpdatedByID,
-		Name:              toddler.Name,
-		Birthdate:         toddler.Birthdate,
-		Sex:               toddler.Sex,
-		Height:            toddler.Height,
-		NutritionalStatus: predict.NutritionalStatus,
-		CreatedAt:         toddler.CreatedAt,
-		UpdatedAt:         toddler.UpdatedAt,
-	}
-
-	predictResponse := responses.PredictResponse{
-		ID:                predict.ID,
-		ToddlerID:         predict.ToddlerID,
-		CreatedByID:       predict.CreatedByID,
-		Height:            predict.Height,
-		Age:               predict.Age,
-		Sex:               predict.Sex,
-		Zscore:            predict.Zscore,
-		NutritionalStatus: predict.NutritionalStatus,
-		CreatedAt:         predict.CreatedAt,
-		UpdatedAt:         predict.UpdatedAt,
-	}
+	toddler.NutritionalStatus = predict.NutritionalStatus
+
+	t.audit.Record(ctx, pkg.AuditActionCreate, pkg.AuditEntityToddler, toddler.ID, nil, toddler)
 
-	return &toddlerResponse, &predictResponse, nil
+	toddlerResponse := toToddlerResponse(t.storage, toddler)
+
+	return &toddlerResponse, predict, nil
 }
 
 func (t *toddlerService) CreateToddlerWithParent(
+	ctx context.Context,
 	toddlerReq requests.CreateToddlerRequest,
 	parentReq requests.CreateParentRequest,
 	userID int,
@@ -264,6 +307,8 @@ func (t *toddlerService) CreateToddlerWithParent(
 		return nil, nil, nil, pkg.NewInternalServerError("Gagal membuat parent")
 	}
 
+	t.audit.Record(ctx, pkg.AuditActionCreate, pkg.AuditEntityParent, parent.ID, nil, parent)
+
 	toddlerMapping := models.Toddler{
 		ParentID:    parent.ID,
 		CreatedByID: userID,
@@ -276,14 +321,21 @@ func (t *toddlerService) CreateToddlerWithParent(
 		LocationID:  toddlerReq.LocationID,
 	}
 
+	if toddlerReq.Weight > 0 {
+		toddlerMapping.Weight = &toddlerReq.Weight
+	}
+	if toddlerReq.HeadCircumference > 0 {
+		toddlerMapping.HeadCircumference = &toddlerReq.HeadCircumference
+	}
+
 	toddler, err := t.repo.CreateToddler(&toddlerMapping)
 	if err != nil {
 		return nil, nil, nil, pkg.NewInternalServerError("Gagal membuat toddler")
 	}
 
-	predict, err := t.predict.CreateIndividualPredict(toddlerReq, parentReq.LocationID, toddler.ID, userID)
+	predict, err := t.predict.CreateIndividualPredict(ctx, toddlerReq, parentReq.LocationID, toddler.ID, userID)
 	if err != nil {
-		return nil, nil, nil, pkg.NewInternalServerError("Gagal membuat prediksi")
+		return nil, nil, nil, err
 	}
 
 	toddlerModel := models.Toddler{
@@ -300,28 +352,16 @@ func (t *toddlerService) CreateToddlerWithParent(
 		NutritionalStatus: predict.NutritionalStatus,
 	}
 
-	_, err = t.repo.UpdateToddlerByID(toddler.ID, parentReq.LocationID, &toddlerModel)
+	_, err = t.repo.UpdateToddlerByID(toddler.ID, pkg.LocationScope(parentReq.LocationID), &toddlerModel)
 	if err != nil {
 		return nil, nil, nil, pkg.NewInternalServerError("Gagal update nutritional status")
 	}
 
 	toddler.NutritionalStatus = predict.NutritionalStatus
 
-	toddlerResponse := responses.ToddlerResponse{
-		ID:                toddler.ID,
-		ParentID:          toddler.ParentID,
-		LocationID:        toddler.LocationID,
-		CreatedByID:       toddler.CreatedByID,
-		UpdatedByID:       toddler.UpdatedByID,
-		Name:              toddler.Name,
-		Birthdate:         toddler.Birthdate,
-		Sex:               toddler.Sex,
-		Height:            toddler.Height,
-		ProfilePicture:    toddler.ProfilePicture,
-		NutritionalStatus: toddler.NutritionalStatus,
-		CreatedAt:         toddler.CreatedAt,
-		UpdatedAt:         toddler.UpdatedAt,
-	}
+	t.audit.Record(ctx, pkg.AuditActionCreate, pkg.AuditEntityToddler, toddler.ID, nil, toddler)
+
+	toddlerResponse := toToddlerResponse(t.storage, toddler)
 
 	parentResp := responses.ParentResponse{
 		ID:          parent.ID,
@@ -337,31 +377,25 @@ func (t *toddlerService) CreateToddlerWithParent(
 		UpdatedAt:   parent.UpdatedAt,
 	}
 
-	predictResponse := responses.PredictResponse{
-		ID:                predict.ID,
-		ToddlerID:         predict.ToddlerID,
-		CreatedByID:       predict.CreatedByID,
-		Height:            predict.Height,
-		Age:               predict.Age,
-		Sex:               predict.Sex,
-		Zscore:            predict.Zscore,
-		NutritionalStatus: predict.NutritionalStatus,
-		CreatedAt:         predict.CreatedAt,
-		UpdatedAt:         predict.UpdatedAt,
-	}
-
-	return &toddlerResponse, &parentResp, &predictResponse, nil
+	return &toddlerResponse, &parentResp, predict, nil
 }
 
-func (t *toddlerService) DeleteToddlerByID(id int, locationID, userID int) error {
-	err := t.repo.DeleteToddlerByID(id, locationID, userID)
+func (t *toddlerService) DeleteToddlerByID(ctx context.Context, id int, scope pkg.AccessScope, userID int) error {
+	before, err := t.repo.GetToddlerByID(id, scope)
+	if err != nil {
+		return pkg.NewNotFoundError("Toddler tidak ditemukan")
+	}
+
+	err = t.repo.DeleteToddlerByID(id, scope, userID)
 	if err != nil {
 		return pkg.NewInternalServerError("Gagal menghapus toddler")
 	}
+
+	t.audit.Record(ctx, pkg.AuditActionDelete, pkg.AuditEntityToddler, id, before, nil)
 	return nil
 }
 
-func (t *toddlerService) GetAllToddler(locationID int, name, pageStr, limitStr string) ([]responses.ToddlerResponse, *responses.PaginationMeta, error) {
+func (t *toddlerService) GetAllToddler(scope pkg.AccessScope, name, pageStr, limitStr string) ([]responses.ToddlerResponse, *responses.PaginationMeta, error) {
 	page, _ := strconv.Atoi(pageStr)
 	limit, _ := strconv.Atoi(limitStr)
 
@@ -375,7 +409,7 @@ func (t *toddlerService) GetAllToddler(locationID int, name, pageStr, limitStr s
 
 	offset := (page - 1) * limit
 
-	toddlers, total, err := t.repo.GetAllToddler(locationID, limit, offset, name)
+	toddlers, total, err := t.repo.GetAllToddler(scope, limit, offset, name)
 
 	if err != nil {
 		return nil, nil, pkg.NewInternalServerError("Gagal mengambil data toddler")
@@ -386,21 +420,7 @@ func (t *toddlerService) GetAllToddler(locationID int, name, pageStr, limitStr s
 	var toddlerResponse []responses.ToddlerResponse
 
 	for _, v := range toddlers {
-		toddlerResponse = append(toddlerResponse, responses.ToddlerResponse{
-			ID:                v.ID,
-			ParentID:          v.ParentID,
-			LocationID:        v.LocationID,
-			CreatedByID:       v.CreatedByID,
-			UpdatedByID:       v.UpdatedByID,
-			Name:              v.Name,
-			Birthdate:         v.Birthdate,
-			Sex:               v.Sex,
-			Height:            v.Height,
-			ProfilePicture:    v.ProfilePicture,
-			NutritionalStatus: v.NutritionalStatus,
-			CreatedAt:         v.CreatedAt,
-			UpdatedAt:         v.UpdatedAt,
-		})
+		toddlerResponse = append(toddlerResponse, toToddlerResponse(t.storage, &v))
 	}
 
 	meta := responses.PaginationMeta{
@@ -413,34 +433,26 @@ func (t *toddlerService) GetAllToddler(locationID int, name, pageStr, limitStr s
 	return toddlerResponse, &meta, err
 }
 
-func (t *toddlerService) GetToddlerByID(id int, locationID int) (*responses.ToddlerResponse, error) {
-	toddler, err := t.repo.GetToddlerByID(id, locationID)
+func (t *toddlerService) GetToddlerByID(id int, scope pkg.AccessScope) (*responses.ToddlerResponse, error) {
+	toddler, err := t.repo.GetToddlerByID(id, scope)
 
 	if err != nil {
 		return nil, pkg.NewNotFoundError("Toddler tidak ditemukan")
 	}
 
-	toddlerResponse := responses.ToddlerResponse{
-		ID:                toddler.ID,
-		ParentID:          toddler.ParentID,
-		LocationID:        toddler.LocationID,
-		CreatedByID:       toddler.CreatedByID,
-		UpdatedByID:       toddler.UpdatedByID,
-		Name:              toddler.Name,
-		Birthdate:         toddler.Birthdate,
-		Sex:               toddler.Sex,
-		Height:            toddler.Height,
-		ProfilePicture:    toddler.ProfilePicture,
-		NutritionalStatus: toddler.NutritionalStatus,
-		CreatedAt:         toddler.CreatedAt,
-		UpdatedAt:         toddler.UpdatedAt,
+	faltering, err := t.growth.GetToddlerFaltering(scope, toddler.ID)
+	if err != nil {
+		return nil, err
 	}
 
+	toddlerResponse := toToddlerResponse(t.storage, toddler)
+	toddlerResponse.Growth = faltering
+
 	return &toddlerResponse, nil
 }
 
 func (t *toddlerService) UpdateToddlerByID(
-	ctx context.Context, id, locationID, userID int,
+	ctx context.Context, id int, scope pkg.AccessScope, userID int,
 	req requests.UpdateToddlerRequest,
 ) (*responses.ToddlerResponse, *responses.PredictResponse, error) {
 
@@ -448,6 +460,11 @@ func (t *toddlerService) UpdateToddlerByID(
 		return nil, nil, pkg.NewBadRequestError(err.Error())
 	}
 
+	current, err := t.repo.GetToddlerByID(id, scope)
+	if err != nil {
+		return nil, nil, pkg.NewNotFoundError("Toddler tidak ditemukan")
+	}
+
 	toddlerRequest := requests.CreateToddlerRequest{}
 	if req.Name != nil {
 		toddlerRequest.Name = *req.Name
@@ -461,6 +478,12 @@ func (t *toddlerService) UpdateToddlerByID(
 	if req.Height != nil {
 		toddlerRequest.Height = *req.Height
 	}
+	if req.Weight != nil {
+		toddlerRequest.Weight = *req.Weight
+	}
+	if req.HeadCircumference != nil {
+		toddlerRequest.HeadCircumference = *req.HeadCircumference
+	}
 	if req.NutritionalStatus != nil {
 		toddlerRequest.NutritionalStatus = *req.NutritionalStatus
 	}
@@ -471,23 +494,25 @@ func (t *toddlerService) UpdateToddlerByID(
 		toddlerRequest.PhoneNumber = *req.PhoneNumber
 	}
 
+	// The photo is checked before the predict is recorded, so a rejected
+	// upload leaves nothing behind.
+	var key string
+	if req.ProfilePicture != nil && req.ProfilePicture.Filename != "" && req.ProfilePicture.Size > 0 {
+		key, err = uploadFile(ctx, t.storage, req.ProfilePicture, "toddlers")
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	predict, err := t.predict.CreateIndividualPredict(
+		ctx,
 		toddlerRequest,
-		locationID,
+		current.LocationID,
 		id,
 		userID,
 	)
 	if err != nil {
-		return nil, nil, pkg.NewInternalServerError("Gagal membuat prediksi")
-	}
-
-	var url string
-
-	if req.ProfilePicture != nil && req.ProfilePicture.Filename != "" && req.ProfilePicture.Size > 0 {
-		url, err = t.s3.UploadFile(ctx, req.ProfilePicture, "toddlers")
-		if err != nil {
-			return nil, nil, pkg.NewInternalServerError("Gagal upload foto")
-		}
+		return nil, nil, err
 	}
 
 	var parentID int
@@ -514,55 +539,58 @@ func (t *toddlerService) UpdateToddlerByID(
 	if req.Height != nil {
 		toddlerMapping.Height = *req.Height
 	}
+	if req.Weight != nil {
+		toddlerMapping.Weight = req.Weight
+	}
+	if req.HeadCircumference != nil {
+		toddlerMapping.HeadCircumference = req.HeadCircumference
+	}
 	if req.PhoneNumber != nil {
 		toddlerMapping.ParentID = parentID
 	}
-	if url != "" {
-		toddlerMapping.ProfilePicture = url
+	if key != "" {
+		toddlerMapping.ProfilePicture = key
 	}
 	if req.LocationID != nil {
 		toddlerMapping.LocationID = *req.LocationID
 	}
 	toddlerMapping.NutritionalStatus = predict.NutritionalStatus
 
-	toddler, err := t.repo.UpdateToddlerByID(id, locationID, &toddlerMapping)
+	toddler, err := t.repo.UpdateToddlerByID(id, scope, &toddlerMapping)
 	if err != nil {
 		return nil, nil, pkg.NewInternalServerError("Gagal update data toddler")
 	}
 
-	toddlerResponse := responses.ToddlerResponse{
-		ID:                toddler.ID,
-		ParentID:          toddler.ParentID,
-		LocationID:        toddler.LocationID,
-		CreatedByID:       toddler.CreatedByID,
-		UpdatedByID:       toddler.UpdatedByID,
-		Name:              toddler.Name,
-		Birthdate:         toddler.Birthdate,
-		Sex:               toddler.Sex,
-		Height:            toddler.Height,
-		NutritionalStatus: toddler.NutritionalStatus,
-		ProfilePicture:    toddler.ProfilePicture,
-		CreatedAt:         toddler.CreatedAt,
-		UpdatedAt:         toddler.UpdatedAt,
-	}
-
-	predictResponse := responses.PredictResponse{
-		ID:                predict.ID,
-		ToddlerID:         predict.ToddlerID,
-		CreatedByID:       predict.CreatedByID,
-		Name:              predict.Name,
-		Height:            predict.Height,
-		Age:               predict.Age,
-		Sex:               predict.Sex,
-		Zscore:            predict.Zscore,
-		NutritionalStatus: predict.NutritionalStatus,
-		CreatedAt:         predict.CreatedAt,
-		UpdatedAt:         predict.UpdatedAt,
-	}
+	t.audit.Record(ctx, pkg.AuditActionUpdate, pkg.AuditEntityToddler, id, current, toddler)
+
+	toddlerResponse := toToddlerResponse(t.storage, toddler)
 
-	return &toddlerResponse, &predictResponse, nil
+	return &toddlerResponse, predict, nil
+}
+
+// toToddlerResponse maps a toddler for a response. The stored picture key
+// becomes a signed URL.
+func toToddlerResponse(store storage.ObjectStorage, toddler *models.Toddler) responses.ToddlerResponse {
+	return responses.ToddlerResponse{
+		ID:                     toddler.ID,
+		ParentID:               toddler.ParentID,
+		LocationID:             toddler.LocationID,
+		CreatedByID:            toddler.CreatedByID,
+		UpdatedByID:            toddler.UpdatedByID,
+		Name:                   toddler.Name,
+		Birthdate:              toddler.Birthdate,
+		Sex:                    toddler.Sex,
+		Height:                 toddler.Height,
+		Weight:                 toddler.Weight,
+		HeadCircumference:      toddler.HeadCircumference,
+		ProfilePicture:         fileURL(store, toddler.ProfilePicture),
+		ProfilePictureVariants: imageVariants(store, toddler.ProfilePicture),
+		NutritionalStatus:      toddler.NutritionalStatus,
+		CreatedAt:              toddler.CreatedAt,
+		UpdatedAt:              toddler.UpdatedAt,
+	}
 }
 
-func NewToddlerService(repo repositories.ToddlerRepository, parentRepo repositories.ParentRepository, s3 *S3Service, predict PredictService) ToddlerService {
-	return &toddlerService{repo: repo, parentRepo: parentRepo, s3: s3, predict: predict}
+func NewToddlerService(repo repositories.ToddlerRepository, parentRepo repositories.ParentRepository, store storage.ObjectStorage, predict PredictService, growth GrowthService, audit AuditService) ToddlerService {
+	return &toddlerService{repo: repo, parentRepo: parentRepo, storage: store, predict: predict, growth: growth, audit: audit}
 }
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"grovia/internal/dto/requests"
+	"grovia/internal/models"
+	"grovia/internal/repositories"
+	"net/http"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+type fakeParentRepository struct {
+	repositories.ParentRepository
+	parent *models.Parent
+	err    error
+}
+
+func (f *fakeParentRepository) FindParentByPhoneNumber(phoneNumber string) (*models.Parent, error) {
+	return f.parent, f.err
+}
+
+func TestCreateToddlerReportsTheParentLookupError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"unknown phone number", gorm.ErrRecordNotFound, http.StatusNotFound},
+		{"database error", errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewToddlerService(nil, &fakeParentRepository{err: tt.err}, nil, nil, nil, &fakeAuditService{})
+
+			_, _, err := service.CreateToddler(context.Background(), requests.CreateToddlerRequest{PhoneNumber: "081234567890"}, 1)
+			if got := statusCode(err); got != tt.want {
+				t.Fatalf("status = %d, want %d (err %v)", got, tt.want, err)
+			}
+		})
+	}
+}
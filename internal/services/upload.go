@@ -0,0 +1,128 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"grovia/internal/dto/responses"
+	"grovia/internal/imaging"
+	"grovia/internal/storage"
+	"grovia/pkg"
+	"io"
+	"log"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const maxUploadSize = 10 << 20
+
+// fileURLTTL is how long the picture URLs in responses stay readable.
+const fileURLTTL = 15 * time.Minute
+
+// uploadFile stores an uploaded image under folder/<uuid>/, one JPEG per
+// imaging.Variants size, and returns the key of the full size. The key is
+// what gets saved; responses sign it with fileURL and imageVariants.
+//
+// The picture is decoded and re-encoded, so the stored files carry no
+// EXIF data (GPS position included) and are already upright.
+func uploadFile(ctx context.Context, store storage.ObjectStorage, file *multipart.FileHeader, folder string) (string, error) {
+	if file.Size > maxUploadSize {
+		return "", pkg.NewBadRequestError("Ukuran file maksimal 10 MB")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return "", pkg.NewBadRequestError("File tidak dapat dibaca")
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, maxUploadSize+1))
+	if err != nil {
+		return "", pkg.NewBadRequestError("File tidak dapat dibaca")
+	}
+	if len(data) > maxUploadSize {
+		return "", pkg.NewBadRequestError("Ukuran file maksimal 10 MB")
+	}
+
+	img, err := imaging.Decode(data)
+	switch {
+	case errors.Is(err, imaging.ErrUnsupportedType):
+		return "", pkg.NewBadRequestError("File harus berupa gambar JPG atau PNG")
+	case errors.Is(err, imaging.ErrImageTooLarge):
+		return "", pkg.NewBadRequestError("Resolusi gambar terlalu besar")
+	case err != nil:
+		return "", pkg.NewBadRequestError("File gambar rusak")
+	}
+
+	base := fmt.Sprintf("%s/%s", folder, uuid.New().String())
+
+	var stored []string
+	for _, variant := range imaging.Variants {
+		// Variants are largest first, so each one is resized from the
+		// previous, smaller copy.
+		img = imaging.Resize(img, variant.Size)
+
+		encoded, err := imaging.EncodeJPEG(img)
+		if err == nil {
+			key := variantKey(base, variant)
+			err = store.Put(ctx, key, bytes.NewReader(encoded), imaging.ContentType)
+			stored = append(stored, key)
+		}
+		if err != nil {
+			for _, key := range stored {
+				if err := store.Delete(ctx, key); err != nil {
+					log.Printf("storage: removing %q after a failed upload: %v", key, err)
+				}
+			}
+			return "", pkg.NewInternalServerError("Gagal menyimpan gambar")
+		}
+	}
+
+	return variantKey(base, imaging.Full), nil
+}
+
+// variantKey is where a size of an upload is stored.
+func variantKey(base string, variant imaging.Variant) string {
+	return base + "/" + variant.Name + imaging.Extension
+}
+
+// fileURL returns a short-lived URL for a stored key, or "" when there is
+// no file or it cannot be signed.
+func fileURL(store storage.ObjectStorage, key string) string {
+	if key == "" {
+		return ""
+	}
+
+	url, err := store.SignedURL(context.Background(), key, fileURLTTL)
+	if err != nil {
+		log.Printf("storage: signing %q: %v", key, err)
+		return ""
+	}
+
+	return url
+}
+
+// imageVariants signs the resized copies of the picture saved under key.
+// Keys from before resizing have no copies and fall back to the picture.
+func imageVariants(store storage.ObjectStorage, key string) *responses.ImageVariantsResponse {
+	if key == "" {
+		return nil
+	}
+
+	sized := func(variant imaging.Variant) string {
+		full := "/" + imaging.Full.Name + imaging.Extension
+		if !strings.HasSuffix(key, full) {
+			return fileURL(store, key)
+		}
+		return fileURL(store, variantKey(strings.TrimSuffix(key, full), variant))
+	}
+
+	return &responses.ImageVariantsResponse{
+		Medium:    sized(imaging.Medium),
+		Thumbnail: sized(imaging.Thumbnail),
+	}
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"grovia/internal/imaging"
+	"grovia/internal/storage"
+	"image"
+	"image/jpeg"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func newTestStorage(t *testing.T) *storage.MemoryStorage {
+	t.Helper()
+
+	signer, err := storage.NewURLSigner("secret", "http://localhost:8080")
+	if err != nil {
+		t.Fatalf("NewURLSigner: %v", err)
+	}
+	return storage.NewMemoryStorage(signer)
+}
+
+// formFile turns data into the file header Fiber hands to the services.
+func formFile(t *testing.T, filename string, data []byte) *multipart.FileHeader {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("picture", filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(data)
+	writer.Close()
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(maxUploadSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { form.RemoveAll() })
+
+	return form.File["picture"][0]
+}
+
+// photoWithGPS is a JPEG from a phone: 2000x1000 with an APP1 segment
+// carrying location data.
+func photoWithGPS(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 2000, 1000)), nil); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	payload := []byte("Exif\x00\x00MM\x00\x2a\x00\x00\x00\x08\x00\x00GPSLatitude -6.2088")
+	segment := []byte{0xff, 0xe1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	out = append(out, payload...)
+	return append(out, data[2:]...)
+}
+
+func readObject(t *testing.T, store storage.ObjectStorage, key string) []byte {
+	t.Helper()
+
+	object, err := store.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get %q: %v", key, err)
+	}
+	defer object.Body.Close()
+
+	data, err := io.ReadAll(object.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if object.ContentType != imaging.ContentType {
+		t.Errorf("%s: content type %q", key, object.ContentType)
+	}
+	return data
+}
+
+func TestUploadFileStoresCleanVariants(t *testing.T) {
+	store := newTestStorage(t)
+
+	key, err := uploadFile(context.Background(), store, formFile(t, "IMG_0001.JPG", photoWithGPS(t)), "toddlers")
+	if err != nil {
+		t.Fatalf("uploadFile: %v", err)
+	}
+	if !strings.HasPrefix(key, "toddlers/") || !strings.HasSuffix(key, "/full.jpg") {
+		t.Fatalf("key = %q", key)
+	}
+
+	base := strings.TrimSuffix(key, "/full.jpg")
+	wantWidth := map[imaging.Variant]int{imaging.Full: 1600, imaging.Medium: 640, imaging.Thumbnail: 160}
+	for variant, width := range wantWidth {
+		data := readObject(t, store, variantKey(base, variant))
+		if bytes.Contains(data, []byte("GPSLatitude")) || bytes.Contains(data, []byte("Exif")) {
+			t.Errorf("%s still carries EXIF data", variant.Name)
+		}
+
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: %v", variant.Name, err)
+		}
+		if cfg.Width != width || cfg.Height != width/2 {
+			t.Errorf("%s: %dx%d, want %dx%d", variant.Name, cfg.Width, cfg.Height, width, width/2)
+		}
+	}
+}
+
+func TestUploadFileRejectsNonImages(t *testing.T) {
+	store := newTestStorage(t)
+
+	_, err := uploadFile(context.Background(), store, formFile(t, "photo.jpg", []byte("<?php system($_GET['c']); ?>")), "users")
+	if statusCode(err) != 400 {
+		t.Fatalf("uploadFile = %v, want a 400", err)
+	}
+}
+
+func TestFileURL(t *testing.T) {
+	store := newTestStorage(t)
+
+	if err := store.Put(context.Background(), "toddlers/a.jpg", bytes.NewReader([]byte("photo")), "image/jpeg"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	if got := fileURL(store, ""); got != "" {
+		t.Errorf("fileURL of no picture = %q, want empty", got)
+	}
+
+	got := fileURL(store, "toddlers/a.jpg")
+	u, err := url.Parse(got)
+	if err != nil {
+		t.Fatalf("parsing %q: %v", got, err)
+	}
+	if u.Path != storage.FilesPath+"toddlers/a.jpg" {
+		t.Errorf("path = %q", u.Path)
+	}
+
+	key := strings.TrimPrefix(u.Path, storage.FilesPath)
+	if err := store.VerifySignedURL(key, u.Query().Get("expires"), u.Query().Get("signature")); err != nil {
+		t.Errorf("VerifySignedURL: %v", err)
+	}
+}
+
+func TestImageVariants(t *testing.T) {
+	store := newTestStorage(t)
+	path := func(raw string) string {
+		u, err := url.Parse(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.TrimPrefix(u.Path, storage.FilesPath)
+	}
+
+	if got := imageVariants(store, ""); got != nil {
+		t.Errorf("imageVariants of no picture = %+v, want nil", got)
+	}
+
+	got := imageVariants(store, "toddlers/abc/full.jpg")
+	if path(got.Medium) != "toddlers/abc/medium.jpg" || path(got.Thumbnail) != "toddlers/abc/thumbnail.jpg" {
+		t.Errorf("variants = %+v", got)
+	}
+
+	// Pictures uploaded before resizing have only the original.
+	legacy := imageVariants(store, "toddlers/abc.png")
+	if path(legacy.Medium) != "toddlers/abc.png" || path(legacy.Thumbnail) != "toddlers/abc.png" {
+		t.Errorf("legacy variants = %+v", legacy)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"grovia/internal/dto/responses"
 	"grovia/internal/models"
 	"grovia/internal/repositories"
+	"grovia/internal/storage"
 	"grovia/pkg"
 	"math"
 	"strconv"
@@ -14,17 +15,18 @@ import (
 type UserService interface {
 	CreateUser(ctx context.Context, req requests.CreateUserRequest, createdBy string, locationID int) (*responses.UserResponse, error)
 	GetCurrentUser(id int) (*responses.UserResponse, error)
-	GetUsersByRole(requesterRole, name, pageStr, limitStr string, locationID int) ([]responses.UserResponse, *responses.PaginationMeta, error)
+	GetUsersByRole(scope pkg.AccessScope, name, pageStr, limitStr string) ([]responses.UserResponse, *responses.PaginationMeta, error)
 	GetUserById(targetUserID int, accesorRole string) (*responses.UserResponse, error)
 	UpdateCurrentUser(ctx context.Context, id int, req requests.UpdateUserRequest) (*responses.UserResponse, error)
 	UpdateUserByID(ctx context.Context, targetUserID int, req requests.UpdateUserRequest, updaterRole string) (*responses.UserResponse, error)
-	DeleteCurrentUser(id int) error
-	DeleteUserByID(targetUserID int, role string) error
+	DeleteCurrentUser(ctx context.Context, id int) error
+	DeleteUserByID(ctx context.Context, targetUserID int, role string) error
 }
 
 type userService struct {
-	repo repositories.UserRepository
-	s3   *S3Service
+	repo    repositories.UserRepository
+	storage storage.ObjectStorage
+	audit   AuditService
 }
 
 // GetUserById implements UserService.
@@ -50,30 +52,31 @@ func (u *userService) GetUserById(targetUserID int, accesorRole string) (*respon
 	}
 
 	response := &responses.UserResponse{
-		ID:             user.ID,
-		LocationID:     user.LocationID,
-		Name:           user.Name,
-		PhoneNumber:    user.PhoneNumber,
-		Address:        user.Address,
-		Nik:            user.Nik,
-		ProfilePicture: user.ProfilePicture,
-		Role:           user.Role,
-		IsActive:       user.IsActive,
-		CreatedBy:      user.CreatedBy,
-		CreatedAt:      user.CreatedAt,
-		UpdatedAt:      user.UpdatedAt,
+		ID:                     user.ID,
+		LocationID:             user.LocationID,
+		Name:                   user.Name,
+		PhoneNumber:            user.PhoneNumber,
+		Address:                user.Address,
+		Nik:                    user.Nik,
+		ProfilePicture:         fileURL(u.storage, user.ProfilePicture),
+		ProfilePictureVariants: imageVariants(u.storage, user.ProfilePicture),
+		Role:                   user.Role,
+		IsActive:               user.IsActive,
+		CreatedBy:              user.CreatedBy,
+		CreatedAt:              user.CreatedAt,
+		UpdatedAt:              user.UpdatedAt,
 	}
 
 	return response, nil
 }
 
 // GetUsersByRole implements UserService.
-func (u *userService) GetUsersByRole(requesterRole, name, pageStr, limitStr string, locationID int) ([]responses.UserResponse, *responses.PaginationMeta, error) {
+func (u *userService) GetUsersByRole(scope pkg.AccessScope, name, pageStr, limitStr string) ([]responses.UserResponse, *responses.PaginationMeta, error) {
 	var roles []string
 	page, _ := strconv.Atoi(pageStr)
 	limit, _ := strconv.Atoi(limitStr)
 
-	switch requesterRole {
+	switch scope.Role {
 	case pkg.RoleAdmin:
 		roles = []string{pkg.RoleKepalaPosyandu, pkg.RoleKader}
 	case pkg.RoleKepalaPosyandu:
@@ -92,7 +95,7 @@ func (u *userService) GetUsersByRole(requesterRole, name, pageStr, limitStr stri
 
 	offset := (page - 1) * limit
 
-	users, total, err := u.repo.FindUsersByRole(roles, name, locationID, limit, offset)
+	users, total, err := u.repo.FindUsersByRole(roles, name, scope, limit, offset)
 	if err != nil {
 		return nil, nil, pkg.NewNotFoundError("User tidak ditemukan")
 	}
@@ -102,18 +105,19 @@ func (u *userService) GetUsersByRole(requesterRole, name, pageStr, limitStr stri
 	var result []responses.UserResponse
 	for _, v := range users {
 		result = append(result, responses.UserResponse{
-			ID:             v.ID,
-			LocationID:     v.LocationID,
-			Name:           v.Name,
-			PhoneNumber:    v.PhoneNumber,
-			Address:        v.Address,
-			Nik:            v.Nik,
-			ProfilePicture: v.ProfilePicture,
-			Role:           v.Role,
-			IsActive:       v.IsActive,
-			CreatedBy:      v.CreatedBy,
-			CreatedAt:      v.CreatedAt,
-			UpdatedAt:      v.UpdatedAt,
+			ID:                     v.ID,
+			LocationID:             v.LocationID,
+			Name:                   v.Name,
+			PhoneNumber:            v.PhoneNumber,
+			Address:                v.Address,
+			Nik:                    v.Nik,
+			ProfilePicture:         fileURL(u.storage, v.ProfilePicture),
+			ProfilePictureVariants: imageVariants(u.storage, v.ProfilePicture),
+			Role:                   v.Role,
+			IsActive:               v.IsActive,
+			CreatedBy:              v.CreatedBy,
+			CreatedAt:              v.CreatedAt,
+			UpdatedAt:              v.UpdatedAt,
 		})
 	}
 
@@ -128,7 +132,7 @@ func (u *userService) GetUsersByRole(requesterRole, name, pageStr, limitStr stri
 }
 
 // DeleteUserByID implements UserService.
-func (u *userService) DeleteUserByID(targetUserID int, updaterRole string) error {
+func (u *userService) DeleteUserByID(ctx context.Context, targetUserID int, updaterRole string) error {
 	targetRole, err := u.repo.FindRoleById(targetUserID)
 
 	if err != nil {
@@ -139,7 +143,7 @@ func (u *userService) DeleteUserByID(targetUserID int, updaterRole string) error
 		return pkg.NewForbiddenError("Tidak memiliki akses menghapus user dengan Role %s" + targetRole)
 	}
 
-	return u.repo.DeleteUser(targetUserID)
+	return u.deleteUser(ctx, targetUserID)
 }
 
 // CreateUser implements UserService.
@@ -153,13 +157,13 @@ func (u *userService) CreateUser(ctx context.Context, req requests.CreateUserReq
 		return nil, pkg.NewBadRequestError(err.Error())
 	}
 
-	var url string
+	var key string
 	var err error
 
 	if req.ProfilePicture != nil && req.ProfilePicture.Filename != "" && req.ProfilePicture.Size > 0 {
-		url, err = u.s3.UploadFile(ctx, req.ProfilePicture, "users")
+		key, err = uploadFile(ctx, u.storage, req.ProfilePicture, "users")
 		if err != nil {
-			return nil, pkg.NewInternalServerError("Gagal upload foto profil")
+			return nil, err
 		}
 	}
 
@@ -189,7 +193,7 @@ func (u *userService) CreateUser(ctx context.Context, req requests.CreateUserReq
 		PhoneNumber:    req.PhoneNumber,
 		Address:        req.Address,
 		Nik:            req.Nik,
-		ProfilePicture: url,
+		ProfilePicture: key,
 		Role:           req.Role,
 		IsActive:       true,
 		Password:       hashedPassword,
@@ -202,29 +206,43 @@ func (u *userService) CreateUser(ctx context.Context, req requests.CreateUserReq
 		return nil, pkg.NewInternalServerError("Gagal membuat user")
 	}
 
+	u.audit.Record(ctx, pkg.AuditActionCreate, pkg.AuditEntityUser, user.ID, nil, user)
+
 	response := responses.UserResponse{
-		ID:             user.ID,
-		LocationID:     location,
-		Name:           user.Name,
-		PhoneNumber:    user.PhoneNumber,
-		Address:        user.Address,
-		Nik:            user.Nik,
-		ProfilePicture: user.ProfilePicture,
-		Role:           user.Role,
-		IsActive:       user.IsActive,
-		CreatedBy:      user.CreatedBy,
-		CreatedAt:      user.CreatedAt,
-		UpdatedAt:      user.UpdatedAt,
+		ID:                     user.ID,
+		LocationID:             location,
+		Name:                   user.Name,
+		PhoneNumber:            user.PhoneNumber,
+		Address:                user.Address,
+		Nik:                    user.Nik,
+		ProfilePicture:         fileURL(u.storage, user.ProfilePicture),
+		ProfilePictureVariants: imageVariants(u.storage, user.ProfilePicture),
+		Role:                   user.Role,
+		IsActive:               user.IsActive,
+		CreatedBy:              user.CreatedBy,
+		CreatedAt:              user.CreatedAt,
+		UpdatedAt:              user.UpdatedAt,
 	}
 
 	return &response, nil
 }
 
 // DeleteUser implements UserService.
-func (u *userService) DeleteCurrentUser(id int) error {
+func (u *userService) DeleteCurrentUser(ctx context.Context, id int) error {
+	return u.deleteUser(ctx, id)
+}
+
+func (u *userService) deleteUser(ctx context.Context, id int) error {
+	before, err := u.repo.GetUser(id)
+	if err != nil {
+		return pkg.NewNotFoundError("User tidak ditemukan")
+	}
+
 	if err := u.repo.DeleteUser(id); err != nil {
 		return pkg.NewInternalServerError("Gagal menghapus user")
 	}
+
+	u.audit.Record(ctx, pkg.AuditActionDelete, pkg.AuditEntityUser, id, before, nil)
 	return nil
 }
 
@@ -237,18 +255,19 @@ func (u *userService) GetCurrentUser(id int) (*responses.UserResponse, error) {
 	}
 
 	response := &responses.UserResponse{
-		ID:             user.ID,
-		LocationID:     user.LocationID,
-		Name:           user.Name,
-		PhoneNumber:    user.PhoneNumber,
-		Address:        user.Address,
-		Nik:            user.Nik,
-		ProfilePicture: user.ProfilePicture,
-		Role:           user.Role,
-		IsActive:       user.IsActive,
-		CreatedBy:      user.CreatedBy,
-		CreatedAt:      user.CreatedAt,
-		UpdatedAt:      user.UpdatedAt,
+		ID:                     user.ID,
+		LocationID:             user.LocationID,
+		Name:                   user.Name,
+		PhoneNumber:            user.PhoneNumber,
+		Address:                user.Address,
+		Nik:                    user.Nik,
+		ProfilePicture:         fileURL(u.storage, user.ProfilePicture),
+		ProfilePictureVariants: imageVariants(u.storage, user.ProfilePicture),
+		Role:                   user.Role,
+		IsActive:               user.IsActive,
+		CreatedBy:              user.CreatedBy,
+		CreatedAt:              user.CreatedAt,
+		UpdatedAt:              user.UpdatedAt,
 	}
 
 	return response, nil
@@ -260,13 +279,13 @@ func (u *userService) UpdateCurrentUser(ctx context.Context, id int, req request
 		return nil, pkg.NewBadRequestError(err.Error())
 	}
 
-	var url string
+	var key string
 	var err error
 
 	if req.ProfilePicture != nil && req.ProfilePicture.Filename != "" && req.ProfilePicture.Size > 0 {
-		url, err = u.s3.UploadFile(ctx, req.ProfilePicture, "users")
+		key, err = uploadFile(ctx, u.storage, req.ProfilePicture, "users")
 		if err != nil {
-			return nil, pkg.NewInternalServerError("Gagal upload foto profil")
+			return nil, err
 		}
 	}
 
@@ -283,8 +302,8 @@ func (u *userService) UpdateCurrentUser(ctx context.Context, id int, req request
 	if req.Nik != nil {
 		userMapping.Nik = *req.Nik
 	}
-	if url != "" {
-		userMapping.ProfilePicture = url
+	if key != "" {
+		userMapping.ProfilePicture = key
 	}
 	if req.Password != nil {
 		hashedPassword, err := pkg.HashPassword(*req.Password)
@@ -294,23 +313,31 @@ func (u *userService) UpdateCurrentUser(ctx context.Context, id int, req request
 		userMapping.Password = hashedPassword
 	}
 
+	before, err := u.repo.GetUser(id)
+	if err != nil {
+		return nil, pkg.NewNotFoundError("User tidak ditemukan")
+	}
+
 	user, err := u.repo.UpdateUser(id, &userMapping)
 	if err != nil {
 		return nil, pkg.NewInternalServerError("Gagal update user")
 	}
 
+	u.audit.Record(ctx, pkg.AuditActionUpdate, pkg.AuditEntityUser, id, before, user)
+
 	return &responses.UserResponse{
-		ID:             user.ID,
-		LocationID:     user.LocationID,
-		Name:           user.Name,
-		PhoneNumber:    user.PhoneNumber,
-		Address:        user.Address,
-		Nik:            user.Nik,
-		ProfilePicture: user.ProfilePicture,
-		Role:           user.Role,
-		CreatedBy:      user.CreatedBy,
-		CreatedAt:      user.CreatedAt,
-		UpdatedAt:      user.UpdatedAt,
+		ID:                     user.ID,
+		LocationID:             user.LocationID,
+		Name:                   user.Name,
+		PhoneNumber:            user.PhoneNumber,
+		Address:                user.Address,
+		Nik:                    user.Nik,
+		ProfilePicture:         fileURL(u.storage, user.ProfilePicture),
+		ProfilePictureVariants: imageVariants(u.storage, user.ProfilePicture),
+		Role:                   user.Role,
+		CreatedBy:              user.CreatedBy,
+		CreatedAt:              user.CreatedAt,
+		UpdatedAt:              user.UpdatedAt,
 	}, nil
 }
 
@@ -336,13 +363,13 @@ func (u *userService) UpdateUserByID(
 		}
 	}
 
-	var url string
+	var key string
 	var err error
 
 	if req.ProfilePicture != nil && req.ProfilePicture.Filename != "" && req.ProfilePicture.Size > 0 {
-		url, err = u.s3.UploadFile(ctx, req.ProfilePicture, "users")
+		key, err = uploadFile(ctx, u.storage, req.ProfilePicture, "users")
 		if err != nil {
-			return nil, pkg.NewInternalServerError("Gagal upload foto profil")
+			return nil, err
 		}
 	}
 
@@ -365,8 +392,8 @@ func (u *userService) UpdateUserByID(
 	if req.Role != nil {
 		userMapping.Role = *req.Role
 	}
-	if url != "" {
-		userMapping.ProfilePicture = url
+	if key != "" {
+		userMapping.ProfilePicture = key
 	}
 	if req.Password != nil {
 		hashedPassword, err := pkg.HashPassword(*req.Password)
@@ -376,23 +403,31 @@ func (u *userService) UpdateUserByID(
 		userMapping.Password = hashedPassword
 	}
 
+	before, err := u.repo.GetUser(targetUserID)
+	if err != nil {
+		return nil, pkg.NewNotFoundError("User tidak ditemukan")
+	}
+
 	user, err := u.repo.UpdateUser(targetUserID, &userMapping)
 	if err != nil {
 		return nil, pkg.NewInternalServerError("Gagal update user")
 	}
 
+	u.audit.Record(ctx, pkg.AuditActionUpdate, pkg.AuditEntityUser, targetUserID, before, user)
+
 	return &responses.UserResponse{
-		ID:             user.ID,
-		LocationID:     user.LocationID,
-		Name:           user.Name,
-		PhoneNumber:    user.PhoneNumber,
-		Address:        user.Address,
-		Nik:            user.Nik,
-		ProfilePicture: user.ProfilePicture,
-		Role:           user.Role,
-		CreatedBy:      user.CreatedBy,
-		CreatedAt:      user.CreatedAt,
-		UpdatedAt:      user.UpdatedAt,
+		ID:                     user.ID,
+		LocationID:             user.LocationID,
+		Name:                   user.Name,
+		PhoneNumber:            user.PhoneNumber,
+		Address:                user.Address,
+		Nik:                    user.Nik,
+		ProfilePicture:         fileURL(u.storage, user.ProfilePicture),
+		ProfilePictureVariants: imageVariants(u.storage, user.ProfilePicture),
+		Role:                   user.Role,
+		CreatedBy:              user.CreatedBy,
+		CreatedAt:              user.CreatedAt,
+		UpdatedAt:              user.UpdatedAt,
 	}, nil
 }
 
@@ -407,6 +442,6 @@ func (u *userService) rolePermission(creatorRole, targetRole string) bool {
 	}
 }
 
-func NewUserService(repo repositories.UserRepository, s3 *S3Service) UserService {
-	return &userService{repo: repo, s3: s3}
+func NewUserService(repo repositories.UserRepository, store storage.ObjectStorage, audit AuditService) UserService {
+	return &userService{repo: repo, storage: store, audit: audit}
 }
@@ -0,0 +1,355 @@
+// Package spreadsheet reads the monthly posyandu measurement workbook and
+// writes the prediction results back into it.
+package spreadsheet
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var (
+	ErrEmptyWorkbook  = errors.New("file tidak memiliki sheet atau baris data")
+	ErrMissingColumns = errors.New("kolom wajib tidak ditemukan")
+)
+
+// Column keys, matched against the header row after lowercasing and
+// removing everything but letters and digits.
+const (
+	ColumnName              = "name"
+	ColumnAge               = "age"
+	ColumnBirthdate         = "birthdate"
+	ColumnSex               = "sex"
+	ColumnHeight            = "height"
+	ColumnWeight            = "weight"
+	ColumnHeadCircumference = "headCircumference"
+	ColumnPhoneNumber       = "phoneNumber"
+	ColumnStatus            = "status"
+	ColumnZscore            = "zscore"
+	ColumnNote              = "note"
+)
+
+var headerAliases = map[string]string{
+	"namaanak":        ColumnName,
+	"namabalita":      ColumnName,
+	"nama":            ColumnName,
+	"umurbulan":       ColumnAge,
+	"umur":            ColumnAge,
+	"tanggallahir":    ColumnBirthdate,
+	"tgllahir":        ColumnBirthdate,
+	"jeniskelamin":    ColumnSex,
+	"tinggibadancm":   ColumnHeight,
+	"tinggibadan":     ColumnHeight,
+	"beratbadankg":    ColumnWeight,
+	"beratbadan":      ColumnWeight,
+	"lingkarkepalacm": ColumnHeadCircumference,
+	"lingkarkepala":   ColumnHeadCircumference,
+	"nohporangtua":    ColumnPhoneNumber,
+	"nomorhporangtua": ColumnPhoneNumber,
+	"nohp":            ColumnPhoneNumber,
+	"nomorhp":         ColumnPhoneNumber,
+	"statusgizi":      ColumnStatus,
+	"zscore":          ColumnZscore,
+	"keterangan":      ColumnNote,
+}
+
+var requiredColumns = []string{ColumnName, ColumnSex, ColumnHeight, ColumnPhoneNumber}
+
+// Appended columns use the same headers as the ML API output.
+var appendedHeaders = map[string]string{
+	ColumnStatus: "Status Gizi",
+	ColumnZscore: "Z-Score",
+	ColumnNote:   "Keterangan",
+}
+
+var dateLayouts = []string{"2006-01-02", "02/01/2006", "2/1/2006", "02-01-2006", "01-02-06"}
+
+// GroupRow is one child's measurement as written in the workbook. Values
+// that could not be read are left empty and explained in Errors.
+type GroupRow struct {
+	Row               int        `json:"row"`
+	Name              string     `json:"name"`
+	AgeMonths         *int       `json:"age,omitempty"`
+	Birthdate         *time.Time `json:"birthdate,omitempty"`
+	Sex               string     `json:"sex"`
+	Height            float64    `json:"height"`
+	Weight            *float64   `json:"weight,omitempty"`
+	HeadCircumference *float64   `json:"headCircumference,omitempty"`
+	PhoneNumber       string     `json:"phoneNumber"`
+	Errors            []string   `json:"errors,omitempty"`
+}
+
+type GroupSheet struct {
+	file    *excelize.File
+	sheet   string
+	columns map[string]int
+	Rows    []GroupRow
+}
+
+// ReadGroupSheet parses the first sheet of the workbook. The first row is
+// the header; empty rows are skipped.
+func ReadGroupSheet(r io.Reader) (*GroupSheet, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, ErrEmptyWorkbook
+	}
+
+	rows, err := f.GetRows(sheets[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) < 2 {
+		return nil, ErrEmptyWorkbook
+	}
+
+	s := &GroupSheet{file: f, sheet: sheets[0], columns: map[string]int{}}
+	for i, header := range rows[0] {
+		if key, ok := headerAliases[normalizeHeader(header)]; ok {
+			if _, exists := s.columns[key]; !exists {
+				s.columns[key] = i
+			}
+		}
+	}
+
+	var missing []string
+	for _, key := range requiredColumns {
+		if _, ok := s.columns[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	_, hasAge := s.columns[ColumnAge]
+	_, hasBirthdate := s.columns[ColumnBirthdate]
+	if !hasAge && !hasBirthdate {
+		missing = append(missing, ColumnAge)
+	}
+	if len(missing) > 0 {
+		return nil, errors.Join(ErrMissingColumns, errors.New(strings.Join(missing, ", ")))
+	}
+
+	for i, cells := range rows[1:] {
+		if isBlank(cells) {
+			continue
+		}
+		s.Rows = append(s.Rows, s.parseRow(i+2, cells))
+	}
+	if len(s.Rows) == 0 {
+		return nil, ErrEmptyWorkbook
+	}
+
+	return s, nil
+}
+
+func (s *GroupSheet) cell(cells []string, key string) string {
+	i, ok := s.columns[key]
+	if !ok || i >= len(cells) {
+		return ""
+	}
+	return strings.TrimSpace(cells[i])
+}
+
+func (s *GroupSheet) parseRow(rowNumber int, cells []string) GroupRow {
+	row := GroupRow{
+		Row:         rowNumber,
+		Name:        s.cell(cells, ColumnName),
+		Sex:         s.cell(cells, ColumnSex),
+		PhoneNumber: NormalizePhoneNumber(s.cell(cells, ColumnPhoneNumber)),
+	}
+
+	if row.Name == "" {
+		row.Errors = append(row.Errors, "Nama anak wajib diisi")
+	}
+	if row.Sex == "" {
+		row.Errors = append(row.Errors, "Jenis kelamin wajib diisi")
+	}
+	if row.PhoneNumber == "" {
+		row.Errors = append(row.Errors, "Nomor HP orang tua wajib diisi")
+	}
+
+	if value := s.cell(cells, ColumnHeight); value == "" {
+		row.Errors = append(row.Errors, "Tinggi badan wajib diisi")
+	} else if height, err := parseDecimal(value); err != nil {
+		row.Errors = append(row.Errors, "Tinggi badan harus berupa angka")
+	} else {
+		row.Height = height
+	}
+
+	if value := s.cell(cells, ColumnWeight); value != "" {
+		if weight, err := parseDecimal(value); err != nil {
+			row.Errors = append(row.Errors, "Berat badan harus berupa angka")
+		} else {
+			row.Weight = &weight
+		}
+	}
+
+	if value := s.cell(cells, ColumnHeadCircumference); value != "" {
+		if hc, err := parseDecimal(value); err != nil {
+			row.Errors = append(row.Errors, "Lingkar kepala harus berupa angka")
+		} else {
+			row.HeadCircumference = &hc
+		}
+	}
+
+	if value := s.cell(cells, ColumnBirthdate); value != "" {
+		if birthdate, err := parseDate(value); err != nil {
+			row.Errors = append(row.Errors, "Tanggal lahir tidak valid: "+value)
+		} else {
+			row.Birthdate = &birthdate
+		}
+	}
+
+	if value := s.cell(cells, ColumnAge); value != "" {
+		if age, err := strconv.Atoi(value); err != nil {
+			row.Errors = append(row.Errors, "Umur harus berupa bilangan bulat (bulan)")
+		} else {
+			row.AgeMonths = &age
+		}
+	}
+
+	if row.AgeMonths == nil && row.Birthdate == nil && !hasErrorPrefix(row.Errors, "Tanggal lahir", "Umur") {
+		row.Errors = append(row.Errors, "Umur atau tanggal lahir wajib diisi")
+	}
+
+	return row
+}
+
+// Annotate writes the prediction result, or the reason the row was not
+// predicted, next to the row's measurements.
+func (s *GroupSheet) Annotate(rowNumber int, zscore *float64, status, note string) error {
+	if zscore != nil {
+		if err := s.setCell(ColumnZscore, rowNumber, *zscore); err != nil {
+			return err
+		}
+	}
+	if status != "" {
+		if err := s.setCell(ColumnStatus, rowNumber, status); err != nil {
+			return err
+		}
+	}
+	if note != "" {
+		return s.setCell(ColumnNote, rowNumber, note)
+	}
+	return nil
+}
+
+func (s *GroupSheet) setCell(key string, rowNumber int, value any) error {
+	col, ok := s.columns[key]
+	if !ok {
+		cols, err := s.file.GetCols(s.sheet)
+		if err != nil {
+			return err
+		}
+		col = len(cols)
+		s.columns[key] = col
+
+		header, err := excelize.CoordinatesToCellName(col+1, 1)
+		if err != nil {
+			return err
+		}
+		if err := s.file.SetCellValue(s.sheet, header, appendedHeaders[key]); err != nil {
+			return err
+		}
+	}
+
+	cell, err := excelize.CoordinatesToCellName(col+1, rowNumber)
+	if err != nil {
+		return err
+	}
+	return s.file.SetCellValue(s.sheet, cell, value)
+}
+
+// Bytes returns the annotated workbook.
+func (s *GroupSheet) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := s.file.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *GroupSheet) Close() error {
+	return s.file.Close()
+}
+
+// NormalizePhoneNumber strips separators and restores the leading zero
+// that spreadsheet programs drop when the column is numeric.
+func NormalizePhoneNumber(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	phone := b.String()
+
+	switch {
+	case strings.HasPrefix(phone, "62"):
+		phone = "0" + strings.TrimPrefix(phone, "62")
+	case strings.HasPrefix(phone, "8"):
+		phone = "0" + phone
+	}
+	return phone
+}
+
+func normalizeHeader(header string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(header) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func parseDecimal(value string) (float64, error) {
+	return strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+}
+
+func parseDate(value string) (time.Time, error) {
+	var lastErr error
+	for _, layout := range dateLayouts {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return t, nil
+		}
+		lastErr = err
+	}
+
+	// Dates typed into a cell without a date format come through as the
+	// Excel serial number.
+	if serial, err := strconv.ParseFloat(value, 64); err == nil {
+		return excelize.ExcelDateToTime(serial, false)
+	}
+
+	return time.Time{}, lastErr
+}
+
+func isBlank(cells []string) bool {
+	for _, c := range cells {
+		if strings.TrimSpace(c) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func hasErrorPrefix(errs []string, prefixes ...string) bool {
+	for _, e := range errs {
+		for _, p := range prefixes {
+			if strings.HasPrefix(e, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package spreadsheet
+
+import (
+	"bytes"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Table is a titled grid written to a single sheet by WriteTable.
+type Table struct {
+	Sheet   string
+	Title   string
+	Headers []string
+	Rows    [][]any
+}
+
+// WriteTable renders the table as an XLSX workbook: the title in the
+// first row, the bold header in the third and the data below it.
+func WriteTable(t Table) ([]byte, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := f.SetSheetName(f.GetSheetName(0), t.Sheet); err != nil {
+		return nil, err
+	}
+
+	if err := f.SetCellValue(t.Sheet, "A1", t.Title); err != nil {
+		return nil, err
+	}
+
+	header := make([]any, len(t.Headers))
+	for i, h := range t.Headers {
+		header[i] = h
+	}
+	if err := f.SetSheetRow(t.Sheet, "A3", &header); err != nil {
+		return nil, err
+	}
+
+	bold, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return nil, err
+	}
+	lastHeader, err := excelize.CoordinatesToCellName(len(t.Headers), 3)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.SetCellStyle(t.Sheet, "A1", "A1", bold); err != nil {
+		return nil, err
+	}
+	if err := f.SetCellStyle(t.Sheet, "A3", lastHeader, bold); err != nil {
+		return nil, err
+	}
+
+	for i, row := range t.Rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+4)
+		if err != nil {
+			return nil, err
+		}
+		if err := f.SetSheetRow(t.Sheet, cell, &row); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,88 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func openWorkbook(t *testing.T, data []byte) *excelize.File {
+	t.Helper()
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("open workbook: %v", err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func TestWriteTable(t *testing.T) {
+	data, err := WriteTable(Table{
+		Sheet:   "Laporan",
+		Title:   "Laporan Bulanan",
+		Headers: []string{"Posyandu", "Jumlah", "Persen"},
+		Rows: [][]any{
+			{"Melati", 12, 33.3},
+			{"Mawar", 0, 0.0},
+		},
+	})
+	if err != nil {
+		t.Fatalf("WriteTable: %v", err)
+	}
+
+	f := openWorkbook(t, data)
+
+	if sheets := f.GetSheetList(); !reflect.DeepEqual(sheets, []string{"Laporan"}) {
+		t.Fatalf("sheets = %v, want [Laporan]", sheets)
+	}
+
+	rows, err := f.GetRows("Laporan")
+	if err != nil {
+		t.Fatalf("GetRows: %v", err)
+	}
+	want := [][]string{
+		{"Laporan Bulanan"},
+		nil,
+		{"Posyandu", "Jumlah", "Persen"},
+		{"Melati", "12", "33.3"},
+		{"Mawar", "0", "0"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %q, want %q", rows, want)
+	}
+
+	for _, cell := range []string{"A1", "A3", "C3"} {
+		styleID, err := f.GetCellStyle("Laporan", cell)
+		if err != nil {
+			t.Fatalf("GetCellStyle(%s): %v", cell, err)
+		}
+		style, err := f.GetStyle(styleID)
+		if err != nil {
+			t.Fatalf("GetStyle(%s): %v", cell, err)
+		}
+		if style.Font == nil || !style.Font.Bold {
+			t.Errorf("%s is not bold", cell)
+		}
+	}
+
+	if kind, err := f.GetCellType("Laporan", "B4"); err != nil || kind == excelize.CellTypeSharedString || kind == excelize.CellTypeInlineString {
+		t.Errorf("B4 type = %v (%v), want a number", kind, err)
+	}
+}
+
+func TestWriteTableWithoutRows(t *testing.T) {
+	data, err := WriteTable(Table{Sheet: "Kosong", Title: "Kosong", Headers: []string{"A", "B"}})
+	if err != nil {
+		t.Fatalf("WriteTable: %v", err)
+	}
+
+	rows, err := openWorkbook(t, data).GetRows("Kosong")
+	if err != nil {
+		t.Fatalf("GetRows: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Errorf("%d rows, want title, blank and header", len(rows))
+	}
+}
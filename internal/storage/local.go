@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+const defaultLocalDir = "storage"
+
+// LocalStorage keeps objects as files under a directory. The content type
+// is derived from the key's extension.
+type LocalStorage struct {
+	dir    string
+	signer *URLSigner
+}
+
+func NewLocalStorage(dir string, signer *URLSigner) (*LocalStorage, error) {
+	if dir == "" {
+		dir = defaultLocalDir
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &LocalStorage{dir: dir, signer: signer}, nil
+}
+
+func (l *LocalStorage) path(key string) (string, error) {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(cleaned)), nil
+}
+
+// Put implements ObjectStorage. The file is written next to its final
+// name and renamed, so readers never see a partial upload.
+func (l *LocalStorage) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), name)
+}
+
+// Get implements ObjectStorage.
+func (l *LocalStorage) Get(ctx context.Context, key string) (*Object, error) {
+	name, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &Object{Body: file, ContentType: mime.TypeByExtension(path.Ext(key)), Size: info.Size()}, nil
+}
+
+// Delete implements ObjectStorage.
+func (l *LocalStorage) Delete(ctx context.Context, key string) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// SignedURL implements ObjectStorage.
+func (l *LocalStorage) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return l.signer.Sign(key, ttl), nil
+}
+
+// VerifySignedURL implements URLVerifier.
+func (l *LocalStorage) VerifySignedURL(key, expires, signature string) error {
+	return l.signer.Verify(key, expires, signature)
+}
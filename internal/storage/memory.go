@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+type memoryObject struct {
+	data        []byte
+	contentType string
+}
+
+// MemoryStorage keeps objects in memory. Everything is lost on restart;
+// it is meant for tests and throwaway environments.
+type MemoryStorage struct {
+	mu      sync.RWMutex
+	objects map[string]memoryObject
+	signer  *URLSigner
+}
+
+func NewMemoryStorage(signer *URLSigner) *MemoryStorage {
+	return &MemoryStorage{objects: map[string]memoryObject{}, signer: signer}
+}
+
+// Put implements ObjectStorage.
+func (m *MemoryStorage) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	if _, err := cleanKey(key); err != nil {
+		return err
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[key] = memoryObject{data: data, contentType: contentType}
+
+	return nil
+}
+
+// Get implements ObjectStorage.
+func (m *MemoryStorage) Get(ctx context.Context, key string) (*Object, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	object, ok := m.objects[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return &Object{
+		Body:        io.NopCloser(bytes.NewReader(object.data)),
+		ContentType: object.contentType,
+		Size:        int64(len(object.data)),
+	}, nil
+}
+
+// Delete implements ObjectStorage.
+func (m *MemoryStorage) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.objects, key)
+
+	return nil
+}
+
+// SignedURL implements ObjectStorage.
+func (m *MemoryStorage) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return m.signer.Sign(key, ttl), nil
+}
+
+// VerifySignedURL implements URLVerifier.
+func (m *MemoryStorage) VerifySignedURL(key, expires, signature string) error {
+	return m.signer.Verify(key, expires, signature)
+}
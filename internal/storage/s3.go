@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"grovia/configs"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+const s3Timeout = 30 * time.Second
+
+type S3Storage struct {
+	client     *s3.Client
+	presign    *s3.PresignClient
+	bucketName string
+}
+
+func NewS3Storage(cfg configs.AwsConfig) (*S3Storage, error) {
+	if cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, errors.New("AWS credentials are not set. Please set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(context.TODO(),
+		config.WithRegion(cfg.Region),
+		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
+			cfg.AccessKey,
+			cfg.SecretKey,
+			"",
+		)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS SDK config: %w", err)
+	}
+
+	client := s3.NewFromConfig(awsCfg)
+
+	return &S3Storage{
+		client:     client,
+		presign:    s3.NewPresignClient(client),
+		bucketName: cfg.Bucket,
+	}, nil
+}
+
+// Put implements ObjectStorage.
+func (s *S3Storage) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	ctx, cancel := context.WithTimeout(ctx, s3Timeout)
+	defer cancel()
+
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucketName),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(contentType),
+		ACL:         types.ObjectCannedACLPrivate,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload to S3: %w", err)
+	}
+
+	return nil
+}
+
+// Get implements ObjectStorage.
+func (s *S3Storage) Get(ctx context.Context, key string) (*Object, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to read from S3: %w", err)
+	}
+
+	return &Object{
+		Body:        out.Body,
+		ContentType: aws.ToString(out.ContentType),
+		Size:        aws.ToInt64(out.ContentLength),
+	}, nil
+}
+
+// Delete implements ObjectStorage.
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, s3Timeout)
+	defer cancel()
+
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete from S3: %w", err)
+	}
+
+	return nil
+}
+
+// SignedURL implements ObjectStorage with a presigned GetObject request.
+func (s *S3Storage) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	req, err := s.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign S3 url: %w", err)
+	}
+
+	return req.URL, nil
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// signedParts splits a signed URL into the key, expiry and signature the
+// file route passes to Verify.
+func signedParts(t *testing.T, signed string) (key, expires, signature string) {
+	t.Helper()
+
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatalf("parsing %q: %v", signed, err)
+	}
+	if !strings.HasPrefix(u.Path, FilesPath) {
+		t.Fatalf("path %q is not under %s", u.Path, FilesPath)
+	}
+
+	return strings.TrimPrefix(u.Path, FilesPath), u.Query().Get("expires"), u.Query().Get("signature")
+}
+
+func newTestSigner(t *testing.T, secret string) *URLSigner {
+	t.Helper()
+
+	signer, err := NewURLSigner(secret, "http://localhost:8080/")
+	if err != nil {
+		t.Fatalf("NewURLSigner: %v", err)
+	}
+	return signer
+}
+
+func TestURLSignerSign(t *testing.T) {
+	signer := newTestSigner(t, "secret")
+
+	signed := signer.Sign("toddlers/a b.jpg", time.Minute)
+	if !strings.HasPrefix(signed, "http://localhost:8080/api/files/toddlers/a%20b.jpg?") {
+		t.Fatalf("Sign = %q", signed)
+	}
+
+	key, expires, signature := signedParts(t, signed)
+	if key != "toddlers/a b.jpg" {
+		t.Errorf("key = %q", key)
+	}
+	if err := signer.Verify(key, expires, signature); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+}
+
+func TestURLSignerVerify(t *testing.T) {
+	signer := newTestSigner(t, "secret")
+	key, expires, signature := signedParts(t, signer.Sign("toddlers/a.jpg", time.Minute))
+	_, pastExpires, pastSignature := signedParts(t, signer.Sign("toddlers/a.jpg", -time.Minute))
+
+	tests := []struct {
+		name                    string
+		signer                  *URLSigner
+		key, expires, signature string
+		want                    error
+	}{
+		{"valid", signer, key, expires, signature, nil},
+		{"expired", signer, key, pastExpires, pastSignature, ErrURLExpired},
+		{"other key", signer, "toddlers/b.jpg", expires, signature, ErrInvalidSignature},
+		{"extended expiry", signer, key, pastExpires, signature, ErrInvalidSignature},
+		{"expired with extended expiry", signer, key, expires, pastSignature, ErrInvalidSignature},
+		{"tampered signature", signer, key, expires, strings.Repeat("0", len(signature)), ErrInvalidSignature},
+		{"missing signature", signer, key, expires, "", ErrInvalidSignature},
+		{"bad expiry", signer, key, "tomorrow", signature, ErrInvalidSignature},
+		{"other secret", newTestSigner(t, "another"), key, expires, signature, ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.signer.Verify(tt.key, tt.expires, tt.signature)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Verify = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestURLSignerRandomSecret(t *testing.T) {
+	a, b := newTestSigner(t, ""), newTestSigner(t, "")
+
+	key, expires, signature := signedParts(t, a.Sign("users/a.png", time.Minute))
+	if err := a.Verify(key, expires, signature); err != nil {
+		t.Errorf("Verify with the same signer: %v", err)
+	}
+	if err := b.Verify(key, expires, signature); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify with another random secret = %v, want %v", err, ErrInvalidSignature)
+	}
+}
@@ -0,0 +1,92 @@
+// Package storage keeps uploaded files behind one interface, so services
+// do not depend on where the bytes live.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"grovia/configs"
+	"grovia/pkg"
+)
+
+var (
+	ErrNotFound   = errors.New("object not found")
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// Object is a stored file opened for reading. The caller closes Body.
+type Object struct {
+	Body        io.ReadCloser
+	ContentType string
+	Size        int64
+}
+
+// ObjectStorage stores files under slash-separated keys such as
+// "toddlers/<uuid>.jpg". Objects are private: callers save the key and
+// hand out SignedURL addresses, never a permanent URL.
+type ObjectStorage interface {
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	Get(ctx context.Context, key string) (*Object, error)
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns an address that can read the object until ttl
+	// has passed.
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// URLVerifier is implemented by the backends whose signed URLs point at
+// this API rather than at an external service.
+type URLVerifier interface {
+	VerifySignedURL(key, expires, signature string) error
+}
+
+// New builds the storage selected in the config. Without a driver S3 is
+// used when a bucket is configured and the local disk otherwise, so the
+// API also runs offline.
+func New(cfg configs.StorageConfig, awsCfg configs.AwsConfig) (ObjectStorage, error) {
+	driver := cfg.Driver
+	if driver == "" {
+		driver = pkg.StorageDriverLocal
+		if awsCfg.Bucket != "" {
+			driver = pkg.StorageDriverS3
+		}
+	}
+
+	switch driver {
+	case pkg.StorageDriverS3:
+		return NewS3Storage(awsCfg)
+	case pkg.StorageDriverLocal, pkg.StorageDriverMemory:
+		signer, err := newSigner(cfg)
+		if err != nil {
+			return nil, err
+		}
+		if driver == pkg.StorageDriverMemory {
+			return NewMemoryStorage(signer), nil
+		}
+		return NewLocalStorage(cfg.LocalDir, signer)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", driver)
+	}
+}
+
+func newSigner(cfg configs.StorageConfig) (*URLSigner, error) {
+	if cfg.SigningSecret == "" {
+		log.Println("storage: STORAGE_SIGNING_SECRET is not set, signed URLs stop working after a restart")
+	}
+	return NewURLSigner(cfg.SigningSecret, cfg.BaseURL)
+}
+
+// cleanKey rejects keys that could escape the storage root.
+func cleanKey(key string) (string, error) {
+	cleaned := path.Clean("/" + key)[1:]
+	if cleaned == "" || cleaned != key || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+	return cleaned, nil
+}
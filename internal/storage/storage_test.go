@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCleanKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		wantErr bool
+	}{
+		{"toddlers/a.jpg", false},
+		{"a.jpg", false},
+		{"", true},
+		{"/", true},
+		{".", true},
+		{"..", true},
+		{"../a.jpg", true},
+		{"toddlers/../../a.jpg", true},
+		{"toddlers/../a.jpg", true},
+		{"/etc/passwd", true},
+		{"toddlers//a.jpg", true},
+		{"toddlers/./a.jpg", true},
+		{"toddlers/", true},
+		{`toddlers\..\a.jpg`, true},
+		{`..\a.jpg`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := cleanKey(tt.key)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidKey) {
+					t.Errorf("cleanKey(%q) = %q, %v; want ErrInvalidKey", tt.key, got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.key {
+				t.Errorf("cleanKey(%q) = %q, %v", tt.key, got, err)
+			}
+		})
+	}
+}
+
+func TestLocalStorageStaysInsideDir(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "files")
+
+	store, err := NewLocalStorage(dir, newTestSigner(t, "secret"))
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := store.Put(ctx, "../escaped.jpg", bytes.NewReader([]byte("x")), "image/jpeg"); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("Put outside the root = %v, want ErrInvalidKey", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "escaped.jpg")); !os.IsNotExist(err) {
+		t.Fatalf("file written outside the root: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "secret.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(ctx, "../secret.txt"); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("Get outside the root = %v, want ErrInvalidKey", err)
+	}
+
+	if err := store.Put(ctx, "toddlers/a.jpg", bytes.NewReader([]byte("photo")), "image/jpeg"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	object, err := store.Get(ctx, "toddlers/a.jpg")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	defer object.Body.Close()
+	if object.Size != 5 {
+		t.Errorf("Size = %d, want 5", object.Size)
+	}
+}
+
+func TestMemoryStorageSignedURL(t *testing.T) {
+	store := NewMemoryStorage(newTestSigner(t, "secret"))
+
+	signed, err := store.SignedURL(context.Background(), "users/a.png", time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+
+	key, expires, signature := signedParts(t, signed)
+	if err := store.VerifySignedURL(key, expires, signature); err != nil {
+		t.Errorf("VerifySignedURL: %v", err)
+	}
+}
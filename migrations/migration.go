@@ -1,31 +1,121 @@
+// Package migrations applies the SQL migrations embedded in the binary
+// with sql-migrate. They are only run on request, through the migrate
+// command; the server never changes the schema on its own.
 package migrations
 
 import (
 	"embed"
-	"fmt"
+	"errors"
+	"time"
 
 	migrate "github.com/rubenv/sql-migrate"
 	"gorm.io/gorm"
 )
 
+const dialect = "postgres"
+
 //go:embed sql_migrations/*.sql
 var dbMigrations embed.FS
 
-func Initiator(dbParam *gorm.DB) {
-	gormDB, err := dbParam.DB()
+var source = pairedSource{fs: dbMigrations, root: "sql_migrations"}
+
+var ErrNothingToRedo = errors.New("no migration has been applied")
+
+// Status is one embedded migration and when it was applied, if it was.
+type Status struct {
+	ID        string
+	AppliedAt *time.Time
+}
+
+// Up applies every pending migration and returns how many ran.
+func Up(db *gorm.DB) (int, error) {
+	sqlDB, err := db.DB()
 	if err != nil {
-		panic("failed to get gorm.DB: " + err.Error())
+		return 0, err
 	}
+	return migrate.Exec(sqlDB, dialect, source, migrate.Up)
+}
 
-	migrations := &migrate.EmbedFileSystemMigrationSource{
-		FileSystem: dbMigrations,
-		Root:       "sql_migrations",
+// Down rolls back the latest steps migrations and returns how many ran.
+func Down(db *gorm.DB, steps int) (int, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return 0, err
 	}
+	return migrate.ExecMax(sqlDB, dialect, source, migrate.Down, steps)
+}
 
-	n, errs := migrate.Exec(gormDB, "postgres", migrations, migrate.Up)
-	if errs != nil {
-		panic(errs)
+// Redo rolls back the latest migration and applies it again.
+func Redo(db *gorm.DB) (string, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return "", err
 	}
 
-	fmt.Println("Migrations success, applied", n, "migrations!")
+	planned, _, err := migrate.PlanMigration(sqlDB, dialect, source, migrate.Down, 1)
+	if err != nil {
+		return "", err
+	}
+	if len(planned) == 0 {
+		return "", ErrNothingToRedo
+	}
+
+	if _, err := migrate.ExecMax(sqlDB, dialect, source, migrate.Down, 1); err != nil {
+		return "", err
+	}
+	if _, err := migrate.ExecMax(sqlDB, dialect, source, migrate.Up, 1); err != nil {
+		return "", err
+	}
+
+	return planned[0].Id, nil
+}
+
+// Statuses lists the embedded migrations in order with their applied time.
+func Statuses(db *gorm.DB) ([]Status, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := source.FindMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := migrate.GetMigrationRecords(sqlDB, dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[string]time.Time, len(records))
+	for _, r := range records {
+		applied[r.Id] = r.AppliedAt
+	}
+
+	result := make([]Status, 0, len(found))
+	for _, m := range found {
+		status := Status{ID: m.Id}
+		if at, ok := applied[m.Id]; ok {
+			status.AppliedAt = &at
+		}
+		result = append(result, status)
+	}
+
+	return result, nil
+}
+
+// Pending counts the embedded migrations that have not been applied.
+func Pending(db *gorm.DB) (int, error) {
+	all, err := Statuses(db)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, s := range all {
+		if s.AppliedAt == nil {
+			pending++
+		}
+	}
+	return pending, nil
 }
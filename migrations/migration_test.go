@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedMigrationsPairUpAndDown(t *testing.T) {
+	found, err := source.FindMigrations()
+	if err != nil {
+		t.Fatalf("FindMigrations: %v", err)
+	}
+	if len(found) == 0 {
+		t.Fatal("no migrations embedded")
+	}
+
+	for i, m := range found {
+		if strings.HasSuffix(m.Id, ".sql") {
+			t.Errorf("%s: id still carries the file name", m.Id)
+		}
+		if len(m.Up) == 0 {
+			t.Errorf("%s has no up statements", m.Id)
+		}
+		if i > 0 && !found[i-1].Less(m) {
+			t.Errorf("%s is not ordered after %s", m.Id, found[i-1].Id)
+		}
+		for _, s := range append(append([]string{}, m.Up...), m.Down...) {
+			switch strings.TrimSpace(s) {
+			case "BEGIN;", "COMMIT;":
+				t.Errorf("%s: %q left in; sql-migrate manages the transaction", m.Id, strings.TrimSpace(s))
+			}
+		}
+	}
+
+	// Every migration can be rolled back except the URL to key conversion.
+	for _, m := range found {
+		if len(m.Down) == 0 && m.Id != "20261018102000_store_picture_keys" {
+			t.Errorf("%s has no down statements", m.Id)
+		}
+	}
+}
+
+func TestWithoutTransaction(t *testing.T) {
+	got := withoutTransaction([]string{"BEGIN;\n", "ALTER TABLE a ADD b INT;\n", "commit ;", "CREATE INDEX c ON a(b);"})
+	want := []string{"ALTER TABLE a ADD b INT;\n", "CREATE INDEX c ON a(b);"}
+
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("withoutTransaction = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,44 @@
+package seeds
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Seed profiles. Admin only creates the first administrator; demo adds
+// fake locations, parents, toddlers and users and must never be run
+// against production data.
+const (
+	ProfileAdmin = "admin"
+	ProfileDemo  = "demo"
+)
+
+// CheckProfile reports whether profile is one Run knows.
+func CheckProfile(profile string) error {
+	if profile != ProfileAdmin && profile != ProfileDemo {
+		return fmt.Errorf("unknown seed profile %q (use %s or %s)", profile, ProfileAdmin, ProfileDemo)
+	}
+	return nil
+}
+
+// Run seeds the given profile.
+func Run(db *gorm.DB, profile string) error {
+	if err := CheckProfile(profile); err != nil {
+		return err
+	}
+
+	SeedAdmin(db)
+	if profile == ProfileDemo {
+		SeedDemo(db)
+	}
+	return nil
+}
+
+// SeedDemo fills an empty database with sample data for development.
+func SeedDemo(db *gorm.DB) {
+	locations := SeedLocations(db)
+	parents := SeedParents(db, locations)
+	SeedToddlers(db, locations, parents)
+	SeedUsers(db, locations)
+}
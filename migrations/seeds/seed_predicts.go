@@ -2,6 +2,7 @@ package seeds
 
 import (
 	"grovia/internal/models"
+	"grovia/pkg"
 	"math/rand"
 	"time"
 
@@ -22,6 +23,7 @@ func SeedPredictsForToddler(db *gorm.DB, toddlerID int) {
 			Sex:               toddler.Sex,         
 			Zscore:            float64(rand.Intn(200))/100 - 1,
 			NutritionalStatus: "normal",
+			Source:            pkg.PredictSourceManual,
 			CreatedAt:         time.Now().AddDate(0, 0, -i),
 		}
 
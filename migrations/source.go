@@ -0,0 +1,92 @@
+package migrations
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// pairedSource reads migrations written as <id>.up.sql and <id>.down.sql
+// file pairs. sql-migrate expects one file per migration holding both
+// directions; read as-is, every down file would become a migration of
+// its own and be applied on `migrate up`.
+type pairedSource struct {
+	fs   fs.FS
+	root string
+}
+
+// FindMigrations implements migrate.MigrationSource.
+func (p pairedSource) FindMigrations() ([]*migrate.Migration, error) {
+	entries, err := fs.ReadDir(p.fs, p.root)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := map[string]*migrate.Migration{}
+	for _, entry := range entries {
+		name := entry.Name()
+
+		var id, direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			id, direction = strings.TrimSuffix(name, ".up.sql"), "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			id, direction = strings.TrimSuffix(name, ".down.sql"), "down"
+		default:
+			continue
+		}
+
+		data, err := fs.ReadFile(p.fs, path.Join(p.root, name))
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := migrate.ParseMigration(name, bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byID[id]
+		if !ok {
+			m = &migrate.Migration{Id: id}
+			byID[id] = m
+		}
+		if direction == "up" {
+			m.Up = withoutTransaction(parsed.Up)
+			m.DisableTransactionUp = parsed.DisableTransactionUp
+		} else {
+			m.Down = withoutTransaction(parsed.Down)
+			m.DisableTransactionDown = parsed.DisableTransactionDown
+		}
+	}
+
+	migrations := make([]*migrate.Migration, 0, len(byID))
+	for id, m := range byID {
+		if len(m.Up) == 0 {
+			return nil, fmt.Errorf("migration %s has no up statements", id)
+		}
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Less(migrations[j]) })
+
+	return migrations, nil
+}
+
+// withoutTransaction drops the BEGIN and COMMIT the files carry for use
+// with psql. sql-migrate runs each migration in a transaction of its own,
+// together with the row that records it.
+func withoutTransaction(statements []string) []string {
+	kept := statements[:0:0]
+	for _, s := range statements {
+		switch strings.ToUpper(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), ";"))) {
+		case "BEGIN", "COMMIT":
+			continue
+		}
+		kept = append(kept, s)
+	}
+	return kept
+}
@@ -1,4 +1,4 @@
--- +migrate Up
+-- +migrate Down
 
 BEGIN;
 
@@ -0,0 +1,5 @@
+-- +migrate Down
+
+ALTER TABLE predicts
+DROP COLUMN who_zscore,
+DROP COLUMN who_nutritional_status;
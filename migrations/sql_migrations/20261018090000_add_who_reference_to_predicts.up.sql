@@ -0,0 +1,5 @@
+-- +migrate Up
+
+ALTER TABLE predicts
+ADD COLUMN who_zscore DECIMAL(4,1),
+ADD COLUMN who_nutritional_status VARCHAR(50);
@@ -0,0 +1,21 @@
+-- +migrate Down
+
+BEGIN;
+
+ALTER TABLE predicts
+DROP COLUMN weight,
+DROP COLUMN head_circumference,
+DROP COLUMN wfa_zscore,
+DROP COLUMN wfa_status,
+DROP COLUMN wfh_zscore,
+DROP COLUMN wfh_status,
+DROP COLUMN bfa_zscore,
+DROP COLUMN bfa_status,
+DROP COLUMN hcfa_zscore,
+DROP COLUMN hcfa_status;
+
+ALTER TABLE toddlers
+DROP COLUMN weight,
+DROP COLUMN head_circumference;
+
+COMMIT;
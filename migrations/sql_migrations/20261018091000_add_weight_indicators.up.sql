@@ -0,0 +1,21 @@
+-- +migrate Up
+
+BEGIN;
+
+ALTER TABLE toddlers
+ADD COLUMN weight DECIMAL(4,1),
+ADD COLUMN head_circumference DECIMAL(4,1);
+
+ALTER TABLE predicts
+ADD COLUMN weight DECIMAL(4,1),
+ADD COLUMN head_circumference DECIMAL(4,1),
+ADD COLUMN wfa_zscore DECIMAL(4,1),
+ADD COLUMN wfa_status VARCHAR(50),
+ADD COLUMN wfh_zscore DECIMAL(4,1),
+ADD COLUMN wfh_status VARCHAR(50),
+ADD COLUMN bfa_zscore DECIMAL(4,1),
+ADD COLUMN bfa_status VARCHAR(50),
+ADD COLUMN hcfa_zscore DECIMAL(4,1),
+ADD COLUMN hcfa_status VARCHAR(50);
+
+COMMIT;
@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE group_predict_previews(
+    id SERIAL PRIMARY KEY,
+    token UUID NOT NULL UNIQUE,
+    location_id INT NOT NULL,
+    created_by_id INT NOT NULL,
+    file_name VARCHAR(255),
+    file BYTEA NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    confirmed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_group_predict_previews_location FOREIGN KEY (location_id) REFERENCES locations(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT fk_group_predict_previews_created_by FOREIGN KEY (created_by_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX idx_group_predict_previews_expires_at ON group_predict_previews(expires_at);
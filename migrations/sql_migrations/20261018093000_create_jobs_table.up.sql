@@ -0,0 +1,28 @@
+-- +migrate Up
+CREATE TABLE jobs(
+    id SERIAL PRIMARY KEY,
+    type VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('queued', 'running', 'succeeded', 'failed')),
+    location_id INT NOT NULL,
+    created_by_id INT NOT NULL,
+    payload JSONB,
+    input BYTEA,
+    progress INT NOT NULL DEFAULT 0,
+    total INT NOT NULL DEFAULT 0,
+    result JSONB,
+    result_file BYTEA,
+    result_file_name VARCHAR(255),
+    error TEXT,
+    attempts INT NOT NULL DEFAULT 0,
+    heartbeat_at TIMESTAMPTZ,
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_jobs_location FOREIGN KEY (location_id) REFERENCES locations(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT fk_jobs_created_by FOREIGN KEY (created_by_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX idx_jobs_status_id ON jobs(status, id);
+CREATE INDEX idx_jobs_location_id ON jobs(location_id);
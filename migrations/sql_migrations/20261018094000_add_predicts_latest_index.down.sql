@@ -0,0 +1,3 @@
+-- +migrate Down
+DROP INDEX IF EXISTS idx_predicts_location_created_at;
+DROP INDEX IF EXISTS idx_predicts_toddler_created_at;
@@ -0,0 +1,8 @@
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS idx_predicts_toddler_created_at
+ON predicts(toddler_id, created_at DESC)
+WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_predicts_location_created_at
+ON predicts(location_id, created_at)
+WHERE deleted_at IS NULL;
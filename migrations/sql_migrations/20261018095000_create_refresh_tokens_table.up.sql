@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE refresh_tokens(
+    id SERIAL PRIMARY KEY,
+    jti UUID NOT NULL UNIQUE,
+    family_id UUID NOT NULL,
+    user_id INT NOT NULL,
+    device VARCHAR(255),
+    expires_at TIMESTAMPTZ NOT NULL,
+    replaced_by UUID,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_refresh_tokens_user FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens(user_id);
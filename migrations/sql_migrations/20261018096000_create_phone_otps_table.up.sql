@@ -0,0 +1,12 @@
+-- +migrate Up
+CREATE TABLE phone_otps(
+    id SERIAL PRIMARY KEY,
+    phone_number VARCHAR(100) NOT NULL,
+    code_hash VARCHAR(100) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    consumed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_phone_otps_phone_number ON phone_otps(phone_number, created_at DESC);
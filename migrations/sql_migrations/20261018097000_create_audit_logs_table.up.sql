@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE audit_logs(
+    id BIGSERIAL PRIMARY KEY,
+    actor_id INT,
+    actor_role VARCHAR(100),
+    actor_location_id INT,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('create', 'update', 'delete')),
+    entity VARCHAR(50) NOT NULL,
+    entity_id INT NOT NULL,
+    changes JSONB,
+    ip_address VARCHAR(100),
+    user_agent VARCHAR(255),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_logs_entity ON audit_logs(entity, entity_id, created_at DESC);
+CREATE INDEX idx_audit_logs_actor_id ON audit_logs(actor_id, created_at DESC);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at DESC);
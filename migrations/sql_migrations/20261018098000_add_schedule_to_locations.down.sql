@@ -0,0 +1,9 @@
+-- +migrate Down
+
+BEGIN;
+
+ALTER TABLE locations
+DROP COLUMN schedule_day,
+DROP COLUMN schedule_time;
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- +migrate Up
+
+BEGIN;
+
+ALTER TABLE locations
+ADD COLUMN schedule_day SMALLINT CHECK (schedule_day BETWEEN 1 AND 31),
+ADD COLUMN schedule_time VARCHAR(5);
+
+COMMIT;
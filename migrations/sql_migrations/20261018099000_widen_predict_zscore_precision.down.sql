@@ -0,0 +1,13 @@
+-- +migrate Down
+
+BEGIN;
+
+ALTER TABLE predicts
+ALTER COLUMN zscore TYPE DECIMAL(4,1),
+ALTER COLUMN who_zscore TYPE DECIMAL(4,1),
+ALTER COLUMN wfa_zscore TYPE DECIMAL(4,1),
+ALTER COLUMN wfh_zscore TYPE DECIMAL(4,1),
+ALTER COLUMN bfa_zscore TYPE DECIMAL(4,1),
+ALTER COLUMN hcfa_zscore TYPE DECIMAL(4,1);
+
+COMMIT;
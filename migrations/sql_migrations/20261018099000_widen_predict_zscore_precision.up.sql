@@ -0,0 +1,13 @@
+-- +migrate Up
+
+BEGIN;
+
+ALTER TABLE predicts
+ALTER COLUMN zscore TYPE DECIMAL(5,2),
+ALTER COLUMN who_zscore TYPE DECIMAL(5,2),
+ALTER COLUMN wfa_zscore TYPE DECIMAL(5,2),
+ALTER COLUMN wfh_zscore TYPE DECIMAL(5,2),
+ALTER COLUMN bfa_zscore TYPE DECIMAL(5,2),
+ALTER COLUMN hcfa_zscore TYPE DECIMAL(5,2);
+
+COMMIT;
@@ -0,0 +1,11 @@
+-- +migrate Down
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_predicts_job_row;
+
+ALTER TABLE predicts
+DROP COLUMN job_id,
+DROP COLUMN job_row;
+
+COMMIT;
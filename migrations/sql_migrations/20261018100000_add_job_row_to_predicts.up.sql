@@ -0,0 +1,11 @@
+-- +migrate Up
+
+BEGIN;
+
+ALTER TABLE predicts
+ADD COLUMN job_id INT REFERENCES jobs(id) ON UPDATE CASCADE ON DELETE SET NULL,
+ADD COLUMN job_row INT;
+
+CREATE UNIQUE INDEX idx_predicts_job_row ON predicts(job_id, job_row) WHERE job_id IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,12 @@
+-- +migrate Down
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_phone_otps_phone_number;
+
+ALTER TABLE phone_otps
+DROP COLUMN purpose;
+
+CREATE INDEX idx_phone_otps_phone_number ON phone_otps(phone_number, created_at DESC);
+
+COMMIT;
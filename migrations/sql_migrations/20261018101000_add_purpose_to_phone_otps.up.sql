@@ -0,0 +1,15 @@
+-- +migrate Up
+
+BEGIN;
+
+-- Codes live for minutes; outstanding ones cannot be attributed to a
+-- purpose, so they are dropped rather than guessed.
+DELETE FROM phone_otps;
+
+ALTER TABLE phone_otps
+ADD COLUMN purpose VARCHAR(20) NOT NULL CHECK (purpose IN ('password_reset', 'parent_login'));
+
+DROP INDEX IF EXISTS idx_phone_otps_phone_number;
+CREATE INDEX idx_phone_otps_phone_number ON phone_otps(phone_number, purpose, created_at DESC);
+
+COMMIT;
@@ -0,0 +1,5 @@
+-- +migrate Down
+
+-- The bucket and host the URLs were built from are configuration, not
+-- data, so the keys are left as they are. The code before this migration
+-- cannot read them back as URLs.
@@ -0,0 +1,20 @@
+-- +migrate Up
+
+BEGIN;
+
+-- Pictures used to be saved as unsigned URLs, either S3 virtual-hosted
+-- addresses or /api/files/ addresses of the local driver. Keep only the
+-- object key; responses sign it on the way out.
+UPDATE toddlers
+SET profile_picture = split_part(regexp_replace(profile_picture, '^https?://[^/]+/(api/files/)?', ''), '?', 1)
+WHERE profile_picture ~ '^https?://[^/]+\.amazonaws\.com/' OR profile_picture ~ '^https?://[^/]+/api/files/';
+
+UPDATE users
+SET profile_picture = split_part(regexp_replace(profile_picture, '^https?://[^/]+/(api/files/)?', ''), '?', 1)
+WHERE profile_picture ~ '^https?://[^/]+\.amazonaws\.com/' OR profile_picture ~ '^https?://[^/]+/api/files/';
+
+UPDATE locations
+SET picture = split_part(regexp_replace(picture, '^https?://[^/]+/(api/files/)?', ''), '?', 1)
+WHERE picture ~ '^https?://[^/]+\.amazonaws\.com/' OR picture ~ '^https?://[^/]+/api/files/';
+
+COMMIT;
@@ -0,0 +1,15 @@
+-- +migrate Down
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_predicts_model_version;
+
+ALTER TABLE predicts
+DROP CONSTRAINT IF EXISTS chk_predicts_source,
+DROP COLUMN source,
+DROP COLUMN model_name,
+DROP COLUMN model_version,
+DROP COLUMN ml_response,
+DROP COLUMN ml_latency_ms;
+
+COMMIT;
@@ -0,0 +1,29 @@
+-- +migrate Up
+
+BEGIN;
+
+ALTER TABLE predicts
+ADD COLUMN source VARCHAR(10),
+ADD COLUMN model_name VARCHAR(100),
+ADD COLUMN model_version VARCHAR(50),
+ADD COLUMN ml_response JSONB,
+ADD COLUMN ml_latency_ms INT;
+
+-- Older rows did not record their source. A status equal to the WHO
+-- reference was most likely computed by the WHO engine, in "who" mode or
+-- as the fallback in "both" mode; everything else came from the ML API.
+UPDATE predicts
+SET source = CASE
+    WHEN who_zscore IS NOT NULL
+        AND zscore = who_zscore
+        AND nutritional_status = who_nutritional_status THEN 'who'
+    ELSE 'ml'
+END;
+
+ALTER TABLE predicts
+ALTER COLUMN source SET NOT NULL,
+ADD CONSTRAINT chk_predicts_source CHECK (source IN ('ml', 'who', 'manual'));
+
+CREATE INDEX idx_predicts_model_version ON predicts(model_version) WHERE model_version IS NOT NULL;
+
+COMMIT;
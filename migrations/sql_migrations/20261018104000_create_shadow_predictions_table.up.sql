@@ -0,0 +1,26 @@
+-- +migrate Up
+
+BEGIN;
+
+CREATE TABLE shadow_predictions(
+    id SERIAL PRIMARY KEY,
+    predict_id INT NOT NULL REFERENCES predicts(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    model_name VARCHAR(100),
+    model_version VARCHAR(50),
+    zscore DECIMAL(5,2),
+    nutritional_status VARCHAR(50),
+    ml_response JSONB,
+    ml_latency_ms INT,
+    error TEXT,
+    primary_source VARCHAR(10) NOT NULL CHECK (primary_source IN ('ml', 'who', 'manual')),
+    primary_zscore DECIMAL(5,2) NOT NULL,
+    primary_nutritional_status VARCHAR(50) NOT NULL,
+    who_zscore DECIMAL(5,2),
+    who_nutritional_status VARCHAR(50),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_shadow_predictions_predict_id ON shadow_predictions(predict_id);
+CREATE INDEX idx_shadow_predictions_created_at ON shadow_predictions(created_at DESC);
+
+COMMIT;
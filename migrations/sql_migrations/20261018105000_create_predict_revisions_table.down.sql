@@ -0,0 +1,7 @@
+-- +migrate Down
+
+BEGIN;
+
+DROP TABLE IF EXISTS predict_revisions;
+
+COMMIT;
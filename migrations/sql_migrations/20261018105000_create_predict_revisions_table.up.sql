@@ -0,0 +1,28 @@
+-- +migrate Up
+
+BEGIN;
+
+CREATE TABLE predict_revisions(
+    id SERIAL PRIMARY KEY,
+    predict_id INT NOT NULL REFERENCES predicts(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    edited_by_id INT NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    measurement_changed BOOLEAN NOT NULL,
+    status_overridden BOOLEAN NOT NULL,
+    reason TEXT,
+    height DECIMAL(4,1) NOT NULL,
+    weight DECIMAL(4,1),
+    head_circumference DECIMAL(4,1),
+    age INT NOT NULL,
+    sex VARCHAR(20) NOT NULL,
+    zscore DECIMAL(5,2) NOT NULL,
+    nutritional_status VARCHAR(50) NOT NULL,
+    source VARCHAR(10) NOT NULL,
+    model_name VARCHAR(100),
+    model_version VARCHAR(50),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_predict_revisions_reason CHECK (NOT status_overridden OR reason IS NOT NULL)
+);
+
+CREATE INDEX idx_predict_revisions_predict_id ON predict_revisions(predict_id, id);
+
+COMMIT;
@@ -0,0 +1,13 @@
+-- +migrate Down
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_predicts_session_id;
+
+ALTER TABLE predicts
+DROP COLUMN session_id;
+
+DROP TABLE IF EXISTS session_attendances;
+DROP TABLE IF EXISTS sessions;
+
+COMMIT;
@@ -0,0 +1,39 @@
+-- +migrate Up
+
+BEGIN;
+
+CREATE TABLE sessions(
+    id SERIAL PRIMARY KEY,
+    location_id INT NOT NULL REFERENCES locations(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    date DATE NOT NULL,
+    status VARCHAR(10) NOT NULL CHECK (status IN ('open', 'closed')),
+    opened_by_id INT NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    closed_by_id INT REFERENCES users(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    opened_at TIMESTAMPTZ NOT NULL,
+    closed_at TIMESTAMPTZ,
+    notes TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_sessions_closed CHECK ((status = 'closed') = (closed_at IS NOT NULL))
+);
+
+-- One session per location and day, and at most one open at a time.
+CREATE UNIQUE INDEX idx_sessions_location_date ON sessions(location_id, date);
+CREATE UNIQUE INDEX idx_sessions_location_open ON sessions(location_id) WHERE status = 'open';
+
+CREATE TABLE session_attendances(
+    id SERIAL PRIMARY KEY,
+    session_id INT NOT NULL REFERENCES sessions(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    toddler_id INT NOT NULL REFERENCES toddlers(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    recorded_by_id INT REFERENCES users(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_session_attendances_toddler ON session_attendances(session_id, toddler_id);
+
+ALTER TABLE predicts
+ADD COLUMN session_id INT REFERENCES sessions(id) ON UPDATE CASCADE ON DELETE SET NULL;
+
+CREATE INDEX idx_predicts_session_id ON predicts(session_id) WHERE session_id IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,8 @@
+-- +migrate Down
+
+BEGIN;
+
+DROP TABLE IF EXISTS immunizations;
+DROP TABLE IF EXISTS vaccines;
+
+COMMIT;
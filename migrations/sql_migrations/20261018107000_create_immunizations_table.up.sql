@@ -0,0 +1,67 @@
+-- +migrate Up
+
+BEGIN;
+
+CREATE TABLE vaccines(
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(20) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    antigen VARCHAR(50) NOT NULL,
+    dose INT NOT NULL,
+    min_age_months INT NOT NULL,
+    overdue_age_months INT NOT NULL,
+    basic BOOLEAN NOT NULL DEFAULT FALSE,
+    sort_order INT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_vaccines_ages CHECK (min_age_months >= 0 AND overdue_age_months > min_age_months)
+);
+
+-- The national routine schedule for children under two. Basic doses are
+-- the ones counted for imunisasi dasar lengkap (IDL) before the first
+-- birthday.
+INSERT INTO vaccines (code, name, antigen, dose, min_age_months, overdue_age_months, basic, sort_order) VALUES
+    ('HB0', 'Hepatitis B 0 (HB0)', 'HB0', 1, 0, 1, TRUE, 1),
+    ('BCG', 'BCG', 'BCG', 1, 1, 2, TRUE, 2),
+    ('OPV-1', 'Polio tetes 1', 'OPV', 1, 1, 2, TRUE, 3),
+    ('DPT-HB-HIB-1', 'DPT-HB-Hib 1', 'DPT-HB-Hib', 1, 2, 3, TRUE, 4),
+    ('OPV-2', 'Polio tetes 2', 'OPV', 2, 2, 3, TRUE, 5),
+    ('PCV-1', 'PCV 1', 'PCV', 1, 2, 3, FALSE, 6),
+    ('RV-1', 'Rotavirus 1', 'RV', 1, 2, 3, FALSE, 7),
+    ('DPT-HB-HIB-2', 'DPT-HB-Hib 2', 'DPT-HB-Hib', 2, 3, 4, TRUE, 8),
+    ('OPV-3', 'Polio tetes 3', 'OPV', 3, 3, 4, TRUE, 9),
+    ('PCV-2', 'PCV 2', 'PCV', 2, 3, 4, FALSE, 10),
+    ('RV-2', 'Rotavirus 2', 'RV', 2, 3, 4, FALSE, 11),
+    ('DPT-HB-HIB-3', 'DPT-HB-Hib 3', 'DPT-HB-Hib', 3, 4, 5, TRUE, 12),
+    ('OPV-4', 'Polio tetes 4', 'OPV', 4, 4, 5, TRUE, 13),
+    ('IPV-1', 'Polio suntik (IPV) 1', 'IPV', 1, 4, 5, TRUE, 14),
+    ('RV-3', 'Rotavirus 3', 'RV', 3, 4, 5, FALSE, 15),
+    ('MR-1', 'Campak-Rubela (MR) 1', 'MR', 1, 9, 10, TRUE, 16),
+    ('IPV-2', 'Polio suntik (IPV) 2', 'IPV', 2, 9, 10, FALSE, 17),
+    ('PCV-3', 'PCV 3', 'PCV', 3, 12, 13, FALSE, 18),
+    ('DPT-HB-HIB-4', 'DPT-HB-Hib lanjutan', 'DPT-HB-Hib', 4, 18, 19, FALSE, 19),
+    ('MR-2', 'Campak-Rubela (MR) lanjutan', 'MR', 2, 18, 19, FALSE, 20);
+
+CREATE TABLE immunizations(
+    id SERIAL PRIMARY KEY,
+    toddler_id INT NOT NULL REFERENCES toddlers(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    vaccine_id INT NOT NULL REFERENCES vaccines(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    location_id INT NOT NULL REFERENCES locations(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    session_id INT REFERENCES sessions(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    given_at DATE NOT NULL,
+    batch_number VARCHAR(50),
+    notes TEXT,
+    recorded_by_id INT NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    deleted_by_id INT REFERENCES users(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+-- A dose is recorded once per toddler; a deleted record can be entered again.
+CREATE UNIQUE INDEX idx_immunizations_toddler_vaccine ON immunizations(toddler_id, vaccine_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_immunizations_location_id ON immunizations(location_id);
+CREATE INDEX idx_immunizations_session_id ON immunizations(session_id) WHERE session_id IS NOT NULL;
+CREATE INDEX idx_immunizations_deleted_at ON immunizations(deleted_at);
+
+COMMIT;
@@ -0,0 +1,57 @@
+package pkg
+
+import "context"
+
+// AccessScope is what part of the data a request may touch. It is derived
+// once from the JWT and applied by the repositories, so there is a single
+// rule for cross-location access. A parent's scope is further limited to
+// their own children.
+type AccessScope struct {
+	UserID       int    `json:"userId"`
+	ParentID     int    `json:"parentId,omitempty"`
+	Role         string `json:"role"`
+	LocationID   int    `json:"locationId"`
+	AllLocations bool   `json:"allLocations"`
+}
+
+func NewAccessScope(userID int, role string, locationID int) AccessScope {
+	return AccessScope{
+		UserID:       userID,
+		Role:         role,
+		LocationID:   locationID,
+		AllLocations: HasPermission(role, PermissionLocationAccessAll),
+	}
+}
+
+// NewParentAccessScope is the scope of a parent's token: the parent's
+// location, and within it only their own children.
+func NewParentAccessScope(parentID, locationID int) AccessScope {
+	return AccessScope{
+		ParentID:   parentID,
+		Role:       RoleParent,
+		LocationID: locationID,
+	}
+}
+
+// LocationScope limits access to one location. It is used for work the
+// server does on behalf of a record, e.g. updating a toddler after a
+// prediction was stored for it.
+func LocationScope(locationID int) AccessScope {
+	return AccessScope{LocationID: locationID}
+}
+
+// CanAccessLocation reports whether records of the location are in scope.
+func (s AccessScope) CanAccessLocation(locationID int) bool {
+	return s.AllLocations || s.LocationID == locationID
+}
+
+type accessScopeKey struct{}
+
+func WithAccessScope(ctx context.Context, scope AccessScope) context.Context {
+	return context.WithValue(ctx, accessScopeKey{}, scope)
+}
+
+func AccessScopeFromContext(ctx context.Context) (AccessScope, bool) {
+	scope, ok := ctx.Value(accessScopeKey{}).(AccessScope)
+	return scope, ok
+}
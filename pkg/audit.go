@@ -0,0 +1,17 @@
+package pkg
+
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+const (
+	AuditEntityToddler      = "toddler"
+	AuditEntityParent       = "parent"
+	AuditEntityPredict      = "predict"
+	AuditEntityUser         = "user"
+	AuditEntityLocation     = "location"
+	AuditEntitySession      = "session"
+	AuditEntityImmunization = "immunization"
+)
@@ -66,4 +66,35 @@ func NewUnprocessableEntityError(message string) *CustomError {
 		Code:       "UNPROCESSABLE_ENTITY",
 		Message:    message,
 	}
-}
\ No newline at end of file
+}
+func NewTooManyRequestsError(message string) *CustomError {
+	return &CustomError{
+		StatusCode: http.StatusTooManyRequests,
+		Code:       "TOO_MANY_REQUESTS",
+		Message:    message,
+	}
+}
+
+func NewBadGatewayError(message string) *CustomError {
+	return &CustomError{
+		StatusCode: http.StatusBadGateway,
+		Code:       "BAD_GATEWAY",
+		Message:    message,
+	}
+}
+
+func NewServiceUnavailableError(message string) *CustomError {
+	return &CustomError{
+		StatusCode: http.StatusServiceUnavailable,
+		Code:       "SERVICE_UNAVAILABLE",
+		Message:    message,
+	}
+}
+
+func NewGatewayTimeoutError(message string) *CustomError {
+	return &CustomError{
+		StatusCode: http.StatusGatewayTimeout,
+		Code:       "GATEWAY_TIMEOUT",
+		Message:    message,
+	}
+}
@@ -0,0 +1,6 @@
+package pkg
+
+// Job types handled by the background workers.
+const (
+	JobGroupPredict = "group_predict"
+)
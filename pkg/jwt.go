@@ -10,51 +10,76 @@ import (
 
 var jwtKey = []byte(os.Getenv("JWT_SECRET"))
 
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+
+	AccessTokenTTL  = 24 * time.Hour
+	RefreshTokenTTL = 7 * 24 * time.Hour
+)
+
+var ErrWrongTokenType = errors.New("wrong token type")
+
 type JWTClaim struct {
 	UserID     int    `json:"user_id"`
+	ParentID   int    `json:"parent_id,omitempty"`
 	LocationID int    `json:"location_id"`
 	Role       string `json:"role"`
+	TokenType  string `json:"token_type"`
 	jwt.RegisteredClaims
 }
 
-func GenerateJWT(userID, locationID int, role string) (accessToken, refreshToken string, err error) {
-	accessClaims := &JWTClaim{
+func GenerateAccessToken(userID, locationID int, role string) (string, error) {
+	claims := &JWTClaim{
 		UserID:     userID,
 		Role:       role,
 		LocationID: locationID,
+		TokenType:  TokenTypeAccess,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenTTL)),
 			Issuer:    "grovia-api",
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	at := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	accessToken, err = at.SignedString(jwtKey)
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(jwtKey)
+}
 
-	if err != nil {
-		return
+// GenerateParentAccessToken signs an access token for a parent. Parents
+// get no refresh token; they sign in again with a new verification code.
+func GenerateParentAccessToken(parentID, locationID int) (string, error) {
+	claims := &JWTClaim{
+		ParentID:   parentID,
+		Role:       RoleParent,
+		LocationID: locationID,
+		TokenType:  TokenTypeAccess,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenTTL)),
+			Issuer:    "grovia-api",
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
 	}
 
-	refreshClaims := &JWTClaim{
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(jwtKey)
+}
+
+// GenerateRefreshToken signs a refresh token whose jti identifies the
+// server-side record that makes it usable.
+func GenerateRefreshToken(userID, locationID int, role, jti string, expiresAt time.Time) (string, error) {
+	claims := &JWTClaim{
 		UserID:     userID,
 		Role:       role,
 		LocationID: locationID,
+		TokenType:  TokenTypeRefresh,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(7 * 24 * time.Hour)),
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			Issuer:    "grovia-api",
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	rt := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
-	refreshToken, err = rt.SignedString(jwtKey)
-
-	if err != nil {
-		return
-	}
-
-	return accessToken, refreshToken, nil
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(jwtKey)
 }
 
 func ValidateToken(signedToken string) (*JWTClaim, error) {
@@ -80,3 +105,19 @@ func ValidateToken(signedToken string) (*JWTClaim, error) {
 
 	return claims, nil
 }
+
+// ValidateTokenType validates the token and checks it was issued as the
+// given type, so a refresh token cannot be used as an access token and
+// the other way around.
+func ValidateTokenType(signedToken, tokenType string) (*JWTClaim, error) {
+	claims, err := ValidateToken(signedToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.TokenType != tokenType {
+		return nil, ErrWrongTokenType
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,112 @@
+package pkg
+
+// Permissions are named <resource>:<action>. Routes require permissions,
+// never roles, so what a role may do is decided only by RolePermissions.
+const (
+	PermissionLocationCreate  = "location:create"
+	PermissionLocationView    = "location:view"
+	PermissionLocationViewAll = "location:view_all"
+	PermissionLocationUpdate  = "location:update"
+	PermissionLocationDelete  = "location:delete"
+	// PermissionLocationAccessAll lifts the location scope on every
+	// resource; see AccessScope.
+	PermissionLocationAccessAll = "location:access_all"
+
+	PermissionParentCreate  = "parent:create"
+	PermissionParentView    = "parent:view"
+	PermissionParentViewAll = "parent:view_all"
+	PermissionParentUpdate  = "parent:update"
+	PermissionParentDelete  = "parent:delete"
+
+	PermissionToddlerCreate  = "toddler:create"
+	PermissionToddlerView    = "toddler:view"
+	PermissionToddlerViewAll = "toddler:view_all"
+	PermissionToddlerUpdate  = "toddler:update"
+	PermissionToddlerDelete  = "toddler:delete"
+
+	PermissionPredictCreate  = "predict:create"
+	PermissionPredictView    = "predict:view"
+	PermissionPredictViewAll = "predict:view_all"
+	PermissionPredictUpdate  = "predict:update"
+	PermissionPredictDelete  = "predict:delete"
+
+	PermissionJobView = "job:view"
+
+	PermissionSessionView   = "session:view"
+	PermissionSessionManage = "session:manage"
+
+	PermissionImmunizationView   = "immunization:view"
+	PermissionImmunizationManage = "immunization:manage"
+
+	PermissionReportView = "report:view"
+
+	PermissionUserCreate = "user:create"
+	PermissionUserView   = "user:view"
+	PermissionUserUpdate = "user:update"
+	PermissionUserDelete = "user:delete"
+
+	PermissionPermissionView = "permission:view"
+
+	PermissionAuditView = "audit:view"
+
+	// PermissionChildView is the read-only access of a parent to their
+	// own children.
+	PermissionChildView = "child:view"
+)
+
+// Permissions lists the catalogue in display order.
+var Permissions = []string{
+	PermissionLocationCreate, PermissionLocationView, PermissionLocationViewAll, PermissionLocationUpdate, PermissionLocationDelete, PermissionLocationAccessAll,
+	PermissionParentCreate, PermissionParentView, PermissionParentViewAll, PermissionParentUpdate, PermissionParentDelete,
+	PermissionToddlerCreate, PermissionToddlerView, PermissionToddlerViewAll, PermissionToddlerUpdate, PermissionToddlerDelete,
+	PermissionPredictCreate, PermissionPredictView, PermissionPredictViewAll, PermissionPredictUpdate, PermissionPredictDelete,
+	PermissionJobView,
+	PermissionSessionView, PermissionSessionManage,
+	PermissionImmunizationView, PermissionImmunizationManage,
+	PermissionReportView,
+	PermissionUserCreate, PermissionUserView, PermissionUserUpdate, PermissionUserDelete,
+	PermissionPermissionView,
+	PermissionAuditView,
+	PermissionChildView,
+}
+
+// Roles lists the roles in display order.
+var Roles = []string{RoleAdmin, RoleKepalaPosyandu, RoleKader, RoleParent}
+
+// RolePermissions maps each role to what it may do. Which users a
+// kepala posyandu may manage is further limited by the user service.
+var RolePermissions = map[string][]string{
+	RoleAdmin: Permissions,
+	RoleKepalaPosyandu: {
+		PermissionLocationView,
+		PermissionParentCreate, PermissionParentView, PermissionParentUpdate, PermissionParentDelete,
+		PermissionToddlerCreate, PermissionToddlerView, PermissionToddlerUpdate, PermissionToddlerDelete,
+		PermissionPredictCreate, PermissionPredictView, PermissionPredictUpdate, PermissionPredictDelete,
+		PermissionJobView,
+		PermissionSessionView, PermissionSessionManage,
+		PermissionImmunizationView, PermissionImmunizationManage,
+		PermissionReportView,
+		PermissionUserCreate, PermissionUserView, PermissionUserUpdate, PermissionUserDelete,
+	},
+	RoleKader: {
+		PermissionLocationView,
+		PermissionParentCreate, PermissionParentView, PermissionParentUpdate,
+		PermissionToddlerCreate, PermissionToddlerView, PermissionToddlerUpdate,
+		PermissionPredictCreate, PermissionPredictView, PermissionPredictUpdate,
+		PermissionJobView,
+		PermissionSessionView, PermissionSessionManage,
+		PermissionImmunizationView, PermissionImmunizationManage,
+	},
+	RoleParent: {
+		PermissionChildView,
+	},
+}
+
+func HasPermission(role, permission string) bool {
+	for _, p := range RolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,18 @@
+package pkg
+
+// Predictor selects how height-for-age status is determined: by the
+// external ML API, by the built-in WHO growth standard engine, or by the
+// ML API with the WHO engine as reference and fallback.
+const (
+	PredictorML   = "ml"
+	PredictorWHO  = "who"
+	PredictorBoth = "both"
+)
+
+// PredictSource records what determined the height-for-age status of a
+// predict: the ML API, the WHO growth standard engine, or a person.
+const (
+	PredictSourceML     = "ml"
+	PredictSourceWHO    = "who"
+	PredictSourceManual = "manual"
+)
@@ -0,0 +1,20 @@
+package pkg
+
+import "context"
+
+// RequestMeta describes where a request came from, for the audit log.
+type RequestMeta struct {
+	IPAddress string
+	UserAgent string
+}
+
+type requestMetaKey struct{}
+
+func WithRequestMeta(ctx context.Context, meta RequestMeta) context.Context {
+	return context.WithValue(ctx, requestMetaKey{}, meta)
+}
+
+func RequestMetaFromContext(ctx context.Context) (RequestMeta, bool) {
+	meta, ok := ctx.Value(requestMetaKey{}).(RequestMeta)
+	return meta, ok
+}
@@ -4,4 +4,7 @@ const (
 	RoleAdmin          = "admin"
 	RoleKepalaPosyandu = "kepala_posyandu"
 	RoleKader          = "kader"
+	// RoleParent is held by orang tua signing in with their phone number.
+	// It is not a user role; the token carries a parent ID instead.
+	RoleParent = "parent"
 )
@@ -0,0 +1,10 @@
+package pkg
+
+// StorageDriver selects where uploaded files are kept. The local and
+// memory drivers serve files through the API with signed URLs and are
+// meant for development and tests.
+const (
+	StorageDriverS3     = "s3"
+	StorageDriverLocal  = "local"
+	StorageDriverMemory = "memory"
+)
@@ -16,6 +16,8 @@ func init() {
 	validate.RegisterValidation("nik", validateNik)
 	validate.RegisterValidation("height", validateHeight)
 	validate.RegisterValidation("age", validateAge)
+	validate.RegisterValidation("weight", validateWeight)
+	validate.RegisterValidation("headcircumference", validateHeadCircumference)
 }
 
 func GetValidator() *validator.Validate {
@@ -62,6 +64,10 @@ func formatFieldError(err validator.FieldError) string {
 		return fmt.Sprintf("%s harus dalam rentang 30cm - 120cm", field)
 	case "age":
 		return fmt.Sprintf("%s harus tidak boleh lebih dari 60 bulan", field)
+	case "weight":
+		return fmt.Sprintf("%s harus dalam rentang 1kg - 40kg", field)
+	case "headcircumference":
+		return fmt.Sprintf("%s harus dalam rentang 25cm - 60cm", field)
 	case "oneof":
 		return fmt.Sprintf("%s harus salah satu dari: %s", field, err.Param())
 	case "numeric":
@@ -118,7 +124,7 @@ func validateHeight(fl validator.FieldLevel) bool {
 		return true
 	}
 
-	return height <= 30 && height >= 120
+	return height >= 30 && height <= 120
 }
 
 func validateAge(fl validator.FieldLevel) bool {
@@ -127,5 +133,23 @@ func validateAge(fl validator.FieldLevel) bool {
 		return true
 	}
 
-	return age <= 0 && age >= 60
+	return age >= 0 && age <= 60
+}
+
+func validateWeight(fl validator.FieldLevel) bool {
+	weight := fl.Field().Float()
+	if weight == 0 {
+		return true
+	}
+
+	return weight >= 1 && weight <= 40
+}
+
+func validateHeadCircumference(fl validator.FieldLevel) bool {
+	head := fl.Field().Float()
+	if head == 0 {
+		return true
+	}
+
+	return head >= 25 && head <= 60
 }
@@ -0,0 +1,22 @@
+package pkg
+
+// PhoneVerifier selects how a phone number is proven before a password
+// reset: a Firebase phone-auth ID token, or an OTP issued by this server.
+const (
+	PhoneVerifierFirebase = "firebase"
+	PhoneVerifierOTP      = "otp"
+)
+
+// SMSGateway selects how OTP codes are delivered. The console gateway
+// only logs the code and is meant for development.
+const (
+	SMSGatewayConsole = "console"
+	SMSGatewayHTTP    = "http"
+)
+
+// OTPPurpose binds a verification code to the flow that requested it, so a
+// code sent for a password reset cannot sign a parent in, and vice versa.
+const (
+	OTPPurposePasswordReset = "password_reset"
+	OTPPurposeParentLogin   = "parent_login"
+)